@@ -0,0 +1,779 @@
+package orvyn
+
+import (
+	"io"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// App holds the whole state of an orvyn program: registered screens, active
+// screen, dialog, theme and window size. It implements tea.Model, so it can be
+// given directly to tea.NewProgram or embedded inside a larger Bubble Tea model.
+//
+// The package level functions (Update, Render, SwitchScreen, ...) operate on a
+// default App, which is what screens and widgets written against them use.
+type App struct {
+	// ProcessExit determines if the App should manage the global exit keybind.
+	ProcessExit bool
+
+	// ExitKeybind to manage global exit.
+	ExitKeybind key.Binding
+
+	// HelpKeybind toggles the help overlay, listing the bindings of the active
+	// screen or dialog and the global ones. The overlay only opens when some
+	// KeyMap is declared for them, and not while they are in input mode.
+	// "?" by default.
+	HelpKeybind key.Binding
+
+	// WindowSize hold the size of the Window.
+	WindowSize Size
+
+	// Bindings holds the keys of the actions, see LoadBindings.
+	Bindings *Bindings
+
+	// StartScreenID is the screen switched to by Init. Nothing happens on Init
+	// when empty.
+	StartScreenID ScreenID
+
+	// TickFunc creates the tea.Cmd returned by TickCmd. tea.Tick by default, it
+	// can be replaced to drive the ticks from a fake clock in tests, see
+	// orvyntest.
+	TickFunc func(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd
+
+	// screens is the map holding all Screen that are registered in the App.
+	screens map[ScreenID]Screen
+
+	// screenStack holds the navigation stack. The last ScreenID is the active
+	// screen, the first one is the root.
+	screenStack []ScreenID
+
+	// previousScreenID holds the previously active ScreenID.
+	previousScreenID ScreenID
+
+	// dialogs holds the open dialogs. The last one is on top and is the only
+	// one receiving the messages.
+	dialogs []*dialog
+
+	// mouseRoot is the layout receiving the mouse, the top dialog one or the
+	// screen one, placed at mouseOrigin on the screen as of the last Render.
+	mouseRoot   Renderable
+	mouseOrigin Position
+
+	// popup is the open popup, nil if none. See OpenPopup.
+	popup *popup
+
+	// keyMaps holds the KeyMaps registered per screen or dialog, the global
+	// ones under the empty ScreenID.
+	keyMaps map[ScreenID][]KeyMap
+
+	// helpVisible is true while the help overlay is shown.
+	helpVisible bool
+
+	activeTheme theme.Theme
+}
+
+// NewApp creates and returns a new *App ready to register screens.
+func NewApp() *App {
+	a := new(App)
+
+	a.Bindings = NewBindings()
+	a.ExitKeybind = a.Bindings.Binding(QuitAction)
+	a.HelpKeybind = a.Bindings.Binding(HelpAction)
+	a.ProcessExit = true
+	a.WindowSize = NewSize(100, 100)
+	a.TickFunc = tea.Tick
+	a.screens = make(map[ScreenID]Screen)
+	a.screenStack = make([]ScreenID, 0)
+	a.dialogs = make([]*dialog, 0)
+	a.keyMaps = make(map[ScreenID][]KeyMap)
+	a.activeTheme = theme.NewDefaultDarkTheme()
+
+	return a
+}
+
+// Init implements tea.Model. Switches to StartScreenID if defined.
+func (a *App) Init() tea.Cmd {
+	if a.StartScreenID == "" {
+		return nil
+	}
+
+	return a.SwitchScreen(a.StartScreenID)
+}
+
+// Update implements tea.Model. Manages the global messages and forwards the
+// message to the active dialog or screen.
+func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return a, a.update(msg)
+}
+
+// View implements tea.Model. See Render.
+func (a *App) View() string {
+	return a.Render()
+}
+
+func (a *App) update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, a.ExitKeybind):
+			if a.ProcessExit {
+				return tea.Quit
+			}
+
+		case a.helpVisible:
+			// Any key closes the help overlay.
+			a.helpVisible = false
+			return nil
+
+		case key.Matches(msg, a.HelpKeybind):
+			if a.canShowHelp() {
+				a.helpVisible = true
+				return nil
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		a.WindowSize.Width = msg.Width
+		a.WindowSize.Height = msg.Height
+
+	case ThemeChangedMsg:
+		return a.broadcast(msg)
+
+	case tea.MouseMsg:
+		if a.helpVisible {
+			return nil
+		}
+
+		return a.forward(a.hitTest(msg))
+	}
+
+	return a.forward(msg)
+}
+
+// forward gives the message to the top dialog, or to the active screen.
+func (a *App) forward(msg tea.Msg) tea.Cmd {
+	if a.currentScreenID() == "" {
+		return nil
+	}
+
+	if d := a.topDialog(); d != nil {
+		return d.screen.Update(msg)
+	}
+
+	return a.screens[a.currentScreenID()].Update(msg)
+}
+
+// broadcast gives the message to every registered screen and open dialog,
+// then to every element of their layouts.
+func (a *App) broadcast(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	screens := make([]Screen, 0, len(a.screens)+len(a.dialogs))
+
+	for _, id := range slices.Sorted(maps.Keys(a.screens)) {
+		screens = append(screens, a.screens[id])
+	}
+
+	for _, d := range a.dialogs {
+		screens = append(screens, d.screen)
+	}
+
+	for _, screen := range screens {
+		cmds = append(cmds, screen.Update(msg))
+
+		if layout := screen.Render(); layout != nil {
+			cmds = append(cmds, Broadcast(layout, msg))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// hitTest wraps the tea.MouseMsg in a MouseMsg holding the renderables under
+// the pointer. Only the top dialog is hit-tested while a dialog is open, the
+// open popup first.
+func (a *App) hitTest(msg tea.MouseMsg) MouseMsg {
+	m := MouseMsg{MouseMsg: msg}
+
+	if hits, ok := a.hitPopup(NewPosition(msg.X, msg.Y)); ok {
+		m.Hits = hits
+	} else if a.mouseRoot != nil {
+		m.Hits = HitTest(a.mouseRoot, a.mouseOrigin, NewPosition(msg.X, msg.Y))
+	}
+
+	return m
+}
+
+// Render returns the view of the active screen, with every open dialog drawn
+// over it, clipped to the window size.
+func (a *App) Render() string {
+	if a.currentScreenID() == "" {
+		return "Orvyn : No Current Screen"
+	}
+
+	layout := a.screens[a.currentScreenID()].Render()
+
+	a.mouseRoot = layout
+	a.mouseOrigin = NewPosition(0, 0)
+
+	if layout == nil && len(a.dialogs) == 0 {
+		return ""
+	}
+
+	view := a.clip(a.renderLayout(layout, a.WindowSize))
+
+	for _, d := range a.dialogs {
+		view = a.renderDialog(d, view)
+	}
+
+	view = a.renderPopup(view)
+
+	if a.helpVisible {
+		view = a.renderHelp(view)
+	}
+
+	return view
+}
+
+// renderLayout resizes and renders the given layout, an empty string for a nil
+// layout.
+func (a *App) renderLayout(layout Layout, size Size) string {
+	if layout == nil {
+		return ""
+	}
+
+	layout.Resize(size)
+
+	return layout.Render()
+}
+
+// clip cuts the view to the window. A layout can legitimately render taller
+// than the space it was given - widgets have a minimal height they cannot go
+// under - and emitting more lines than the terminal has makes the terminal
+// scroll, which silently eats the *top* of the screen. Cutting the overflow off
+// the bottom keeps the top anchored where the user expects it.
+func (a *App) clip(view string) string {
+	return lipgloss.NewStyle().
+		MaxWidth(a.WindowSize.Width).
+		MaxHeight(a.WindowSize.Height).
+		Render(view)
+}
+
+// renderDialog draws the dialog at its preferred size, framed with the theme
+// DialogStyleID and centered over the dimmed background.
+func (a *App) renderDialog(d *dialog, background string) string {
+	layout := d.screen.Render()
+
+	// A dialog without layout still takes the mouse from the screen under it.
+	a.mouseRoot = nil
+
+	if layout == nil {
+		return background
+	}
+
+	frame := a.activeTheme.Style(theme.DialogStyleID)
+
+	size := layout.GetPreferredSize()
+	size.Width = min(size.Width, a.WindowSize.Width-frame.GetHorizontalFrameSize())
+	size.Height = min(size.Height, a.WindowSize.Height-frame.GetVerticalFrameSize())
+	size.Width = max(size.Width, 0)
+	size.Height = max(size.Height, 0)
+
+	view := a.clip(frame.Render(a.renderLayout(layout, size)))
+
+	x, y, view := a.placeOverDimmed(view, background)
+
+	a.mouseRoot = layout
+	a.mouseOrigin = NewPosition(
+		x+frame.GetBorderLeftSize()+frame.GetPaddingLeft()+frame.GetMarginLeft(),
+		y+frame.GetBorderTopSize()+frame.GetPaddingTop()+frame.GetMarginTop())
+
+	return view
+}
+
+// placeOverDimmed centers the view over the dimmed background and returns
+// where it was placed with the result.
+func (a *App) placeOverDimmed(view, background string) (int, int, string) {
+	// The background is brought to the full window size so the view is
+	// centered on the window and not on what the screen happened to render.
+	background = lipgloss.Place(a.WindowSize.Width, a.WindowSize.Height,
+		lipgloss.Left, lipgloss.Top,
+		DimView(background, a.activeTheme.Style(theme.DimmedBackgroundStyleID)))
+
+	width, height := lipgloss.Size(view)
+	x := (a.WindowSize.Width - width) / 2
+	y := (a.WindowSize.Height - height) / 2
+
+	return x, y, a.clip(PlaceOverlay(x, y, view, background))
+}
+
+// Theme
+
+// GetTheme returns the active theme of the App.
+func (a *App) GetTheme() theme.Theme {
+	return a.activeTheme
+}
+
+// TickCmd returns the tea.Cmd sending a TickMsg with the tag after the given
+// seconds, created by TickFunc. See the package level TickCmd.
+func (a *App) TickCmd(seconds time.Duration, tag uint) tea.Cmd {
+	return a.TickFunc(seconds*time.Second, func(t time.Time) tea.Msg {
+		return TickMsg{
+			Time: t,
+			Tag:  tag,
+		}
+	})
+}
+
+// SetTheme changes the active theme of the App. The returned tea.Cmd sends
+// the ThemeChangedMsg restyling the existing widgets.
+func (a *App) SetTheme(theme theme.Theme) tea.Cmd {
+	a.activeTheme = theme
+
+	return themeChangedCmd(theme)
+}
+
+// Bindings
+
+// LoadBindings loads the binding overrides of the user from a JSON object,
+// the only format supported, see Bindings. ExitKeybind and HelpKeybind are
+// updated, the screens and widgets get the overrides when they are created:
+// load them first.
+func (a *App) LoadBindings(r io.Reader) error {
+	if err := a.Bindings.Load(r); err != nil {
+		return err
+	}
+
+	a.updateGlobalKeybinds()
+
+	return nil
+}
+
+// LoadBindingsFile loads the binding overrides from the named JSON file, see
+// LoadBindings. A file without the .json extension is refused with
+// ErrBindingsFormat.
+func (a *App) LoadBindingsFile(name string) error {
+	if err := a.Bindings.LoadFile(name); err != nil {
+		return err
+	}
+
+	a.updateGlobalKeybinds()
+
+	return nil
+}
+
+// updateGlobalKeybinds reads the keybinds handled by the App from Bindings.
+func (a *App) updateGlobalKeybinds() {
+	a.ExitKeybind = a.Bindings.Binding(QuitAction)
+	a.HelpKeybind = a.Bindings.Binding(HelpAction)
+}
+
+// Key maps
+
+// RegisterKeyMap declares a KeyMap for the screen or dialog of the given
+// ScreenID, shown by the help bar and the help overlay while it is active. An
+// empty ScreenID declares global bindings. A screen or dialog implementing
+// KeyMap is taken into account without registration.
+func (a *App) RegisterKeyMap(id ScreenID, km KeyMap) {
+	a.keyMaps[id] = append(a.keyMaps[id], km)
+}
+
+// ShortHelp implements KeyMap. Returns the short help of the active dialog or
+// screen, then the global one.
+func (a *App) ShortHelp() []key.Binding {
+	var bindings []key.Binding
+
+	for _, km := range a.contextKeyMaps() {
+		bindings = append(bindings, km.ShortHelp()...)
+	}
+
+	for _, km := range a.keyMaps[""] {
+		bindings = append(bindings, km.ShortHelp()...)
+	}
+
+	if a.canShowHelp() {
+		bindings = append(bindings, a.HelpKeybind)
+	}
+
+	return HelpBindings(bindings)
+}
+
+// FullHelp implements KeyMap. Returns a column per KeyGroups section.
+func (a *App) FullHelp() [][]key.Binding {
+	groups := a.KeyGroups()
+	columns := make([][]key.Binding, 0, len(groups))
+
+	for _, g := range groups {
+		columns = append(columns, g.Bindings)
+	}
+
+	return columns
+}
+
+// KeyGroups implements KeyGroupsProvider. Returns the sections of the active
+// dialog or screen, titled after its ScreenID by default, then the global
+// ones.
+func (a *App) KeyGroups() []KeyGroup {
+	var groups []KeyGroup
+
+	id := a.contextID()
+
+	for _, km := range a.contextKeyMaps() {
+		groups = mergeKeyGroups(groups, KeyGroupsOf(km, string(id))...)
+	}
+
+	for _, km := range a.keyMaps[""] {
+		groups = mergeKeyGroups(groups, KeyGroupsOf(km, "Global")...)
+	}
+
+	global := []key.Binding{a.HelpKeybind}
+
+	if a.ProcessExit {
+		global = append(global, a.ExitKeybind)
+	}
+
+	return mergeKeyGroups(groups, KeyGroup{"Global", HelpBindings(global)})
+}
+
+// IsInputting returns true if a KeyMap of the active dialog or screen, or the
+// screen itself, reports being in input mode with an IsInputting method, as
+// the FocusManager does.
+func (a *App) IsInputting() bool {
+	for _, km := range a.contextKeyMaps() {
+		if i, ok := km.(interface{ IsInputting() bool }); ok && i.IsInputting() {
+			return true
+		}
+	}
+
+	if i, ok := a.contextScreen().(interface{ IsInputting() bool }); ok {
+		return i.IsInputting()
+	}
+
+	return false
+}
+
+// ShowHelp shows or hides the help overlay.
+func (a *App) ShowHelp(show bool) {
+	a.helpVisible = show
+}
+
+// IsHelpVisible returns true while the help overlay is shown.
+func (a *App) IsHelpVisible() bool {
+	return a.helpVisible
+}
+
+// Screen management
+
+// RegisterScreen allows to register a Screen with the given ScreenID.
+func (a *App) RegisterScreen(id ScreenID, screen Screen) {
+	a.screens[id] = screen
+}
+
+// SwitchScreen change the currently active screen and called OnExit and OnEnter.
+// The OnExit value of the current screen is given to OnEnter of the new one.
+// The switched screen replaces the top of the navigation stack, see ReplaceScreen.
+func (a *App) SwitchScreen(id ScreenID) tea.Cmd {
+	return a.ReplaceScreen(id)
+}
+
+// SwitchToPreviousScreen switches back to the previously active screen.
+func (a *App) SwitchToPreviousScreen() tea.Cmd {
+	if a.previousScreenID == "" {
+		return nil
+	}
+
+	return a.SwitchScreen(a.previousScreenID)
+}
+
+// SetPreviousScreen overrides the previously active ScreenID.
+func (a *App) SetPreviousScreen(id ScreenID) {
+	a.previousScreenID = id
+}
+
+// GetPreviousScreen returns the previously active ScreenID.
+func (a *App) GetPreviousScreen() ScreenID {
+	return a.previousScreenID
+}
+
+// GetScreen returns the Screen for the given registered ScreenID.
+func (a *App) GetScreen(id ScreenID) Screen {
+	screen, ok := a.screens[id]
+
+	if !ok {
+		return nil
+	}
+
+	return screen
+}
+
+// GetCurrentScreenID returns the currently active ScreenID.
+func (a *App) GetCurrentScreenID() ScreenID {
+	return a.currentScreenID()
+}
+
+// Navigation stack
+
+// PushScreen puts the screen with the given ScreenID on top of the navigation
+// stack and calls its OnEnter with the given param. The covered screen is not
+// exited: it stays as it is until the pushed screen is popped, and then
+// receives a ScreenResultMsg.
+func (a *App) PushScreen(id ScreenID, param any) tea.Cmd {
+	a.mustHaveScreen(id)
+
+	a.previousScreenID = a.currentScreenID()
+
+	a.screenStack = append(a.screenStack, id)
+
+	return a.screens[id].OnEnter(param)
+}
+
+// PopScreen removes the active screen from the navigation stack and calls its
+// OnExit. The value returned by OnExit is delivered to the screen underneath
+// as a ScreenResultMsg. Does nothing when the active screen is the root.
+func (a *App) PopScreen() tea.Cmd {
+	if len(a.screenStack) <= 1 {
+		return nil
+	}
+
+	id := a.currentScreenID()
+	param := a.screens[id].OnExit()
+
+	a.screenStack = a.screenStack[:len(a.screenStack)-1]
+	a.previousScreenID = id
+
+	return screenResultCmd(id, param)
+}
+
+// PopToRoot removes every screen above the root of the navigation stack,
+// calling their OnExit from the top down. The root receives a ScreenResultMsg
+// holding the OnExit value of the screen that was active.
+func (a *App) PopToRoot() tea.Cmd {
+	if len(a.screenStack) <= 1 {
+		return nil
+	}
+
+	id := a.currentScreenID()
+	param := a.screens[id].OnExit()
+
+	for i := len(a.screenStack) - 2; i > 0; i-- {
+		a.screens[a.screenStack[i]].OnExit()
+	}
+
+	a.screenStack = a.screenStack[:1]
+	a.previousScreenID = id
+
+	return screenResultCmd(id, param)
+}
+
+// ReplaceScreen replaces the active screen with the one of the given ScreenID,
+// keeping the rest of the navigation stack. The OnExit value of the replaced
+// screen is given to OnEnter of the new one. On an empty stack, the screen
+// becomes the root.
+func (a *App) ReplaceScreen(id ScreenID) tea.Cmd {
+	var param any
+
+	a.mustHaveScreen(id)
+
+	current := a.currentScreenID()
+
+	if current != "" {
+		param = a.screens[current].OnExit()
+		a.screenStack = a.screenStack[:len(a.screenStack)-1]
+	}
+
+	a.previousScreenID = current
+
+	a.screenStack = append(a.screenStack, id)
+
+	return a.screens[id].OnEnter(param)
+}
+
+// ScreenStackDepth returns the number of screens in the navigation stack.
+func (a *App) ScreenStackDepth() int {
+	return len(a.screenStack)
+}
+
+// GetScreenStack returns a copy of the navigation stack, from the root to the
+// active screen. Useful to render breadcrumbs.
+func (a *App) GetScreenStack() []ScreenID {
+	return slices.Clone(a.screenStack)
+}
+
+// currentScreenID returns the ScreenID on top of the navigation stack, or an
+// empty ScreenID when the stack is empty.
+func (a *App) currentScreenID() ScreenID {
+	if len(a.screenStack) == 0 {
+		return ""
+	}
+
+	return a.screenStack[len(a.screenStack)-1]
+}
+
+// mustHaveScreen stops the program when no Screen is registered for the given
+// ScreenID. Navigating to an unknown screen is a programming error.
+func (a *App) mustHaveScreen(id ScreenID) {
+	if _, ok := a.screens[id]; !ok {
+		log.Fatalf("Orvyn : Screen with ID %s does not exist", id)
+	}
+}
+
+// Dialog API
+
+// OpenDialog opens the given dialog screen on top of the current screen and of
+// the dialogs already open. Only the topmost dialog receives the messages.
+func (a *App) OpenDialog(dialogID ScreenID, dialogScreen Screen, param any) tea.Cmd {
+	d := new(dialog)
+
+	d.dialogID = dialogID
+	d.screen = dialogScreen
+
+	a.dialogs = append(a.dialogs, d)
+
+	return d.screen.OnEnter(param)
+}
+
+// CloseDialog closes the topmost dialog, giving back the messages to the
+// dialog or screen underneath, and sends a DialogExitMsg holding the dialog
+// OnExit value.
+func (a *App) CloseDialog() tea.Cmd {
+	d := a.topDialog()
+
+	if d == nil {
+		return nil
+	}
+
+	param := d.screen.OnExit()
+
+	a.dialogs = a.dialogs[:len(a.dialogs)-1]
+
+	return dialogExitCmd(d.dialogID, param)
+}
+
+// DialogStackDepth returns the number of open dialogs.
+func (a *App) DialogStackDepth() int {
+	return len(a.dialogs)
+}
+
+// contextID returns the ScreenID of the top dialog, or of the active screen.
+func (a *App) contextID() ScreenID {
+	if d := a.topDialog(); d != nil {
+		return d.dialogID
+	}
+
+	return a.currentScreenID()
+}
+
+// contextScreen returns the top dialog, or the active screen, nil if none.
+func (a *App) contextScreen() Screen {
+	if d := a.topDialog(); d != nil {
+		return d.screen
+	}
+
+	if a.currentScreenID() == "" {
+		return nil
+	}
+
+	return a.screens[a.currentScreenID()]
+}
+
+// contextKeyMaps returns the KeyMaps of the top dialog, or of the active
+// screen: the screen itself if it is one, then the registered ones.
+func (a *App) contextKeyMaps() []KeyMap {
+	var kms []KeyMap
+
+	screen := a.contextScreen()
+
+	if screen == nil {
+		return nil
+	}
+
+	if km, ok := screen.(KeyMap); ok {
+		kms = append(kms, km)
+	}
+
+	return append(kms, a.keyMaps[a.contextID()]...)
+}
+
+// canShowHelp returns true if the help overlay has something to list and the
+// active dialog or screen is not in input mode, where "?" is text.
+func (a *App) canShowHelp() bool {
+	if !a.HelpKeybind.Enabled() || a.IsInputting() {
+		return false
+	}
+
+	return len(a.contextKeyMaps()) > 0 || len(a.keyMaps[""]) > 0
+}
+
+// renderHelp draws the help overlay over the view: every KeyGroups section,
+// flowed in columns fitting the window height, framed with the theme
+// DialogStyleID.
+func (a *App) renderHelp(background string) string {
+	// The help takes the mouse, it is closed by any key.
+	a.mouseRoot = nil
+
+	frame := a.activeTheme.Style(theme.DialogStyleID)
+	keyStyle := a.activeTheme.Style(theme.HelpKeyStyleID)
+	descStyle := a.activeTheme.Style(theme.HelpDescStyleID)
+	titleStyle := a.activeTheme.Style(theme.TitleStyleID)
+
+	maxHeight := max(a.WindowSize.Height-frame.GetVerticalFrameSize(), 1)
+
+	var columns []string
+	var column []string
+
+	for _, g := range a.KeyGroups() {
+		keyWidth := 0
+
+		for _, b := range g.Bindings {
+			keyWidth = max(keyWidth, lipgloss.Width(b.Help().Key))
+		}
+
+		lines := []string{titleStyle.Render(g.Title)}
+
+		for _, b := range g.Bindings {
+			lines = append(lines, keyStyle.Width(keyWidth).Render(b.Help().Key)+"  "+
+				descStyle.Render(b.Help().Desc))
+		}
+
+		if len(column) > 0 && len(column)+1+len(lines) > maxHeight {
+			columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, column...))
+			column = nil
+		}
+
+		if len(column) > 0 {
+			column = append(column, "")
+		}
+
+		column = append(column, lines...)
+	}
+
+	if len(column) > 0 {
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, column...))
+	}
+
+	for i := range columns[:max(len(columns)-1, 0)] {
+		columns[i] = lipgloss.NewStyle().PaddingRight(4).Render(columns[i])
+	}
+
+	view := a.clip(frame.Render(lipgloss.JoinHorizontal(lipgloss.Top, columns...)))
+
+	_, _, view = a.placeOverDimmed(view, background)
+
+	return view
+}
+
+// topDialog returns the topmost open dialog, nil when there is none.
+func (a *App) topDialog() *dialog {
+	if len(a.dialogs) == 0 {
+		return nil
+	}
+
+	return a.dialogs[len(a.dialogs)-1]
+}
@@ -0,0 +1,333 @@
+package orvyn
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// stubScreen records the messages it receives and renders a fixed text.
+type stubScreen struct {
+	text     string
+	received []tea.Msg
+	entered  any
+}
+
+func (s *stubScreen) OnEnter(param any) tea.Cmd {
+	s.entered = param
+	return nil
+}
+
+func (s *stubScreen) OnExit() any {
+	return s.text
+}
+
+func (s *stubScreen) Update(msg tea.Msg) tea.Cmd {
+	s.received = append(s.received, msg)
+	return nil
+}
+
+func (s *stubScreen) Render() Layout {
+	return &stubLayout{BaseLayout: NewBaseLayout(NewSimpleRenderable(s.text))}
+}
+
+type stubLayout struct {
+	BaseLayout
+}
+
+func (l *stubLayout) Render() string {
+	return l.GetElements()[0].Render()
+}
+
+// Two App instances must not share any state.
+func TestAppsAreIndependent(t *testing.T) {
+	a := NewApp()
+	b := NewApp()
+
+	sa := &stubScreen{text: "a"}
+	sb := &stubScreen{text: "b"}
+
+	a.RegisterScreen("main", sa)
+	b.RegisterScreen("main", sb)
+
+	a.SwitchScreen("main")
+	b.SwitchScreen("main")
+
+	a.Update(tea.WindowSizeMsg{Width: 20, Height: 5})
+
+	if a.WindowSize != NewSize(20, 5) {
+		t.Errorf("a.WindowSize = %v, want {20 5}", a.WindowSize)
+	}
+
+	if b.WindowSize == a.WindowSize {
+		t.Errorf("b.WindowSize followed a: %v", b.WindowSize)
+	}
+
+	if len(sb.received) != 0 {
+		t.Errorf("screen of b received %d messages sent to a", len(sb.received))
+	}
+
+	if got := a.View(); got != "a" {
+		t.Errorf("a.View() = %q, want %q", got, "a")
+	}
+
+	if got := b.View(); got != "b" {
+		t.Errorf("b.View() = %q, want %q", got, "b")
+	}
+}
+
+// The package level functions keep working on the default App, including the
+// exported variables that predate App.
+func TestDefaultAppWrappers(t *testing.T) {
+	Init()
+
+	s := &stubScreen{text: "default"}
+
+	RegisterScreen("main", s)
+	SwitchScreen("main")
+
+	Update(tea.WindowSizeMsg{Width: 30, Height: 4})
+
+	if WindowSize != NewSize(30, 4) {
+		t.Errorf("WindowSize = %v, want {30 4}", WindowSize)
+	}
+
+	if DefaultApp().GetCurrentScreenID() != "main" {
+		t.Errorf("default App current screen = %q, want main", DefaultApp().GetCurrentScreenID())
+	}
+
+	ProcessExit = false
+
+	if cmd := Update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd != nil {
+		t.Errorf("exit keybind handled while ProcessExit is false")
+	}
+}
+
+// runCmd executes a tea.Cmd and returns its message, nil for a nil tea.Cmd.
+func runCmd(cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	return cmd()
+}
+
+// Going back twice from Main -> Settings -> Detail must land on Main, and each
+// uncovered screen must receive the result of the popped one.
+func TestNavigationStackPushPop(t *testing.T) {
+	a := NewApp()
+
+	main := &stubScreen{text: "main"}
+	settings := &stubScreen{text: "settings"}
+	detail := &stubScreen{text: "detail"}
+
+	a.RegisterScreen("main", main)
+	a.RegisterScreen("settings", settings)
+	a.RegisterScreen("detail", detail)
+
+	a.SwitchScreen("main")
+	a.PushScreen("settings", 42)
+	a.PushScreen("detail", nil)
+
+	if settings.entered != 42 {
+		t.Errorf("settings OnEnter param = %v, want 42", settings.entered)
+	}
+
+	if got := a.GetScreenStack(); len(got) != 3 || got[0] != "main" || got[2] != "detail" {
+		t.Fatalf("stack = %v, want [main settings detail]", got)
+	}
+
+	msg := runCmd(a.PopScreen())
+
+	if a.GetCurrentScreenID() != "settings" {
+		t.Fatalf("current screen = %q after first pop, want settings", a.GetCurrentScreenID())
+	}
+
+	a.Update(msg)
+
+	if result, ok := GetScreenResult[string](settings.received[len(settings.received)-1], "detail"); !ok || result != "detail" {
+		t.Errorf("settings did not receive the detail result, got %v", settings.received)
+	}
+
+	a.Update(runCmd(a.PopScreen()))
+
+	if a.GetCurrentScreenID() != "main" {
+		t.Fatalf("current screen = %q after second pop, want main", a.GetCurrentScreenID())
+	}
+
+	if a.ScreenStackDepth() != 1 {
+		t.Errorf("depth = %d, want 1", a.ScreenStackDepth())
+	}
+
+	if cmd := a.PopScreen(); cmd != nil || a.GetCurrentScreenID() != "main" {
+		t.Errorf("popping the root must do nothing")
+	}
+}
+
+func TestNavigationStackPopToRootAndReplace(t *testing.T) {
+	a := NewApp()
+
+	for _, id := range []ScreenID{"main", "settings", "detail", "other"} {
+		a.RegisterScreen(id, &stubScreen{text: string(id)})
+	}
+
+	a.SwitchScreen("main")
+	a.PushScreen("settings", nil)
+	a.ReplaceScreen("other")
+
+	if got := a.GetScreenStack(); len(got) != 2 || got[1] != "other" {
+		t.Fatalf("stack = %v, want [main other]", got)
+	}
+
+	a.PushScreen("detail", nil)
+
+	msg := runCmd(a.PopToRoot())
+
+	if a.ScreenStackDepth() != 1 || a.GetCurrentScreenID() != "main" {
+		t.Fatalf("stack = %v, want [main]", a.GetScreenStack())
+	}
+
+	if result, ok := GetScreenResult[string](msg, "detail"); !ok || result != "detail" {
+		t.Errorf("PopToRoot message = %#v, want the detail result", msg)
+	}
+}
+
+// A dialog opened from another dialog must stack on it: only the topmost gets
+// the messages and closing it gives them back to the first one.
+func TestDialogStack(t *testing.T) {
+	a := NewApp()
+
+	main := &stubScreen{text: "screen"}
+	first := &stubScreen{text: "first"}
+	second := &stubScreen{text: "second"}
+
+	a.RegisterScreen("main", main)
+	a.SwitchScreen("main")
+
+	a.OpenDialog("first", first, nil)
+	a.OpenDialog("second", second, nil)
+
+	a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(second.received) != 1 || len(first.received) != 0 || len(main.received) != 0 {
+		t.Fatalf("messages received: main %d, first %d, second %d, want only second",
+			len(main.received), len(first.received), len(second.received))
+	}
+
+	msg := runCmd(a.CloseDialog())
+
+	exit, ok := msg.(DialogExitMsg)
+
+	if !ok || exit.DialogID != "second" || exit.Param != "second" {
+		t.Fatalf("CloseDialog message = %#v, want the exit of the second dialog", msg)
+	}
+
+	a.Update(msg)
+
+	if len(first.received) != 1 {
+		t.Errorf("first dialog did not receive the DialogExitMsg")
+	}
+
+	if a.DialogStackDepth() != 1 {
+		t.Errorf("dialog depth = %d, want 1", a.DialogStackDepth())
+	}
+}
+
+// The screen must stay visible behind a dialog, and the dialog must be
+// centered over it.
+func TestDialogRenderedAsOverlay(t *testing.T) {
+	a := NewApp()
+	a.WindowSize = NewSize(40, 11)
+
+	a.RegisterScreen("main", &stubScreen{text: "background screen"})
+	a.SwitchScreen("main")
+
+	a.OpenDialog("popup", &stubScreen{text: "popup"}, nil)
+
+	lines := strings.Split(ansi.Strip(a.Render()), "\n")
+
+	if len(lines) != 11 {
+		t.Fatalf("rendered %d lines, want the 11 of the window", len(lines))
+	}
+
+	if !strings.HasPrefix(lines[0], "background screen") {
+		t.Errorf("screen not visible behind the dialog: %q", lines[0])
+	}
+
+	if !strings.Contains(lines[5], "popup") {
+		t.Errorf("dialog not centered, middle line is %q", lines[5])
+	}
+}
+
+func TestPlaceOverlay(t *testing.T) {
+	background := "aaaaaa\nbbbbbb\ncc"
+
+	got := PlaceOverlay(2, 1, "XX\nYYY", background)
+	want := "aaaaaa\nbbXXbb\nccYYY"
+
+	if got != want {
+		t.Errorf("PlaceOverlay = %q, want %q", got, want)
+	}
+}
+
+// popupScreen renders a fixed layout holding the owner of a popup, on the
+// fifth row.
+type popupScreen struct {
+	stubScreen
+	layout *stubLayout
+}
+
+func newPopupScreen() *popupScreen {
+	s := new(popupScreen)
+
+	owner := NewSimpleRenderable("owner")
+	owner.Resize(NewSize(5, 1))
+
+	s.layout = &stubLayout{BaseLayout: NewBaseLayout(owner)}
+	s.layout.SetElementPosition(owner, NewPosition(0, 4))
+
+	return s
+}
+
+func (s *popupScreen) Render() Layout {
+	return s.layout
+}
+
+// A popup goes above its owner when there is more room there than under it,
+// and takes the clicks over it.
+func TestPopup(t *testing.T) {
+	a := NewApp()
+	a.WindowSize = NewSize(20, 6)
+
+	screen := newPopupScreen()
+
+	a.RegisterScreen("main", screen)
+	a.SwitchScreen("main")
+
+	owner := screen.layout.GetElements()[0]
+	content := NewSimpleRenderable("pop\nup")
+
+	a.OpenPopup(owner, content)
+
+	lines := strings.Split(ansi.Strip(a.Render()), "\n")
+
+	if !strings.HasPrefix(lines[2], "pop") || !strings.HasPrefix(lines[3], "up") {
+		t.Errorf("popup not above its owner:\n%s", strings.Join(lines, "\n"))
+	}
+
+	a.Update(tea.MouseMsg{X: 1, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+
+	m, ok := screen.received[len(screen.received)-1].(MouseMsg)
+
+	if !ok || !m.IsOver(owner) || m.Target() != content {
+		t.Errorf("click on the popup hit %v", m.Hits)
+	}
+
+	a.ClosePopup(owner)
+
+	if a.IsPopupOpen(owner) || strings.Contains(ansi.Strip(a.Render()), "pop") {
+		t.Error("popup still drawn after ClosePopup")
+	}
+}
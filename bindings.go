@@ -0,0 +1,347 @@
+package orvyn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// ActionID is the stable identifier of a key binding, made of a scope and an
+// action: "list.cursor_up" for example. Bindings are looked up by ActionID so
+// users can rebind them, see Bindings.
+type ActionID string
+
+// Actions of the orvyn package.
+const (
+	QuitAction          ActionID = "app.quit"
+	HelpAction          ActionID = "app.help"
+	NextFocusAction     ActionID = "focus.next"
+	PreviousFocusAction ActionID = "focus.previous"
+	ExitInputAction     ActionID = "input.exit"
+)
+
+// globalScope is the scope of the actions handled by the App before anything
+// else, which conflict with the actions of every scope.
+const globalScope = "app"
+
+// actions holds the default binding of every defined action.
+var actions = map[ActionID]key.Binding{
+	QuitAction: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+	HelpAction: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "help"),
+	),
+	NextFocusAction: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "next focus"),
+	),
+	PreviousFocusAction: key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "previous focus"),
+	),
+	ExitInputAction: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "exit input"),
+	),
+}
+
+// DefineAction defines the default binding of an action. Widget packages
+// define their actions in an init function, so every action is known when the
+// user bindings are loaded.
+//
+//	func init() {
+//		orvyn.DefineAction(CursorUpAction,
+//			key.WithKeys("up", "k"),
+//			key.WithHelp("↑/k", "up"))
+//	}
+func DefineAction(id ActionID, opts ...key.BindingOpt) {
+	if _, ok := actions[id]; ok {
+		log.Fatalf("Orvyn : Action %s is already defined", id)
+	}
+
+	actions[id] = key.NewBinding(opts...)
+}
+
+// GetActions returns the ActionID of every defined action, sorted.
+func GetActions() []ActionID {
+	return slices.Sorted(maps.Keys(actions))
+}
+
+// GetDefaultBinding returns the default binding of the action, false if the
+// action is not defined.
+func GetDefaultBinding(id ActionID) (key.Binding, bool) {
+	b, ok := actions[id]
+
+	return b, ok
+}
+
+// ErrBindingsFormat is returned by LoadFile for a file that is not a JSON one,
+// the only format supported for the bindings: TOML and YAML files included.
+var ErrBindingsFormat = errors.New("orvyn: bindings must be a JSON file")
+
+// ConflictError reports a key bound to several actions that are active at the
+// same time: actions of the same scope, or an "app" action and any other.
+type ConflictError struct {
+	Key     string
+	Actions []ActionID
+}
+
+func (e *ConflictError) Error() string {
+	ids := make([]string, len(e.Actions))
+
+	for i, id := range e.Actions {
+		ids[i] = string(id)
+	}
+
+	return fmt.Sprintf("orvyn: key %q is bound to %s", e.Key, strings.Join(ids, ", "))
+}
+
+// Bindings holds the keys of the actions, their defaults unless overridden.
+// Overrides are loaded from a JSON object, the only format supported, mapping
+// an ActionID to a key or a list of keys, an empty list disabling the action:
+//
+//	{
+//		"list.cursor_up": ["up", "ctrl+p"],
+//		"list.cursor_down": ["down", "ctrl+n"],
+//		"app.help": "f1",
+//		"table.sort": []
+//	}
+//
+// Widgets read their bindings when created, so the overrides must be loaded
+// before creating the screens.
+type Bindings struct {
+	overrides map[ActionID][]string
+}
+
+// NewBindings creates and returns a new *Bindings without overrides.
+func NewBindings() *Bindings {
+	b := new(Bindings)
+
+	b.overrides = make(map[ActionID][]string)
+
+	return b
+}
+
+// Binding returns the binding of the action: its default keys and help, or
+// the overriding keys. The program stops if the action is not defined.
+func (b *Bindings) Binding(id ActionID) key.Binding {
+	binding, ok := actions[id]
+
+	if !ok {
+		log.Fatalf("Orvyn : Action %s is not defined", id)
+	}
+
+	keys, ok := b.overrides[id]
+
+	if !ok {
+		return binding
+	}
+
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKey(keys), binding.Help().Desc),
+	)
+}
+
+// Keys returns the keys the action is bound to.
+func (b *Bindings) Keys(id ActionID) []string {
+	binding := b.Binding(id)
+
+	if !binding.Enabled() {
+		return nil
+	}
+
+	return binding.Keys()
+}
+
+// Set overrides the keys of the action, no key disabling it. The override is
+// refused if the action is not defined or if it makes a conflict.
+func (b *Bindings) Set(id ActionID, keys ...string) error {
+	return b.apply(map[ActionID][]string{id: keys})
+}
+
+// Reset removes every override.
+func (b *Bindings) Reset() {
+	b.overrides = make(map[ActionID][]string)
+}
+
+// Load reads overrides from a JSON object, see Bindings. Nothing is applied
+// if an action is not defined or if the overrides make conflicts: every
+// problem is reported in the returned error, the conflicts as ConflictError.
+func (b *Bindings) Load(r io.Reader) error {
+	var raw map[ActionID]json.RawMessage
+
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return fmt.Errorf("orvyn: reading bindings: %w", err)
+	}
+
+	overrides := make(map[ActionID][]string, len(raw))
+
+	var errs []error
+
+	for id, value := range raw {
+		var keys []string
+		var single string
+
+		if err := json.Unmarshal(value, &single); err == nil {
+			keys = []string{single}
+		} else if err := json.Unmarshal(value, &keys); err != nil {
+			errs = append(errs, fmt.Errorf("orvyn: %s: want a key or a list of keys", id))
+			continue
+		}
+
+		overrides[id] = keys
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return b.apply(overrides)
+}
+
+// LoadFile reads overrides from the named JSON file, see Load. A file without
+// the .json extension is refused with ErrBindingsFormat.
+func (b *Bindings) LoadFile(name string) error {
+	if !strings.EqualFold(filepath.Ext(name), ".json") {
+		return fmt.Errorf("%w: %s", ErrBindingsFormat, name)
+	}
+
+	f, err := os.Open(name)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return b.Load(f)
+}
+
+// Conflicts returns the conflicts of the current bindings, nil if none.
+func (b *Bindings) Conflicts() []*ConflictError {
+	return findConflicts(b.overrides)
+}
+
+// Hidden functions
+
+// apply adds the overrides if every action is defined and no conflict is
+// made.
+func (b *Bindings) apply(overrides map[ActionID][]string) error {
+	var errs []error
+
+	for _, id := range slices.Sorted(maps.Keys(overrides)) {
+		if _, ok := actions[id]; !ok {
+			errs = append(errs, fmt.Errorf("orvyn: unknown action %q", id))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	merged := maps.Clone(b.overrides)
+	maps.Copy(merged, overrides)
+
+	for _, c := range findConflicts(merged) {
+		errs = append(errs, c)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	b.overrides = merged
+
+	return nil
+}
+
+// findConflicts returns the keys bound to several actions of the same scope,
+// or to an "app" action and another one, with the given overrides.
+func findConflicts(overrides map[ActionID][]string) []*ConflictError {
+	var conflicts []*ConflictError
+
+	bound := make(map[string][]ActionID)
+
+	for _, id := range GetActions() {
+		keys, ok := overrides[id]
+
+		if !ok {
+			keys = nil
+
+			if actions[id].Enabled() {
+				keys = actions[id].Keys()
+			}
+		}
+
+		for _, k := range keys {
+			bound[k] = append(bound[k], id)
+		}
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(bound)) {
+		ids := bound[k]
+
+		var conflicting []ActionID
+
+		for i, a := range ids {
+			for j, b := range ids {
+				if i != j && conflict(a, b) {
+					conflicting = append(conflicting, a)
+					break
+				}
+			}
+		}
+
+		if len(conflicting) > 1 {
+			conflicts = append(conflicts, &ConflictError{k, conflicting})
+		}
+	}
+
+	return conflicts
+}
+
+// conflict returns true if both actions can be active at the same time.
+func conflict(a, b ActionID) bool {
+	sa, sb := scope(a), scope(b)
+
+	return sa == sb || sa == globalScope || sb == globalScope
+}
+
+// scope returns the part of the ActionID before the first dot.
+func scope(id ActionID) string {
+	s, _, _ := strings.Cut(string(id), ".")
+
+	return s
+}
+
+// helpKey returns the help text of the keys.
+func helpKey(keys []string) string {
+	names := make([]string, len(keys))
+
+	for i, k := range keys {
+		names[i] = k
+
+		if k == " " {
+			names[i] = "space"
+		}
+	}
+
+	return strings.Join(names, "/")
+}
@@ -0,0 +1,131 @@
+package orvyn
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLoadBindings(t *testing.T) {
+	a := NewApp()
+
+	err := a.LoadBindings(strings.NewReader(`{
+		"app.quit": "ctrl+q",
+		"focus.next": ["tab", "ctrl+n"],
+		"focus.previous": []
+	}`))
+
+	if err != nil {
+		t.Fatalf("LoadBindings: %v", err)
+	}
+
+	if help := a.ExitKeybind.Help(); help.Key != "ctrl+q" || help.Desc != "quit" {
+		t.Errorf("exit help = %+v, want ctrl+q quit", help)
+	}
+
+	if _, cmd := a.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd != nil {
+		t.Errorf("ctrl+c still quits")
+	}
+
+	next := a.Bindings.Binding(NextFocusAction)
+
+	if next.Help().Key != "tab/ctrl+n" || next.Help().Desc != "next focus" {
+		t.Errorf("next focus help = %+v, want tab/ctrl+n next focus", next.Help())
+	}
+
+	if a.Bindings.Binding(PreviousFocusAction).Enabled() {
+		t.Errorf("an empty list of keys must disable the action")
+	}
+
+	a.Bindings.Reset()
+
+	if keys := a.Bindings.Keys(QuitAction); len(keys) != 1 || keys[0] != "ctrl+c" {
+		t.Errorf("keys after Reset = %v, want the default ctrl+c", keys)
+	}
+}
+
+// A conflicting load is reported with every conflict and leaves the bindings
+// as they were.
+func TestLoadBindingsConflicts(t *testing.T) {
+	b := NewBindings()
+
+	if err := b.Set(HelpAction, "f1"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	err := b.Load(strings.NewReader(`{
+		"focus.previous": "tab",
+		"app.help": "esc"
+	}`))
+
+	var conflicts []*ConflictError
+
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var c *ConflictError
+
+		if errors.As(e, &c) {
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	if len(conflicts) != 2 {
+		t.Fatalf("got %d conflicts, want 2: %v", len(conflicts), err)
+	}
+
+	// The app actions conflict with the actions of any scope.
+	if c := conflicts[0]; c.Key != "esc" || len(c.Actions) != 2 || c.Actions[0] != HelpAction {
+		t.Errorf("first conflict = %v, want esc on app.help and input.exit", c)
+	}
+
+	if c := conflicts[1]; c.Key != "tab" || len(c.Actions) != 2 {
+		t.Errorf("second conflict = %v, want tab on both focus actions", c)
+	}
+
+	if keys := b.Keys(HelpAction); len(keys) != 1 || keys[0] != "f1" {
+		t.Errorf("help keys = %v after a refused load, want f1", keys)
+	}
+
+	if err := b.Set("app.unknown", "x"); err == nil {
+		t.Errorf("an unknown action was accepted")
+	}
+
+	if len(b.Conflicts()) != 0 {
+		t.Errorf("conflicts left after refused overrides: %v", b.Conflicts())
+	}
+}
+
+func TestLoadBindingsFileFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"keys.toml", "keys.yaml", "keys.yml", "keys"} {
+		path := filepath.Join(dir, name)
+
+		if err := os.WriteFile(path, []byte(`app.help = "f1"`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewApp().LoadBindingsFile(path); !errors.Is(err, ErrBindingsFormat) {
+			t.Errorf("%s: error %v, want ErrBindingsFormat", name, err)
+		}
+	}
+
+	path := filepath.Join(dir, "keys.JSON")
+
+	if err := os.WriteFile(path, []byte(`{"app.help": "f1"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewApp()
+
+	if err := a.LoadBindingsFile(path); err != nil {
+		t.Fatalf("LoadBindingsFile: %v", err)
+	}
+
+	if a.HelpKeybind.Help().Key != "f1" {
+		t.Errorf("help key = %q, want f1", a.HelpKeybind.Help().Key)
+	}
+}
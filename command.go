@@ -29,12 +29,7 @@ type TickMsg struct {
 //		}
 //	}
 func TickCmd(seconds time.Duration, tag uint) tea.Cmd {
-	return tea.Tick(seconds*time.Second, func(t time.Time) tea.Msg {
-		return TickMsg{
-			Time: t,
-			Tag:  tag,
-		}
-	})
+	return defaultApp.TickCmd(seconds, tag)
 }
 
 // DialogExitMsg is the message sent when an Orvyn dialog is exited.
@@ -51,3 +46,45 @@ func dialogExitCmd(id ScreenID, param any) tea.Cmd {
 		}
 	}
 }
+
+// ScreenResultMsg is the message sent to the uncovered screen when a screen
+// is popped from the navigation stack.
+type ScreenResultMsg struct {
+	// ScreenID of the popped screen.
+	ScreenID ScreenID
+
+	// Param holds the value returned by OnExit of the popped screen.
+	Param any
+}
+
+// GetScreenResult returns the param of a ScreenResultMsg sent by the screen of
+// the given ScreenID, typed as T. The bool is false when the message is not a
+// result of that screen or when the param is not a T.
+//
+//	func (s *MainScreen) Update(msg tea.Msg) tea.Cmd {
+//		if settings, ok := orvyn.GetScreenResult[Settings](msg, SettingsScreenID); ok {
+//			s.applySettings(settings)
+//		}
+//	}
+func GetScreenResult[T any](msg tea.Msg, id ScreenID) (T, bool) {
+	var none T
+
+	m, ok := msg.(ScreenResultMsg)
+
+	if !ok || m.ScreenID != id {
+		return none, false
+	}
+
+	param, ok := m.Param.(T)
+
+	return param, ok
+}
+
+func screenResultCmd(id ScreenID, param any) tea.Cmd {
+	return func() tea.Msg {
+		return ScreenResultMsg{
+			ScreenID: id,
+			Param:    param,
+		}
+	}
+}
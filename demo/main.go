@@ -1,23 +1,36 @@
 package main
 
 import (
+	"errors"
+	"io/fs"
 	"log"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/halsten-dev/orvyn"
 	"github.com/halsten-dev/orvyn/demo/screen"
+	"github.com/halsten-dev/orvyn/theme"
 )
 
 func main() {
 	// Orvyn
 	orvyn.Init()
+	orvyn.SetTheme(theme.ForTerminal(theme.NewAdaptiveTheme()))
+
+	// Optional user bindings, loaded before the screens create their widgets.
+	if err := orvyn.LoadBindingsFile("keys.json"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal(err)
+	}
 
 	orvyn.RegisterScreen(screen.MainMenuScreenID, screen.NewMainMenu())
 	orvyn.RegisterScreen(screen.ListDemoScreenID, screen.NewListDemo())
 	orvyn.RegisterScreen(screen.InputWidgetDemoScreenID, screen.NewInputWidgetDemo())
 	orvyn.RegisterScreen(screen.ProgressDemoScreenID, screen.NewProgressDemo())
+	orvyn.RegisterScreen(screen.FormDemoScreenID, screen.NewFormDemo())
+
+	app := orvyn.DefaultApp()
+	app.StartScreenID = screen.MainMenuScreenID
 
-	p := tea.NewProgram(&App{}, tea.WithAltScreen())
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
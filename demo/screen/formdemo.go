@@ -0,0 +1,102 @@
+package screen
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/widget/form"
+	"github.com/halsten-dev/orvyn/widget/helpbar"
+	"github.com/halsten-dev/orvyn/widget/statusmessage"
+)
+
+type account struct {
+	Name     string
+	Password string
+	Age      int
+	Bio      string
+	Role     string
+	Plan     string
+	Admin    bool
+	Notify   bool
+}
+
+type FormDemo struct {
+	account account
+
+	form    *form.Form[account]
+	smInfo  *statusmessage.Widget
+	helpBar *helpbar.Widget
+
+	layout *layout.CenterLayout
+}
+
+func NewFormDemo() *FormDemo {
+	s := new(FormDemo)
+
+	s.account = account{Role: "user", Plan: "free"}
+
+	s.form = form.New(&s.account,
+		form.Text("Name", "Name").Validate(form.Required()),
+		form.Password("Password", "Password").Validate(form.Required(), form.MinLength(8)),
+		form.Number("Age", "Age").Validate(form.Range(18, 120)),
+		form.TextArea("Bio", "Biography").Validate(form.MaxLength(200)),
+		form.Select("Role", "Role",
+			form.NewOption("User", "user"),
+			form.NewOption("Moderator", "moderator")),
+		form.HorizontalRadio("Plan", "Plan",
+			form.NewOption("Free", "free"),
+			form.NewOption("Pro", "pro"),
+			form.NewOption("Team", "team")),
+		form.Checkbox("Admin", "Administrator"),
+		form.Toggle("Notify", "Email notifications"),
+	)
+
+	orvyn.RegisterKeyMap(FormDemoScreenID, s.form)
+
+	s.smInfo = statusmessage.New()
+	s.helpBar = helpbar.New()
+
+	s.layout = layout.NewCenterLayout(
+		layout.NewMaxWidthVBoxLayout(0,
+			s.form,
+			s.smInfo,
+			s.helpBar,
+		),
+	)
+
+	return s
+}
+
+func (s *FormDemo) OnEnter(a any) tea.Cmd {
+	s.smInfo.Reset()
+
+	return s.form.Init()
+}
+
+func (s *FormDemo) OnExit() any {
+	return nil
+}
+
+func (s *FormDemo) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case form.SubmitMsg[account]:
+		s.smInfo.SetMessage(fmt.Sprintf("Saved %s (%s)", msg.Value.Name, msg.Value.Role),
+			statusmessage.SuccessMessage)
+
+		return nil
+
+	case tea.KeyMsg:
+		if key.Matches(msg, key.NewBinding(key.WithKeys("esc"))) {
+			return orvyn.PopScreen()
+		}
+	}
+
+	return s.form.Update(msg)
+}
+
+func (s *FormDemo) Render() orvyn.Layout {
+	return s.layout
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/halsten-dev/orvyn"
 	"github.com/halsten-dev/orvyn/layout"
 	"github.com/halsten-dev/orvyn/widget/checkbox"
+	"github.com/halsten-dev/orvyn/widget/helpbar"
+	"github.com/halsten-dev/orvyn/widget/selectbox"
 	"github.com/halsten-dev/orvyn/widget/textarea"
 	"github.com/halsten-dev/orvyn/widget/textinput"
 )
@@ -14,6 +16,9 @@ type InputWidgetDemo struct {
 	tiDemo *textinput.Widget
 	taDemo *textarea.Widget
 	cbDemo *checkbox.Widget
+	sbDemo *selectbox.Widget[string]
+
+	helpBar *helpbar.Widget
 
 	focusManager *orvyn.FocusManager
 
@@ -34,16 +39,27 @@ func NewInputWidgetDemo() *InputWidgetDemo {
 
 	s.cbDemo = checkbox.New("Test")
 
+	s.sbDemo = selectbox.New("Red", "Orange", "Yellow", "Green", "Blue",
+		"Indigo", "Violet", "Black", "White", "Grey")
+	s.sbDemo.Placeholder = "Pick a color"
+
 	s.focusManager = orvyn.NewFocusManager()
 	s.focusManager.Add(s.tiDemo)
 	s.focusManager.Add(s.taDemo)
 	s.focusManager.Add(s.cbDemo)
+	s.focusManager.Add(s.sbDemo)
+
+	orvyn.RegisterKeyMap(InputWidgetDemoScreenID, s.focusManager)
+
+	s.helpBar = helpbar.New()
 
 	s.layout = layout.NewCenterLayout(
 		layout.NewMaxWidthVBoxFullLayout(orvyn.NewSize(10, 5), 1,
 			s.tiDemo,
 			s.taDemo,
 			s.cbDemo,
+			s.sbDemo,
+			s.helpBar,
 		),
 	)
 
@@ -61,10 +77,10 @@ func (s *InputWidgetDemo) OnExit() any {
 }
 
 func (s *InputWidgetDemo) Update(msg tea.Msg) tea.Cmd {
-	if m, ok := orvyn.GetKeyMsg(msg); ok {
+	if m, ok := orvyn.GetKeyMsg(msg); ok && !s.focusManager.IsInputting() {
 		switch {
 		case key.Matches(m, key.NewBinding(key.WithKeys("esc"))):
-			return orvyn.SwitchToPreviousScreen()
+			return orvyn.PopScreen()
 		}
 	}
 
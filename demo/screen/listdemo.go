@@ -84,6 +84,17 @@ func (s *ListDemo) Update(msg tea.Msg) tea.Cmd {
 				s.elementIndex++
 			}
 
+		case key.Matches(m, key.NewBinding(key.WithKeys("m"))):
+			if s.stringList.FilterState() != widgetlist.Filtering {
+				mode := widgetlist.Scroll
+
+				if s.stringList.GetDisplayMode() == widgetlist.Scroll {
+					mode = widgetlist.Paginated
+				}
+
+				s.stringList.SetDisplayMode(mode)
+			}
+
 		case key.Matches(m, key.NewBinding(key.WithKeys("shift+up"))):
 			currentIndex := s.stringList.GetGlobalIndex()
 			if currentIndex > 0 {
@@ -98,7 +109,7 @@ func (s *ListDemo) Update(msg tea.Msg) tea.Cmd {
 
 		case key.Matches(m, key.NewBinding(key.WithKeys("esc"))):
 			if s.stringList.FilterState() == widgetlist.Unfiltered {
-				return orvyn.SwitchToPreviousScreen()
+				return orvyn.PopScreen()
 			}
 		}
 	}
@@ -32,6 +32,10 @@ func NewMainMenu() *MainMenu {
 			Label:  "Input widget demo",
 			Action: m.inputDemo,
 		},
+		{
+			Label:  "Form demo",
+			Action: m.formDemo,
+		},
 		{
 			Label:  "WidgetList demo",
 			Action: m.listDemo,
@@ -65,15 +69,19 @@ func NewMainMenu() *MainMenu {
 }
 
 func (m *MainMenu) inputDemo() tea.Cmd {
-	return orvyn.SwitchScreen(InputWidgetDemoScreenID)
+	return orvyn.PushScreen(InputWidgetDemoScreenID, nil)
+}
+
+func (m *MainMenu) formDemo() tea.Cmd {
+	return orvyn.PushScreen(FormDemoScreenID, nil)
 }
 
 func (m *MainMenu) listDemo() tea.Cmd {
-	return orvyn.SwitchScreen(ListDemoScreenID)
+	return orvyn.PushScreen(ListDemoScreenID, nil)
 }
 
 func (m *MainMenu) progressDemo() tea.Cmd {
-	return orvyn.SwitchScreen(ProgressDemoScreenID)
+	return orvyn.PushScreen(ProgressDemoScreenID, nil)
 }
 
 func (m *MainMenu) quit() tea.Cmd {
@@ -58,7 +58,7 @@ func (p *ProgressDemo) Update(msg tea.Msg) tea.Cmd {
 			return p.launchProgress()
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
-			return orvyn.SwitchToPreviousScreen()
+			return orvyn.PopScreen()
 		}
 	case orvyn.DialogExitMsg:
 		switch msg.DialogID {
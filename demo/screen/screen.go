@@ -9,4 +9,5 @@ const (
 	ListDemoScreenID        orvyn.ScreenID = "listDemoScreen"
 	InputWidgetDemoScreenID orvyn.ScreenID = "inputWidgetDemoScreen"
 	ProgressDemoScreenID    orvyn.ScreenID = "progressScreen"
+	FormDemoScreenID        orvyn.ScreenID = "formDemoScreen"
 )
@@ -37,40 +37,19 @@ type Popup struct {
 // NewPopup returns a new screen based on the given Config.
 // This screen needs to be used with orvyn.OpenDialog().
 func NewPopup(config Config) *Popup {
-	var b strings.Builder
-
 	s := new(Popup)
 
-	t := orvyn.GetTheme()
-	ns := t.Style(theme.NormalTextStyleID)
-	ds := t.Style(theme.DimTextStyleID)
-	nds := t.Style(theme.NeutralDimTextStyleID)
-
 	s.config = config
 
-	b.WriteString(config.Message)
-	b.WriteString("\n\n")
-
-	s.content = orvyn.NewSimpleRenderable(b.String())
-	s.content.Style = ns.AlignHorizontal(lipgloss.Center)
+	s.content = orvyn.NewSimpleRenderable("")
 	s.content.SizeConstraint = true
 
-	b.Reset()
+	s.options = orvyn.NewSimpleRenderable("")
 
-	for i, o := range config.Options {
-		if i > 0 {
-			b.WriteString(nds.Render(fmt.Sprintf(" %c ", '•')))
-		}
-
-		fmt.Fprintf(&b, "%s %s",
-			ns.Render(o.Keybind.Help().Key),
-			ds.Render(o.Text))
-	}
-
-	s.options = orvyn.NewSimpleRenderable(b.String())
+	s.updateStyle()
 
 	s.layout = layout.NewCenterLayout(
-		layout.NewVBoxLayout(10,
+		layout.NewVBoxLayout(0,
 			s.content,
 			s.options,
 		),
@@ -89,6 +68,9 @@ func (s *Popup) OnExit() any {
 
 func (s *Popup) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
+	case orvyn.ThemeChangedMsg:
+		s.updateStyle()
+
 	case tea.KeyMsg:
 		for _, o := range s.config.Options {
 			if key.Matches(msg, o.Keybind) {
@@ -104,3 +86,33 @@ func (s *Popup) Update(msg tea.Msg) tea.Cmd {
 func (s *Popup) Render() orvyn.Layout {
 	return s.layout
 }
+
+// updateStyle renders the message and the options with the active theme.
+func (s *Popup) updateStyle() {
+	var b strings.Builder
+
+	t := orvyn.GetTheme()
+	ns := t.Style(theme.NormalTextStyleID)
+	ds := t.Style(theme.DimTextStyleID)
+	nds := t.Style(theme.NeutralDimTextStyleID)
+
+	b.WriteString(s.config.Message)
+	b.WriteString("\n\n")
+
+	s.content.SetValue(b.String())
+	s.content.Style = ns.AlignHorizontal(lipgloss.Center)
+
+	b.Reset()
+
+	for i, o := range s.config.Options {
+		if i > 0 {
+			b.WriteString(nds.Render(fmt.Sprintf(" %c ", '•')))
+		}
+
+		fmt.Fprintf(&b, "%s %s",
+			ns.Render(o.Keybind.Help().Key),
+			ds.Render(o.Text))
+	}
+
+	s.options.SetValue(b.String())
+}
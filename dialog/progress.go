@@ -41,7 +41,7 @@ func NewProgress(title string) *Progress {
 	}
 
 	p.layout = layout.NewCenterLayout(
-		layout.NewMaxWidthVBoxLayout(10,
+		layout.NewMaxWidthVBoxLayout(0,
 			p.progressBar,
 			p.srCancelKeybind,
 		),
@@ -66,6 +66,9 @@ func (p *Progress) Update(msg tea.Msg) tea.Cmd {
 	cmd := p.progressBar.Update(msg)
 
 	switch msg := msg.(type) {
+	case orvyn.ThemeChangedMsg:
+		p.SetCancelKeybind(p.cancelKeybind)
+
 	case tea.KeyMsg:
 		if p.cancelKeybind != nil {
 			if key.Matches(msg, *p.cancelKeybind) {
@@ -141,6 +144,20 @@ func (p *Progress) SetCancelKeybind(key *key.Binding) {
 	p.srCancelKeybind.SetActive(true)
 }
 
+// ShortHelp implements orvyn.KeyMap. Returns the cancel keybind, if any.
+func (p *Progress) ShortHelp() []key.Binding {
+	if p.cancelKeybind == nil {
+		return nil
+	}
+
+	return []key.Binding{*p.cancelKeybind}
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (p *Progress) FullHelp() [][]key.Binding {
+	return [][]key.Binding{p.ShortHelp()}
+}
+
 // SetBarColor helps changing the underlying progressBar color.
 func (p *Progress) SetBarColor(color lipgloss.Color) {
 	p.progressBar.SetColor(color)
@@ -74,17 +74,37 @@ type BaseFocusable struct {
 
 	focusedStyle lipgloss.Style
 	blurredStyle lipgloss.Style
+
+	// focusedStyleID and blurredStyleID are the theme styles the focused and
+	// blurred styles derive from.
+	focusedStyleID theme.StyleID
+	blurredStyleID theme.StyleID
+
+	// customFocusedStyle and customBlurredStyle are true once the styles are
+	// set by hand, they are then kept when the theme changes.
+	customFocusedStyle bool
+	customBlurredStyle bool
 }
 
 func NewBaseFocusable(widget Widget) BaseFocusable {
+	return NewStyledBaseFocusable(widget, theme.FocusedWidgetStyleID, theme.BlurredWidgetStyleID)
+}
+
+// NewStyledBaseFocusable creates a BaseFocusable whose focused and blurred
+// styles derive from the given theme styles instead of the bordered
+// theme.FocusedWidgetStyleID and theme.BlurredWidgetStyleID, for the widgets
+// drawn without border.
+func NewStyledBaseFocusable(widget Widget, focused, blurred theme.StyleID) BaseFocusable {
 	t := GetTheme()
 
 	return BaseFocusable{
-		widget:       widget,
-		focused:      false,
-		inputting:    false,
-		focusedStyle: t.Style(theme.FocusedWidgetStyleID),
-		blurredStyle: t.Style(theme.BlurredWidgetStyleID),
+		widget:         widget,
+		focused:        false,
+		inputting:      false,
+		focusedStyle:   t.Style(focused),
+		blurredStyle:   t.Style(blurred),
+		focusedStyleID: focused,
+		blurredStyleID: blurred,
 	}
 }
 
@@ -121,7 +141,7 @@ func (b *BaseFocusable) GetEnterInputKeybind() *key.Binding {
 }
 
 func (b *BaseFocusable) GetExitInputKeybind() key.Binding {
-	return key.NewBinding(key.WithKeys("esc"))
+	return Binding(ExitInputAction)
 }
 
 func (b *BaseFocusable) CanExitInputting() bool {
@@ -138,8 +158,41 @@ func (b *BaseFocusable) setInputting(input bool) {
 
 func (b *BaseFocusable) SetFocusedStyle(style lipgloss.Style) {
 	b.focusedStyle = style
+	b.customFocusedStyle = true
 }
 
 func (b *BaseFocusable) SetBlurredStyle(style lipgloss.Style) {
 	b.blurredStyle = style
+	b.customBlurredStyle = true
+}
+
+// UpdateTheme re-derives the focused and blurred styles from the active
+// theme, except the ones set by hand, and applies the current one through
+// OnFocus or OnBlur. Widgets call it when they receive a ThemeChangedMsg.
+func (b *BaseFocusable) UpdateTheme() {
+	t := GetTheme()
+
+	if !b.customFocusedStyle {
+		b.focusedStyle = t.Style(b.focusedStyleID)
+	}
+
+	if !b.customBlurredStyle {
+		b.blurredStyle = t.Style(b.blurredStyleID)
+	}
+
+	f, ok := b.widget.(Focusable)
+
+	switch {
+	case ok && b.focused:
+		f.OnFocus()
+
+	case ok:
+		f.OnBlur()
+
+	case b.focused:
+		b.OnFocus()
+
+	default:
+		b.OnBlur()
+	}
 }
@@ -27,6 +27,13 @@ type FocusManager struct {
 	isInputting bool
 }
 
+// BroadcastMsg is implemented by the messages a FocusManager gives to all its
+// widgets instead of the focused one only, like the result of a work started by
+// a widget which may have lost the focus since.
+type BroadcastMsg interface {
+	BroadcastMsg()
+}
+
 // NewFocusManager creates and return a new *FocusManager.
 func NewFocusManager() *FocusManager {
 	f := new(FocusManager)
@@ -35,14 +42,8 @@ func NewFocusManager() *FocusManager {
 	f.tabIndex = 0
 	f.isInputting = false
 
-	f.NextFocusKeybind = key.NewBinding(
-		key.WithKeys("tab"),
-		key.WithHelp("tab", "next focus"),
-	)
-	f.PreviousFocusKeybind = key.NewBinding(
-		key.WithKeys("shift+tab"),
-		key.WithHelp("shift+tab", "previous focus"),
-	)
+	f.NextFocusKeybind = Binding(NextFocusAction)
+	f.PreviousFocusKeybind = Binding(PreviousFocusAction)
 
 	f.ManageFocusNextPrevKeybind = true
 
@@ -178,11 +179,97 @@ func (f *FocusManager) TabIndex() int {
 	return f.tabIndex
 }
 
-// IsInputting returns true if a widget is in inputting mode.
+// GetFocused returns the currently focused widget, nil if none is focused.
+func (f *FocusManager) GetFocused() Focusable {
+	if f.tabIndex < 0 || f.tabIndex >= len(f.widgets) {
+		return nil
+	}
+
+	if !f.widgets[f.tabIndex].IsFocused() {
+		return nil
+	}
+
+	return f.widgets[f.tabIndex]
+}
+
+// IsInputting returns true if a widget is in inputting mode, entered with
+// its keybind or reported by the focused widget itself.
 func (f *FocusManager) IsInputting() bool {
+	if focused := f.GetFocused(); focused != nil && focused.IsInputting() {
+		return true
+	}
+
 	return f.isInputting
 }
 
+// ShortHelp implements KeyMap. Returns the bindings of the focused widget,
+// then the key exiting its input mode while inputting, or the keys moving the
+// focus otherwise.
+func (f *FocusManager) ShortHelp() []key.Binding {
+	var bindings []key.Binding
+
+	focused := f.GetFocused()
+
+	if km, ok := focused.(KeyMap); ok {
+		bindings = append(bindings, km.ShortHelp()...)
+	}
+
+	if focused != nil && focused.IsInputting() {
+		exit := focused.GetExitInputKeybind()
+
+		// The widget can already describe what its exit key does.
+		if !slices.ContainsFunc(bindings, func(b key.Binding) bool {
+			return slices.Equal(b.Keys(), exit.Keys())
+		}) {
+			bindings = append(bindings, exit)
+		}
+
+		return HelpBindings(bindings)
+	}
+
+	if focused != nil && focused.GetEnterInputKeybind() != nil {
+		bindings = append(bindings, *focused.GetEnterInputKeybind())
+	}
+
+	if f.ManageFocusNextPrevKeybind {
+		bindings = append(bindings, f.NextFocusKeybind, f.PreviousFocusKeybind)
+	}
+
+	return HelpBindings(bindings)
+}
+
+// FullHelp implements KeyMap. Returns a column per KeyGroups section.
+func (f *FocusManager) FullHelp() [][]key.Binding {
+	groups := f.KeyGroups()
+	columns := make([][]key.Binding, 0, len(groups))
+
+	for _, g := range groups {
+		columns = append(columns, g.Bindings)
+	}
+
+	return columns
+}
+
+// KeyGroups implements KeyGroupsProvider. Returns the focus keys, then a
+// section per kind of widget declaring its bindings.
+func (f *FocusManager) KeyGroups() []KeyGroup {
+	var groups []KeyGroup
+
+	if f.ManageFocusNextPrevKeybind {
+		groups = append(groups, KeyGroup{"Focus", HelpBindings([]key.Binding{
+			f.NextFocusKeybind, f.PreviousFocusKeybind,
+		})})
+	}
+
+	for _, widget := range f.widgets {
+		if km, ok := widget.(KeyMap); ok {
+			groups = mergeKeyGroups(groups, KeyGroupsOf(km, "Widget")...)
+		}
+	}
+
+	return groups
+}
+
 // PrevFocus moves the focus to the previous widget.
 func (f *FocusManager) PrevFocus() {
 	if !f.clampTabIndex() {
@@ -247,6 +334,23 @@ func (f *FocusManager) Update(msg tea.Msg) tea.Cmd {
 		return nil
 	}
 
+	if m, ok := msg.(MouseMsg); ok {
+		return f.updateMouse(m)
+	}
+
+	// Every widget restyles, not only the focused one.
+	_, themeChanged := msg.(ThemeChangedMsg)
+
+	if _, ok := msg.(BroadcastMsg); ok || themeChanged {
+		cmds := make([]tea.Cmd, 0, len(f.widgets))
+
+		for _, widget := range f.widgets {
+			cmds = append(cmds, widget.Update(msg))
+		}
+
+		return tea.Batch(cmds...)
+	}
+
 	if f.widgets[f.tabIndex].IsInputting() {
 		var exitCmd tea.Cmd
 
@@ -321,6 +425,65 @@ func (f *FocusManager) Update(msg tea.Msg) tea.Cmd {
 
 // Hidden functions
 
+// updateMouse gives the mouse message to the widget under the pointer, which
+// is not necessarily the focused one: the mouse wheel scrolls what it points
+// at. A left click also moves the focus to the widget, unless the focused one
+// is in input mode and cannot exit it.
+func (f *FocusManager) updateMouse(msg MouseMsg) tea.Cmd {
+	var exitCmd tea.Cmd
+
+	index := f.widgetAt(msg)
+
+	if index < 0 {
+		return nil
+	}
+
+	if msg.IsLeftClick() && index != f.tabIndex {
+		current := f.widgets[f.tabIndex]
+
+		if current.IsInputting() {
+			if !current.CanExitInputting() {
+				return nil
+			}
+
+			exitCmd = f.exitInput(f.tabIndex)
+		}
+	}
+
+	if msg.IsLeftClick() && (index != f.tabIndex || !f.widgets[index].IsFocused()) {
+		f.Focus(index)
+	}
+
+	return tea.Batch(exitCmd, f.widgets[index].Update(msg))
+}
+
+// widgetAt returns the index of the active widget under the pointer, the
+// deepest one when widgets are nested, -1 if none. Only widgets that are
+// Renderable and reachable from the hit-tested layout can be found.
+func (f *FocusManager) widgetAt(msg MouseMsg) int {
+	index, depth := -1, -1
+
+	for i, widget := range f.widgets {
+		if !widget.IsActive() {
+			continue
+		}
+
+		r, ok := widget.(Renderable)
+
+		if !ok {
+			continue
+		}
+
+		for d, hit := range msg.Hits {
+			if hit.Renderable == r && d > depth {
+				index, depth = i, d
+			}
+		}
+	}
+
+	return index
+}
+
 // clampTabIndex brings the tab index back inside the widget list and reports
 // whether the list has a widget to work with. Widgets are added, removed and
 // replaced while the manager keeps its index, so callers that index
@@ -0,0 +1,38 @@
+// Package scrollbar draws the scrollbar shared by the scrolling layouts and
+// widgets of orvyn.
+package scrollbar
+
+import (
+	"strings"
+
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// Render returns a one column scrollbar of the given height, for a content of
+// contentHeight lines shown from offset. The thumb is as tall as the visible
+// part of the content.
+func Render(height, contentHeight, offset int) string {
+	t := orvyn.GetTheme()
+	trackStyle := t.Style(theme.ScrollbarTrackStyleID)
+	thumbStyle := t.Style(theme.ScrollbarThumbStyleID)
+
+	thumbHeight := max(height*height/max(contentHeight, 1), 1)
+	thumbHeight = min(thumbHeight, height)
+
+	scrollable := max(contentHeight-height, 1)
+	thumbStart := offset * (height - thumbHeight) / scrollable
+
+	lines := make([]string, height)
+
+	for i := range lines {
+		if i >= thumbStart && i < thumbStart+thumbHeight {
+			lines[i] = thumbStyle.Render("┃")
+			continue
+		}
+
+		lines[i] = trackStyle.Render("│")
+	}
+
+	return strings.Join(lines, "\n")
+}
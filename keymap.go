@@ -0,0 +1,129 @@
+package orvyn
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap is implemented by the screens, dialogs and widgets declaring their
+// key bindings. It is the same interface as help.KeyMap of bubbles, so a
+// KeyMap can be given as is to a help.Model.
+type KeyMap interface {
+	// ShortHelp returns the bindings worth showing in a help bar.
+	ShortHelp() []key.Binding
+
+	// FullHelp returns every binding, grouped in columns.
+	FullHelp() [][]key.Binding
+}
+
+// TitledKeyMap is a KeyMap naming its section of the help overlay.
+type TitledKeyMap interface {
+	KeyMap
+
+	// KeyMapTitle returns the title of the help overlay section.
+	KeyMapTitle() string
+}
+
+// KeyGroupsProvider is implemented by a KeyMap made of several parts, like the
+// FocusManager and its widgets, to get one help overlay section per part.
+type KeyGroupsProvider interface {
+	KeyGroups() []KeyGroup
+}
+
+// KeyGroup is a titled set of bindings, a section of the help overlay.
+type KeyGroup struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// KeyGroupsOf returns the help overlay sections of the KeyMap. A KeyMap that
+// is neither a KeyGroupsProvider nor a TitledKeyMap gets a single section with
+// the given title. Bindings without help or disabled are left out, and so are
+// the sections left empty.
+func KeyGroupsOf(km KeyMap, title string) []KeyGroup {
+	var groups []KeyGroup
+
+	if p, ok := km.(KeyGroupsProvider); ok {
+		groups = p.KeyGroups()
+	} else {
+		if t, ok := km.(TitledKeyMap); ok {
+			title = t.KeyMapTitle()
+		}
+
+		var bindings []key.Binding
+
+		for _, column := range km.FullHelp() {
+			bindings = append(bindings, column...)
+		}
+
+		groups = []KeyGroup{{title, bindings}}
+	}
+
+	result := make([]KeyGroup, 0, len(groups))
+
+	for _, g := range groups {
+		g.Bindings = HelpBindings(g.Bindings)
+
+		if len(g.Bindings) > 0 {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
+
+// HelpBindings returns the given bindings that are enabled and have a help
+// key, the ones a help view can show.
+func HelpBindings(bindings []key.Binding) []key.Binding {
+	result := make([]key.Binding, 0, len(bindings))
+
+	for _, b := range bindings {
+		if b.Enabled() && b.Help().Key != "" {
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
+
+// Hidden functions
+
+// mergeKeyGroups appends the groups to the list, the bindings of a group
+// titled like one already in the list going to the existing one, without the
+// bindings it already holds. Several widgets of the same kind give a single
+// section.
+func mergeKeyGroups(list []KeyGroup, groups ...KeyGroup) []KeyGroup {
+	for _, g := range groups {
+		index := -1
+
+		for i := range list {
+			if list[i].Title == g.Title {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			list = append(list, KeyGroup{g.Title, nil})
+			index = len(list) - 1
+		}
+
+		for _, b := range g.Bindings {
+			if !containsBinding(list[index].Bindings, b) {
+				list[index].Bindings = append(list[index].Bindings, b)
+			}
+		}
+	}
+
+	return list
+}
+
+// containsBinding returns true if a binding with the same help is in the list.
+func containsBinding(bindings []key.Binding, b key.Binding) bool {
+	for _, c := range bindings {
+		if c.Help() == b.Help() {
+			return true
+		}
+	}
+
+	return false
+}
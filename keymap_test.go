@@ -0,0 +1,64 @@
+package orvyn_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+)
+
+// press sends the key messages to the App.
+func press(app *orvyn.App, keys ...tea.KeyMsg) {
+	for _, k := range keys {
+		app.Update(k)
+	}
+}
+
+var (
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+	keyTab   = tea.KeyMsg{Type: tea.KeyTab}
+	keySpace = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	keyHelp  = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}}
+)
+
+func TestHelpOverlay(t *testing.T) {
+	app, s := newFormApp(t)
+
+	app.RegisterKeyMap("form", s.focusManager)
+
+	// "?" is text while the input has the keyboard.
+	press(app, keyHelp)
+
+	if app.IsHelpVisible() || s.tiName.Value() != "?" {
+		t.Fatalf("? opened the help while inputting, value %q", s.tiName.Value())
+	}
+
+	press(app, keyEsc, keyTab)
+
+	if got := app.ShortHelp(); len(got) == 0 || got[0].Help().Desc != "toggle" {
+		t.Errorf("short help does not start with the checkbox binding: %v", got)
+	}
+
+	press(app, keyHelp)
+
+	frame := ansi.Strip(app.Render())
+
+	for _, text := range []string{"Checkbox", "next focus", "Global"} {
+		if !strings.Contains(frame, text) {
+			t.Errorf("help overlay does not contain %q:\n%s", text, frame)
+		}
+	}
+
+	// Any key closes the help, without reaching the screen.
+	press(app, keySpace)
+
+	if frame := ansi.Strip(app.Render()); strings.Contains(frame, "next focus") {
+		t.Errorf("the help overlay is still shown:\n%s", frame)
+	}
+
+	if s.cbAgree.IsChecked() {
+		t.Errorf("the key closing the help reached the screen")
+	}
+}
@@ -9,11 +9,24 @@ type Layout interface {
 	GetElements() []Renderable
 }
 
+// FullLayout is a Layout also giving its inactive elements. Broadcast walks
+// them, so the widgets hidden when the theme changes get the new one.
+type FullLayout interface {
+	Layout
+
+	// GetAllElements returns every element of the layout, active or not.
+	GetAllElements() []Renderable
+}
+
 // BaseLayout type is used to simplify the creation of custom layouts.
 type BaseLayout struct {
 	BaseRenderable
 
 	elements []Renderable
+
+	// positions holds the position of the elements relative to the layout,
+	// recorded by the layout Render.
+	positions map[Renderable]Position
 }
 
 // NewBaseLayout creates and returns a new BaseLayout.
@@ -22,6 +35,7 @@ func NewBaseLayout(elements ...Renderable) BaseLayout {
 
 	b.BaseRenderable = NewBaseRenderable()
 	b.elements = elements
+	b.positions = make(map[Renderable]Position)
 
 	return b
 }
@@ -41,6 +55,11 @@ func (b *BaseLayout) GetElements() []Renderable {
 	return visibleElements
 }
 
+// GetAllElements returns every element of the layout, active or not.
+func (b *BaseLayout) GetAllElements() []Renderable {
+	return b.elements
+}
+
 // SetActive change the active state of all elements of the layout and the layout itself.
 func (b *BaseLayout) SetActive(active bool) {
 	for _, e := range b.elements {
@@ -49,3 +68,22 @@ func (b *BaseLayout) SetActive(active bool) {
 
 	b.active = active
 }
+
+// SetElementPosition records the position given to the element by Render,
+// relative to the layout. Layouts must call it for every rendered element so
+// the element can be found with FindPosition.
+func (b *BaseLayout) SetElementPosition(e Renderable, position Position) {
+	if b.positions == nil {
+		b.positions = make(map[Renderable]Position)
+	}
+
+	b.positions[e] = position
+}
+
+// GetElementPosition returns the position of the element relative to the
+// layout, as of the last Render.
+func (b *BaseLayout) GetElementPosition(e Renderable) (Position, bool) {
+	position, ok := b.positions[e]
+
+	return position, ok
+}
@@ -25,12 +25,21 @@ func (l *CenterLayout) Render() string {
 
 	size := l.GetSize()
 
-	l.GetElements()[0].Resize(size)
+	element := l.GetElements()[0]
+
+	element.Resize(size)
+
+	view := element.Render()
+	width, height := lipgloss.Size(view)
+
+	l.SetElementPosition(element, orvyn.NewPosition(
+		placeOffset(size.Width-width, lipgloss.Center),
+		placeOffset(size.Height-height, lipgloss.Center)))
 
 	return lipgloss.Place(
 		size.Width, size.Height,
 		lipgloss.Center, lipgloss.Center,
-		l.GetElements()[0].Render(),
+		view,
 	)
 }
 
@@ -50,7 +50,7 @@ func (l *DefinedWidthVerticalLayout) Render() string {
 		max(layoutSize.Height-l.Margin.Height, 0),
 		visibleElements...)
 
-	writeElements(&b, visibleElements)
+	writeElements(&b, &l.BaseLayout, visibleElements)
 
 	return b.String()
 }
@@ -4,16 +4,18 @@ import (
 	"math"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/halsten-dev/orvyn"
 )
 
-// writeElements joins the rendered elements with a newline. An element that was
-// squeezed to zero height and renders nothing is dropped entirely: keeping it
-// would cost a blank line the layout has no room for. An element that renders
-// nothing but still owns some height keeps its blank line, as it is spacing the
-// layout asked for.
-func writeElements(b *strings.Builder, elements []orvyn.Renderable) {
+// writeElements joins the rendered elements with a newline and records their
+// position in the layout. An element that was squeezed to zero height and
+// renders nothing is dropped entirely: keeping it would cost a blank line the
+// layout has no room for. An element that renders nothing but still owns some
+// height keeps its blank line, as it is spacing the layout asked for.
+func writeElements(b *strings.Builder, l *orvyn.BaseLayout, elements []orvyn.Renderable) {
 	first := true
+	y := 0
 
 	for _, e := range elements {
 		view := e.Render()
@@ -28,6 +30,9 @@ func writeElements(b *strings.Builder, elements []orvyn.Renderable) {
 
 		b.WriteString(view)
 
+		l.SetElementPosition(e, orvyn.NewPosition(0, y))
+		y += lipgloss.Height(view)
+
 		first = false
 	}
 }
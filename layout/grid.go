@@ -0,0 +1,370 @@
+package layout
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+)
+
+// TrackKind defines how the size of a grid column or row is computed.
+type TrackKind int
+
+const (
+	// TrackFixed tracks have a size in cells.
+	TrackFixed TrackKind = iota
+
+	// TrackFraction tracks share the space left by the other tracks,
+	// proportionally to their weight.
+	TrackFraction
+
+	// TrackAuto tracks take the preferred size of their content, shrunk down
+	// to the minimal size when space is missing.
+	TrackAuto
+)
+
+// Track defines a column or a row of a GridLayout.
+type Track struct {
+	Kind TrackKind
+
+	// Value is the size in cells of a TrackFixed, the weight of a
+	// TrackFraction. Unused by TrackAuto.
+	Value int
+}
+
+// FixedTrack returns a Track of the given size in cells.
+func FixedTrack(size int) Track {
+	return Track{Kind: TrackFixed, Value: size}
+}
+
+// FractionTrack returns a Track sharing the leftover space with the given weight.
+func FractionTrack(weight int) Track {
+	return Track{Kind: TrackFraction, Value: weight}
+}
+
+// AutoTrack returns a Track sized after its content.
+func AutoTrack() Track {
+	return Track{Kind: TrackAuto}
+}
+
+// GridCell places an element in a GridLayout.
+type GridCell struct {
+	row     int
+	col     int
+	rowSpan int
+	colSpan int
+	element orvyn.Renderable
+}
+
+// NewGridCell places the element at the given row and column.
+func NewGridCell(row, col int, element orvyn.Renderable) GridCell {
+	return NewSpanGridCell(row, col, 1, 1, element)
+}
+
+// NewSpanGridCell places the element at the given row and column, spanning
+// over rowSpan rows and colSpan columns.
+func NewSpanGridCell(row, col, rowSpan, colSpan int, element orvyn.Renderable) GridCell {
+	return GridCell{
+		row:     row,
+		col:     col,
+		rowSpan: max(rowSpan, 1),
+		colSpan: max(colSpan, 1),
+		element: element,
+	}
+}
+
+// GridLayout arranges elements in a grid of columns and rows. Each track is
+// fixed, fractional or sized after its content (see Track). Only elements
+// spanning a single track are taken into account to size an auto track.
+// Elements placed outside of the declared tracks are not rendered.
+type GridLayout struct {
+	orvyn.BaseLayout
+
+	columns []Track
+	rows    []Track
+	cells   []GridCell
+
+	// ColumnGap is the space between two columns.
+	ColumnGap int
+
+	// RowGap is the space between two rows.
+	RowGap int
+}
+
+// NewGridLayout creates a new GridLayout with the given columns, rows and cells.
+func NewGridLayout(columns, rows []Track, cells ...GridCell) *GridLayout {
+	l := new(GridLayout)
+
+	elements := make([]orvyn.Renderable, 0, len(cells))
+
+	for _, c := range cells {
+		elements = append(elements, c.element)
+	}
+
+	l.BaseLayout = orvyn.NewBaseLayout(elements...)
+	l.columns = columns
+	l.rows = rows
+	l.cells = cells
+
+	return l
+}
+
+func (l *GridLayout) Render() string {
+	if len(l.GetElements()) == 0 || len(l.columns) == 0 || len(l.rows) == 0 {
+		return ""
+	}
+
+	layoutSize := l.GetSize()
+
+	widths := l.trackSizes(l.columns, layoutSize.Width, l.ColumnGap, columnAxis)
+	heights := l.trackSizes(l.rows, layoutSize.Height, l.RowGap, rowAxis)
+
+	xs := trackOffsets(widths, l.ColumnGap)
+	ys := trackOffsets(heights, l.RowGap)
+
+	totalWidth := xs[len(xs)-1] + widths[len(widths)-1]
+	totalHeight := ys[len(ys)-1] + heights[len(heights)-1]
+
+	line := strings.Repeat(" ", max(totalWidth, 0))
+	lines := make([]string, max(totalHeight, 0))
+
+	for i := range lines {
+		lines[i] = line
+	}
+
+	view := strings.Join(lines, "\n")
+
+	for _, c := range l.visibleCells() {
+		size := orvyn.NewSize(
+			spanSize(widths, c.col, c.colSpan, l.ColumnGap),
+			spanSize(heights, c.row, c.rowSpan, l.RowGap))
+
+		c.element.Resize(size)
+
+		// A track squeezed to nothing leaves no room to draw the element in.
+		if size.Width <= 0 || size.Height <= 0 {
+			continue
+		}
+
+		// The cell is clipped so an element rendering bigger than its area
+		// cannot push the other cells out of the grid.
+		cellView := lipgloss.NewStyle().
+			MaxWidth(size.Width).
+			MaxHeight(size.Height).
+			Render(c.element.Render())
+
+		view = orvyn.PlaceOverlay(xs[c.col], ys[c.row], cellView, view)
+
+		l.SetElementPosition(c.element, orvyn.NewPosition(xs[c.col], ys[c.row]))
+	}
+
+	return view
+}
+
+func (l *GridLayout) GetMinSize() orvyn.Size {
+	return orvyn.NewSize(
+		l.contentSize(l.columns, l.ColumnGap, columnAxis, orvyn.Renderable.GetMinSize),
+		l.contentSize(l.rows, l.RowGap, rowAxis, orvyn.Renderable.GetMinSize))
+}
+
+func (l *GridLayout) GetPreferredSize() orvyn.Size {
+	return orvyn.NewSize(
+		l.contentSize(l.columns, l.ColumnGap, columnAxis, orvyn.Renderable.GetPreferredSize),
+		l.contentSize(l.rows, l.RowGap, rowAxis, orvyn.Renderable.GetPreferredSize))
+}
+
+// SetColumns replaces the columns of the grid.
+func (l *GridLayout) SetColumns(columns ...Track) {
+	l.columns = columns
+}
+
+// SetRows replaces the rows of the grid.
+func (l *GridLayout) SetRows(rows ...Track) {
+	l.rows = rows
+}
+
+// Hidden functions
+
+// gridAxis selects the columns or the rows of a grid.
+type gridAxis int
+
+const (
+	columnAxis gridAxis = iota
+	rowAxis
+)
+
+// visibleCells returns the cells of active elements placed inside the tracks.
+func (l *GridLayout) visibleCells() []GridCell {
+	cells := make([]GridCell, 0, len(l.cells))
+
+	for _, c := range l.cells {
+		if !c.element.IsActive() {
+			continue
+		}
+
+		if c.row < 0 || c.row >= len(l.rows) || c.col < 0 || c.col >= len(l.columns) {
+			continue
+		}
+
+		c.rowSpan = min(c.rowSpan, len(l.rows)-c.row)
+		c.colSpan = min(c.colSpan, len(l.columns)-c.col)
+
+		cells = append(cells, c)
+	}
+
+	return cells
+}
+
+// contentSizes returns, for every track of the axis, the biggest size the
+// single-span elements of the track report through sizeOf.
+func (l *GridLayout) contentSizes(tracks []Track, axis gridAxis, sizeOf func(orvyn.Renderable) orvyn.Size) []int {
+	sizes := make([]int, len(tracks))
+
+	for _, c := range l.visibleCells() {
+		index, span := c.col, c.colSpan
+		size := sizeOf(c.element).Width
+
+		if axis == rowAxis {
+			index, span = c.row, c.rowSpan
+			size = sizeOf(c.element).Height
+		}
+
+		if span != 1 {
+			continue
+		}
+
+		sizes[index] = max(sizes[index], size)
+	}
+
+	return sizes
+}
+
+// contentSize returns the total size of the axis when every non fixed track
+// gets the size its content reports through sizeOf.
+func (l *GridLayout) contentSize(tracks []Track, gap int, axis gridAxis, sizeOf func(orvyn.Renderable) orvyn.Size) int {
+	if len(tracks) == 0 {
+		return 0
+	}
+
+	sizes := l.contentSizes(tracks, axis, sizeOf)
+	total := gap * (len(tracks) - 1)
+
+	for i, t := range tracks {
+		if t.Kind == TrackFixed {
+			total += t.Value
+			continue
+		}
+
+		total += sizes[i]
+	}
+
+	return total
+}
+
+// trackSizes computes the size of every track of the axis within the available
+// space. Fixed tracks are served first, then auto tracks get their preferred
+// size, shrunk toward their minimal size when it does not fit, and fraction
+// tracks share what is left. The rounding remainder goes to the last fraction
+// track so the tracks fill the available space exactly.
+func (l *GridLayout) trackSizes(tracks []Track, available, gap int, axis gridAxis) []int {
+	sizes := make([]int, len(tracks))
+
+	minSizes := l.contentSizes(tracks, axis, orvyn.Renderable.GetMinSize)
+	prefSizes := l.contentSizes(tracks, axis, orvyn.Renderable.GetPreferredSize)
+
+	remaining := available - gap*(len(tracks)-1)
+
+	autoMin, autoPref := 0, 0
+	fractionTotal, lastFraction := 0, -1
+
+	for i, t := range tracks {
+		switch t.Kind {
+		case TrackFixed:
+			sizes[i] = t.Value
+			remaining -= t.Value
+
+		case TrackAuto:
+			autoMin += minSizes[i]
+			autoPref += prefSizes[i]
+
+		case TrackFraction:
+			fractionTotal += t.Value
+			lastFraction = i
+		}
+	}
+
+	remaining = max(remaining, 0)
+
+	for i, t := range tracks {
+		if t.Kind != TrackAuto {
+			continue
+		}
+
+		switch {
+		case remaining >= autoPref:
+			sizes[i] = prefSizes[i]
+
+		case remaining >= autoMin && autoPref > autoMin:
+			surplus := remaining - autoMin
+
+			sizes[i] = minSizes[i] + int(math.Floor(
+				float64(surplus)*float64(prefSizes[i]-minSizes[i])/float64(autoPref-autoMin)))
+
+		case autoMin > 0:
+			sizes[i] = int(math.Floor(
+				float64(remaining) * float64(minSizes[i]) / float64(autoMin)))
+		}
+	}
+
+	for i, t := range tracks {
+		if t.Kind == TrackAuto {
+			remaining -= sizes[i]
+		}
+	}
+
+	remaining = max(remaining, 0)
+	left := remaining
+
+	for i, t := range tracks {
+		if t.Kind != TrackFraction {
+			continue
+		}
+
+		if i == lastFraction {
+			sizes[i] = left
+			break
+		}
+
+		if fractionTotal > 0 {
+			sizes[i] = int(math.Floor(
+				float64(remaining) * float64(t.Value) / float64(fractionTotal)))
+		}
+
+		left -= sizes[i]
+	}
+
+	return sizes
+}
+
+// trackOffsets returns the position of every track.
+func trackOffsets(sizes []int, gap int) []int {
+	offsets := make([]int, len(sizes))
+
+	for i := 1; i < len(sizes); i++ {
+		offsets[i] = offsets[i-1] + sizes[i-1] + gap
+	}
+
+	return offsets
+}
+
+// spanSize returns the size of the span, gaps between its tracks included.
+func spanSize(sizes []int, start, span, gap int) int {
+	total := gap * (span - 1)
+
+	for _, s := range sizes[start : start+span] {
+		total += s
+	}
+
+	return max(total, 0)
+}
@@ -0,0 +1,94 @@
+package layout
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/halsten-dev/orvyn"
+)
+
+// Fixed tracks keep their size, auto tracks take their content preferred size
+// and fraction tracks share the rest by weight, the remainder going to the
+// last one.
+func TestGridTrackSizes(t *testing.T) {
+	auto := newFlexStub(orvyn.NewSize(4, 1), orvyn.NewSize(10, 1))
+
+	l := NewGridLayout(
+		[]Track{FixedTrack(5), AutoTrack(), FractionTrack(1), FractionTrack(2)},
+		[]Track{FixedTrack(1)},
+		NewGridCell(0, 1, auto))
+	l.ColumnGap = 1
+
+	got := l.trackSizes(l.columns, 41, l.ColumnGap, columnAxis)
+
+	// 41 - 3 gaps - 5 fixed - 10 auto = 23 shared 1:2.
+	if want := []int{5, 10, 7, 16}; !slices.Equal(got, want) {
+		t.Errorf("track sizes = %v, want %v", got, want)
+	}
+}
+
+// Auto tracks shrink from their preferred toward their minimal size before
+// fraction tracks get anything.
+func TestGridAutoTrackShrinks(t *testing.T) {
+	a := newFlexStub(orvyn.NewSize(4, 1), orvyn.NewSize(10, 1))
+	b := newFlexStub(orvyn.NewSize(4, 1), orvyn.NewSize(20, 1))
+
+	l := NewGridLayout(
+		[]Track{AutoTrack(), AutoTrack(), FractionTrack(1)},
+		[]Track{FixedTrack(1)},
+		NewGridCell(0, 0, a),
+		NewGridCell(0, 1, b))
+
+	got := l.trackSizes(l.columns, 16, 0, columnAxis)
+
+	// 8 of surplus over the minimums, shared 6:16 and rounded down: the
+	// rounding loss goes to the fraction track.
+	if want := []int{6, 9, 1}; !slices.Equal(got, want) {
+		t.Errorf("track sizes = %v, want %v", got, want)
+	}
+}
+
+// A spanning element gets the tracks it covers and the gaps between them.
+func TestGridSpan(t *testing.T) {
+	wide := newFlexStub(orvyn.NewSize(1, 1), orvyn.NewSize(1, 1))
+	tall := newFlexStub(orvyn.NewSize(1, 1), orvyn.NewSize(1, 1))
+
+	l := NewGridLayout(
+		[]Track{FractionTrack(1), FractionTrack(1), FractionTrack(1)},
+		[]Track{FixedTrack(2), FixedTrack(3)},
+		NewSpanGridCell(0, 0, 1, 2, wide),
+		NewSpanGridCell(0, 2, 2, 1, tall))
+	l.ColumnGap = 2
+	l.RowGap = 1
+
+	l.Resize(orvyn.NewSize(34, 10))
+	l.Render()
+
+	if got := wide.GetSize(); got != orvyn.NewSize(22, 2) {
+		t.Errorf("column span size = %v, want {22 2}", got)
+	}
+
+	if got := tall.GetSize(); got != orvyn.NewSize(10, 6) {
+		t.Errorf("row span size = %v, want {10 6}", got)
+	}
+}
+
+// Inactive elements and elements outside of the tracks are not rendered.
+func TestGridSkipsHiddenCells(t *testing.T) {
+	hidden := newFlexStub(orvyn.NewSize(1, 1), orvyn.NewSize(1, 1))
+	outside := newFlexStub(orvyn.NewSize(1, 1), orvyn.NewSize(1, 1))
+
+	hidden.SetActive(false)
+
+	l := NewGridLayout(
+		[]Track{FixedTrack(3)},
+		[]Track{FixedTrack(1)},
+		NewGridCell(0, 0, hidden),
+		NewGridCell(5, 5, outside))
+
+	l.Resize(orvyn.NewSize(3, 1))
+
+	if got := l.Render(); got != "   " {
+		t.Errorf("Render() = %q, want an empty cell", got)
+	}
+}
@@ -85,10 +85,14 @@ func (l *HBoxGrowLayout) Render() string {
 	compensatorSize := l.calculateCompensatorSize(elementSize, layoutSize)
 
 	view = make([]string, 0)
+	elementViews := make([]string, 0, len(l.GetElements()))
+
+	x := 0
 
 	for i, e := range l.GetElements() {
 		if i > 0 {
 			view = append(view, strings.Repeat(" ", l.gap))
+			x += l.gap
 		}
 
 		if i == l.compensatorIndex {
@@ -97,11 +101,29 @@ func (l *HBoxGrowLayout) Render() string {
 			e.Resize(elementSize)
 		}
 
-		view = append(view, e.Render())
+		elementView := e.Render()
+		view = append(view, elementView)
+		elementViews = append(elementViews, elementView)
+
+		l.SetElementPosition(e, orvyn.NewPosition(x, 0))
+		x += lipgloss.Width(elementView)
 	}
 
-	return lipgloss.JoinHorizontal(l.Align,
+	joined := lipgloss.JoinHorizontal(l.Align,
 		view...)
+
+	// The vertical position depends on the tallest element, only known once
+	// they are all rendered.
+	height := lipgloss.Height(joined)
+
+	for i, e := range l.GetElements() {
+		position, _ := l.GetElementPosition(e)
+		position.Y = joinOffset(height-lipgloss.Height(elementViews[i]), l.Align)
+
+		l.SetElementPosition(e, position)
+	}
+
+	return joined
 }
 
 func (l *HBoxGrowLayout) calculateCompensatorSize(baseElementSize, layoutSize orvyn.Size) orvyn.Size {
@@ -38,7 +38,15 @@ func NewHBoxFixedRatioLayout(margin int, gap,
 	compensatorIndex int, elements ...FixedRatioRenderable) *HBoxFixedRatio {
 	l := new(HBoxFixedRatio)
 
-	l.BaseLayout = orvyn.NewBaseLayout()
+	renderables := make([]orvyn.Renderable, 0, len(elements))
+
+	for _, e := range elements {
+		renderables = append(renderables, e.element)
+	}
+
+	// The elements are given to the base layout too, so SetActive and
+	// FindPosition reach them.
+	l.BaseLayout = orvyn.NewBaseLayout(renderables...)
 
 	l.margin = margin
 	l.gap = gap
@@ -83,16 +91,23 @@ func (l *HBoxFixedRatio) Render() string {
 
 	view := make([]string, 0)
 
+	x := 0
+
 	for i, e := range l.elements {
 		if i > 0 {
 			view = append(view, strings.Repeat(" ", l.gap))
+			x += l.gap
 		}
 
 		elementSize.Width = e.tempWidth
 
 		e.element.Resize(elementSize)
 
-		view = append(view, e.element.Render())
+		elementView := e.element.Render()
+		view = append(view, elementView)
+
+		l.SetElementPosition(e.element, orvyn.NewPosition(x, 0))
+		x += lipgloss.Width(elementView)
 	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Top,
@@ -29,6 +29,8 @@ func (l *PileLayout) Render() string {
 	for _, e := range l.GetElements() {
 		e.Resize(layoutSize)
 		view = e.Render()
+
+		l.SetElementPosition(e, orvyn.NewPosition(0, 0))
 	}
 
 	return view
@@ -0,0 +1,33 @@
+package layout
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// placeOffset returns the space lipgloss.Place puts before a block when there
+// is the given gap to share at the given position.
+func placeOffset(gap int, pos lipgloss.Position) int {
+	if gap <= 0 {
+		return 0
+	}
+
+	return gap - int(math.Round(float64(gap)*positionValue(pos)))
+}
+
+// joinOffset returns the space lipgloss.JoinHorizontal puts above a block
+// shorter than the others by n lines at the given position. Not the same
+// rounding as placeOffset.
+func joinOffset(n int, pos lipgloss.Position) int {
+	if n <= 0 {
+		return 0
+	}
+
+	return int(math.Round(float64(n) * positionValue(pos)))
+}
+
+// positionValue clamps the position between 0 and 1, like lipgloss does.
+func positionValue(pos lipgloss.Position) float64 {
+	return min(1, max(0, float64(pos)))
+}
@@ -0,0 +1,329 @@
+package layout
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/internal/scrollbar"
+)
+
+// Actions of the ScrollView, see orvyn.Bindings.
+const (
+	ScrollUpAction       orvyn.ActionID = "scroll.line_up"
+	ScrollDownAction     orvyn.ActionID = "scroll.line_down"
+	ScrollPageUpAction   orvyn.ActionID = "scroll.page_up"
+	ScrollPageDownAction orvyn.ActionID = "scroll.page_down"
+	ScrollTopAction      orvyn.ActionID = "scroll.top"
+	ScrollBottomAction   orvyn.ActionID = "scroll.bottom"
+)
+
+func init() {
+	orvyn.DefineAction(ScrollUpAction,
+		key.WithDisabled(),
+		key.WithHelp("", "scroll up"))
+	orvyn.DefineAction(ScrollDownAction,
+		key.WithDisabled(),
+		key.WithHelp("", "scroll down"))
+	orvyn.DefineAction(ScrollPageUpAction,
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "page up"))
+	orvyn.DefineAction(ScrollPageDownAction,
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdown", "page down"))
+	orvyn.DefineAction(ScrollTopAction,
+		key.WithKeys("home"),
+		key.WithHelp("home", "scroll to top"))
+	orvyn.DefineAction(ScrollBottomAction,
+		key.WithKeys("end"),
+		key.WithHelp("end", "scroll to bottom"))
+}
+
+// ScrollView gives its element its preferred height and only renders the
+// slice of it that fits, with a scrollbar when the element is taller than the
+// view. ScrollView must receive the messages in the screen Update to react to
+// the scroll keybinds and to the mouse wheel over it.
+//
+// When a FocusManager is given, the view scrolls to keep the focused widget
+// visible every time the focus moves. The focused widget must be reachable
+// from the element through layouts, see orvyn.FindPosition.
+type ScrollView struct {
+	orvyn.BaseLayout
+
+	// ScrollUpKeybind scrolls one line up. Disabled by default.
+	ScrollUpKeybind key.Binding
+
+	// ScrollDownKeybind scrolls one line down. Disabled by default.
+	ScrollDownKeybind key.Binding
+
+	// PageUpKeybind scrolls one page up. pgup by default.
+	PageUpKeybind key.Binding
+
+	// PageDownKeybind scrolls one page down. pgdown by default.
+	PageDownKeybind key.Binding
+
+	// HomeKeybind scrolls to the top. home by default.
+	HomeKeybind key.Binding
+
+	// EndKeybind scrolls to the bottom. end by default.
+	EndKeybind key.Binding
+
+	// WheelStep is the number of lines scrolled by a mouse wheel step. 3 by default.
+	WheelStep int
+
+	offset        int
+	contentHeight int
+
+	focusManager *orvyn.FocusManager
+	lastFocused  orvyn.Focusable
+}
+
+// NewScrollView creates a new ScrollView around the given element.
+func NewScrollView(element orvyn.Renderable) *ScrollView {
+	l := new(ScrollView)
+
+	l.BaseLayout = orvyn.NewBaseLayout(element)
+
+	l.ScrollUpKeybind = orvyn.Binding(ScrollUpAction)
+	l.ScrollDownKeybind = orvyn.Binding(ScrollDownAction)
+	l.PageUpKeybind = orvyn.Binding(ScrollPageUpAction)
+	l.PageDownKeybind = orvyn.Binding(ScrollPageDownAction)
+	l.HomeKeybind = orvyn.Binding(ScrollTopAction)
+	l.EndKeybind = orvyn.Binding(ScrollBottomAction)
+
+	l.WheelStep = 3
+
+	return l
+}
+
+// SetFocusManager gives the FocusManager whose focused widget must be kept
+// visible. nil to stop following the focus.
+func (l *ScrollView) SetFocusManager(focusManager *orvyn.FocusManager) {
+	l.focusManager = focusManager
+	l.lastFocused = nil
+}
+
+func (l *ScrollView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, l.ScrollUpKeybind):
+			l.ScrollBy(-1)
+
+		case key.Matches(msg, l.ScrollDownKeybind):
+			l.ScrollBy(1)
+
+		case key.Matches(msg, l.PageUpKeybind):
+			l.ScrollBy(-l.pageHeight())
+
+		case key.Matches(msg, l.PageDownKeybind):
+			l.ScrollBy(l.pageHeight())
+
+		case key.Matches(msg, l.HomeKeybind):
+			l.ScrollToTop()
+
+		case key.Matches(msg, l.EndKeybind):
+			l.ScrollToBottom()
+		}
+
+	case orvyn.MouseMsg:
+		if !msg.IsOver(l) {
+			return nil
+		}
+
+		switch {
+		case msg.IsWheelUp():
+			l.ScrollBy(-l.WheelStep)
+
+		case msg.IsWheelDown():
+			l.ScrollBy(l.WheelStep)
+		}
+	}
+
+	return nil
+}
+
+func (l *ScrollView) Render() string {
+	if len(l.GetElements()) == 0 {
+		return ""
+	}
+
+	element := l.GetElements()[0]
+	size := l.GetSize()
+
+	if size.Width <= 0 || size.Height <= 0 {
+		return ""
+	}
+
+	// The element gets its preferred height, and at least the height of the
+	// view so elements filling their height still fill the view.
+	height := max(element.GetPreferredSize().Height, element.GetMinSize().Height, size.Height)
+	width := size.Width
+
+	if height > size.Height {
+		width--
+	}
+
+	element.Resize(orvyn.NewSize(max(width, 0), height))
+
+	lines := strings.Split(element.Render(), "\n")
+
+	// The scrollbar is decided on the requested height, but what counts is
+	// the rendered one: an element can render taller than it was given.
+	l.contentHeight = len(lines)
+
+	if width == size.Width && l.contentHeight > size.Height {
+		width--
+		element.Resize(orvyn.NewSize(max(width, 0), height))
+		lines = strings.Split(element.Render(), "\n")
+		l.contentHeight = len(lines)
+	}
+
+	l.followFocus(element, size.Height)
+	l.clampOffset(size.Height)
+
+	l.SetElementPosition(element, orvyn.NewPosition(0, -l.offset))
+
+	end := min(l.offset+size.Height, len(lines))
+	visible := lines[l.offset:end]
+
+	view := lipgloss.NewStyle().
+		Width(width).
+		MaxWidth(width).
+		Height(size.Height).
+		Render(strings.Join(visible, "\n"))
+
+	if width == size.Width {
+		return view
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		view, scrollbar.Render(size.Height, l.contentHeight, l.offset))
+}
+
+func (l *ScrollView) GetMinSize() orvyn.Size {
+	if size := l.BaseLayout.GetMinSize(); size != orvyn.NewSize(1, 1) {
+		return size
+	}
+
+	if len(l.GetElements()) == 0 {
+		return orvyn.NewSize(0, 0)
+	}
+
+	return orvyn.NewSize(l.GetElements()[0].GetMinSize().Width+1, 1)
+}
+
+func (l *ScrollView) GetPreferredSize() orvyn.Size {
+	if size := l.BaseLayout.GetPreferredSize(); size != orvyn.NewSize(1, 1) {
+		return size
+	}
+
+	if len(l.GetElements()) == 0 {
+		return orvyn.NewSize(0, 0)
+	}
+
+	size := l.GetElements()[0].GetPreferredSize()
+	size.Width++
+
+	return size
+}
+
+// ScrollBy scrolls by the given number of lines, negative to scroll up.
+func (l *ScrollView) ScrollBy(lines int) {
+	l.offset += lines
+	l.clampOffset(l.GetSize().Height)
+}
+
+// ScrollTo scrolls to show the given line at the top of the view.
+func (l *ScrollView) ScrollTo(line int) {
+	l.offset = line
+	l.clampOffset(l.GetSize().Height)
+}
+
+// ScrollToTop scrolls to the first line.
+func (l *ScrollView) ScrollToTop() {
+	l.offset = 0
+}
+
+// ScrollToBottom scrolls to show the last line at the bottom of the view.
+func (l *ScrollView) ScrollToBottom() {
+	l.offset = l.contentHeight
+	l.clampOffset(l.GetSize().Height)
+}
+
+// GetOffset returns the line shown at the top of the view.
+func (l *ScrollView) GetOffset() int {
+	return l.offset
+}
+
+// ShortHelp implements orvyn.KeyMap.
+func (l *ScrollView) ShortHelp() []key.Binding {
+	return []key.Binding{l.PageUpKeybind, l.PageDownKeybind}
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (l *ScrollView) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{l.ScrollUpKeybind, l.ScrollDownKeybind,
+		l.PageUpKeybind, l.PageDownKeybind, l.HomeKeybind, l.EndKeybind}}
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (l *ScrollView) KeyMapTitle() string {
+	return "Scroll"
+}
+
+// Hidden functions
+
+// pageHeight returns the lines scrolled by a page, at least one.
+func (l *ScrollView) pageHeight() int {
+	return max(l.GetSize().Height, 1)
+}
+
+// clampOffset keeps the offset between the first line and the last page. The
+// content height is the one of the last Render, so the offset is clamped again
+// on every Render.
+func (l *ScrollView) clampOffset(viewHeight int) {
+	l.offset = min(l.offset, l.contentHeight-viewHeight)
+	l.offset = max(l.offset, 0)
+}
+
+// followFocus scrolls to show the focused widget when the focus moved since
+// the last Render. The view is left alone otherwise, so the user can scroll
+// away from the focused widget.
+func (l *ScrollView) followFocus(element orvyn.Renderable, viewHeight int) {
+	if l.focusManager == nil {
+		return
+	}
+
+	focused := l.focusManager.GetFocused()
+
+	if focused == nil || focused == l.lastFocused {
+		return
+	}
+
+	l.lastFocused = focused
+
+	renderable, ok := focused.(orvyn.Renderable)
+
+	if !ok {
+		return
+	}
+
+	position, ok := orvyn.FindPosition(element, renderable)
+
+	if !ok {
+		return
+	}
+
+	height := renderable.GetSize().Height
+
+	switch {
+	case position.Y < l.offset:
+		l.offset = position.Y
+	case position.Y+height > l.offset+viewHeight:
+		// A widget taller than the view shows its top.
+		l.offset = min(position.Y+height-viewHeight, position.Y)
+	}
+}
@@ -0,0 +1,182 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+)
+
+// focusStub is a one line focusable widget rendering its name.
+type focusStub struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	name string
+}
+
+func newFocusStub(name string) *focusStub {
+	s := new(focusStub)
+
+	s.BaseWidget = orvyn.NewBaseWidget()
+	s.BaseFocusable = orvyn.NewBaseFocusable(s)
+	s.name = name
+
+	return s
+}
+
+func (s *focusStub) OnFocus()                     {}
+func (s *focusStub) OnBlur()                      {}
+func (s *focusStub) GetMinSize() orvyn.Size       { return orvyn.NewSize(8, 1) }
+func (s *focusStub) GetPreferredSize() orvyn.Size { return orvyn.NewSize(8, 1) }
+func (s *focusStub) Render() string               { return s.name }
+
+// newLines returns a VBox of count one line widgets and the widgets.
+func newLines(count int) (*VBoxLayout, []*focusStub) {
+	stubs := make([]*focusStub, 0, count)
+	elements := make([]orvyn.Renderable, 0, count)
+
+	for i := range count {
+		s := newFocusStub(fmt.Sprintf("line %02d", i))
+
+		stubs = append(stubs, s)
+		elements = append(elements, s)
+	}
+
+	return NewMaxWidthVBoxLayout(0, elements...), stubs
+}
+
+func visibleLines(l *ScrollView) []string {
+	lines := strings.Split(ansi.Strip(l.Render()), "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(strings.TrimRight(line, "│┃"))
+	}
+
+	return lines
+}
+
+// wheelDown returns a wheel step at the given point of the view, hit-tested
+// the way the App does.
+func wheelDown(l *ScrollView, x, y int) orvyn.MouseMsg {
+	return orvyn.MouseMsg{
+		MouseMsg: tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonWheelDown},
+		Hits:     orvyn.HitTest(l, orvyn.NewPosition(0, 0), orvyn.NewPosition(x, y)),
+	}
+}
+
+func TestScrollViewKeyboardAndWheel(t *testing.T) {
+	vbox, _ := newLines(20)
+
+	l := NewScrollView(vbox)
+	l.Resize(orvyn.NewSize(12, 5))
+
+	if got := visibleLines(l); got[0] != "line 00" || len(got) != 5 {
+		t.Fatalf("first page = %q", got)
+	}
+
+	l.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+
+	if got := visibleLines(l); got[0] != "line 05" {
+		t.Errorf("after pgdown, top line = %q, want line 05", got[0])
+	}
+
+	l.Update(wheelDown(l, 20, 2))
+
+	if got := visibleLines(l); got[0] != "line 05" {
+		t.Errorf("wheel away from the view scrolled it, top line = %q", got[0])
+	}
+
+	l.Update(wheelDown(l, 2, 2))
+
+	if got := visibleLines(l); got[0] != "line 08" {
+		t.Errorf("after wheel down, top line = %q, want line 08", got[0])
+	}
+
+	l.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	if got := visibleLines(l); got[4] != "line 19" {
+		t.Errorf("after end, bottom line = %q, want line 19", got[4])
+	}
+
+	l.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+
+	if l.GetOffset() != 15 {
+		t.Errorf("offset = %d past the end, want 15", l.GetOffset())
+	}
+
+	l.Update(tea.KeyMsg{Type: tea.KeyHome})
+
+	if got := visibleLines(l); got[0] != "line 00" {
+		t.Errorf("after home, top line = %q, want line 00", got[0])
+	}
+}
+
+// The scrollbar only shows when the content is taller than the view.
+func TestScrollViewScrollbar(t *testing.T) {
+	vbox, _ := newLines(3)
+
+	l := NewScrollView(vbox)
+	l.Resize(orvyn.NewSize(12, 5))
+
+	if view := ansi.Strip(l.Render()); strings.ContainsAny(view, "│┃") {
+		t.Errorf("scrollbar shown for a content fitting the view:\n%s", view)
+	}
+
+	vbox, _ = newLines(20)
+
+	l = NewScrollView(vbox)
+	l.Resize(orvyn.NewSize(12, 5))
+
+	lines := strings.Split(ansi.Strip(l.Render()), "\n")
+
+	if !strings.HasSuffix(lines[0], "┃") || !strings.HasSuffix(lines[4], "│") {
+		t.Errorf("thumb must be at the top of the scrollbar:\n%s", strings.Join(lines, "\n"))
+	}
+
+	for _, line := range lines {
+		if w := ansi.StringWidth(line); w != 12 {
+			t.Errorf("line %q is %d wide, want 12", line, w)
+		}
+	}
+}
+
+// Moving the focus out of the view scrolls to the focused widget.
+func TestScrollViewFollowsFocus(t *testing.T) {
+	vbox, stubs := newLines(20)
+
+	focusManager := orvyn.NewFocusManager()
+
+	for _, s := range stubs {
+		focusManager.Add(s)
+	}
+
+	focusManager.FocusFirst()
+
+	l := NewScrollView(NewCenterLayout(vbox))
+	l.SetFocusManager(focusManager)
+	l.Resize(orvyn.NewSize(12, 5))
+	l.Render()
+
+	focusManager.Focus(12)
+
+	if got := visibleLines(l); got[4] != "line 12" {
+		t.Errorf("focused line 12 not at the bottom of the view: %q", got)
+	}
+
+	focusManager.Focus(3)
+
+	if got := visibleLines(l); got[0] != "line 03" {
+		t.Errorf("focused line 03 not at the top of the view: %q", got)
+	}
+
+	// Scrolling away from the focused widget must stick.
+	l.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+
+	if got := visibleLines(l); got[0] != "line 08" {
+		t.Errorf("scroll away from the focus reverted, top line = %q", got[0])
+	}
+}
@@ -0,0 +1,113 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/orvyntest"
+)
+
+// box returns a bordered element drawn at exactly the size it is given, so the
+// golden files show the space each layout allocates.
+func box(value string, min, pref orvyn.Size) *orvyn.SimpleRenderable {
+	s := orvyn.NewSimpleRenderable(value)
+
+	s.Style = lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+	s.SizeConstraint = true
+	s.SetMinSize(min)
+	s.SetPreferredSize(pref)
+
+	return s
+}
+
+func TestLayoutSnapshots(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout func() orvyn.Layout
+	}{
+		{"center", func() orvyn.Layout {
+			return NewCenterLayout(box("center", orvyn.NewSize(10, 3), orvyn.NewSize(20, 5)))
+		}},
+		{"vbox", func() orvyn.Layout {
+			return NewVBoxLayout(2,
+				box("a", orvyn.NewSize(8, 3), orvyn.NewSize(30, 3)),
+				box("b", orvyn.NewSize(12, 4), orvyn.NewSize(30, 6)))
+		}},
+		{"vbox_maxwidth", func() orvyn.Layout {
+			return NewMaxWidthVBoxLayout(2,
+				box("a", orvyn.NewSize(8, 3), orvyn.NewSize(30, 3)),
+				box("b", orvyn.NewSize(12, 4), orvyn.NewSize(30, 6)))
+		}},
+		{"vboxfull", func() orvyn.Layout {
+			return NewVBoxFullLayout(orvyn.NewSize(2, 0), 1,
+				box("top", orvyn.NewSize(8, 3), orvyn.NewSize(30, 3)),
+				box("grow", orvyn.NewSize(8, 3), orvyn.NewSize(30, 10)),
+				box("bottom", orvyn.NewSize(8, 3), orvyn.NewSize(30, 3)))
+		}},
+		{"vboxfull_flexible", func() orvyn.Layout {
+			return NewMaxWidthFlexibleVBoxFullLayout(orvyn.NewSize(0, 0),
+				box("fixed", orvyn.NewSize(8, 3), orvyn.NewSize(30, 3)),
+				box("flex 1", orvyn.NewSize(8, 3), orvyn.NewSize(30, 10)),
+				box("flex 2", orvyn.NewSize(8, 3), orvyn.NewSize(30, 20)))
+		}},
+		{"hboxgrow", func() orvyn.Layout {
+			return NewHBoxGrowLayout(1, 2,
+				box("a", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5)),
+				box("b", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5)),
+				box("c", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5)))
+		}},
+		{"hboxgrow_fullheight", func() orvyn.Layout {
+			return NewHBoxGrowFullHeightLayout(0, 0,
+				box("a", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5)),
+				box("b", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5)),
+				box("c", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5)))
+		}},
+		{"hfixedratio", func() orvyn.Layout {
+			return NewHBoxFixedRatioLayout(0, 1, 1,
+				NewFixedRatioRenderable(0.25, box("1/4", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5))),
+				NewFixedRatioRenderable(0.5, box("1/2", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5))),
+				NewFixedRatioRenderable(0.25, box("1/4", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5))))
+		}},
+		{"definedwidthvertical", func() orvyn.Layout {
+			return NewDefinedWidthVerticalLayout(12, 40, orvyn.NewSize(2, 2),
+				box("fixed", orvyn.NewSize(8, 3), orvyn.NewSize(8, 3)),
+				box("flex", orvyn.NewSize(8, 3), orvyn.NewSize(8, 12)))
+		}},
+		{"grid", func() orvyn.Layout {
+			l := NewGridLayout(
+				[]Track{FixedTrack(10), AutoTrack(), FractionTrack(1), FractionTrack(2)},
+				[]Track{AutoTrack(), FractionTrack(1), FixedTrack(3)},
+				NewSpanGridCell(0, 0, 1, 4, box("header", orvyn.NewSize(8, 3), orvyn.NewSize(8, 3))),
+				NewSpanGridCell(1, 0, 2, 1, box("side", orvyn.NewSize(3, 3), orvyn.NewSize(3, 3))),
+				NewGridCell(1, 1, box("auto", orvyn.NewSize(6, 3), orvyn.NewSize(12, 3))),
+				NewGridCell(1, 2, box("1fr", orvyn.NewSize(3, 3), orvyn.NewSize(3, 3))),
+				NewGridCell(1, 3, box("2fr", orvyn.NewSize(3, 3), orvyn.NewSize(3, 3))),
+				NewSpanGridCell(2, 1, 1, 3, box("footer", orvyn.NewSize(3, 3), orvyn.NewSize(3, 3))))
+			l.ColumnGap = 1
+
+			return l
+		}},
+		{"scrollview", func() orvyn.Layout {
+			l := NewScrollView(NewMaxWidthVBoxLayout(0,
+				box("first", orvyn.NewSize(8, 5), orvyn.NewSize(30, 5)),
+				box("second", orvyn.NewSize(8, 5), orvyn.NewSize(30, 5)),
+				box("third", orvyn.NewSize(8, 5), orvyn.NewSize(30, 5))))
+			l.ScrollBy(3)
+
+			return l
+		}},
+		{"pile", func() orvyn.Layout {
+			hidden := box("hidden", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5))
+			hidden.SetActive(false)
+
+			return NewPileLayout(hidden, box("shown", orvyn.NewSize(3, 3), orvyn.NewSize(10, 5)))
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orvyntest.AssertSnapshot(t, test.name, test.layout())
+		})
+	}
+}
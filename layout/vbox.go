@@ -3,6 +3,7 @@ package layout
 import (
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/halsten-dev/orvyn"
 )
 
@@ -62,6 +63,8 @@ func (l *VBoxLayout) Render() string {
 		}
 	}
 
+	y := 0
+
 	for i, e := range l.GetElements() {
 		if i > 0 {
 			b.WriteString("\n")
@@ -70,7 +73,12 @@ func (l *VBoxLayout) Render() string {
 		s.Height = e.GetMinSize().Height
 
 		e.Resize(s)
-		b.WriteString(e.Render())
+
+		view := e.Render()
+		b.WriteString(view)
+
+		l.SetElementPosition(e, orvyn.NewPosition(0, y))
+		y += lipgloss.Height(view)
 	}
 
 	return b.String()
@@ -81,7 +81,7 @@ func (l *VBoxFullLayout) Render() string {
 		l.resizeSingleGrow(width, layoutSize)
 	}
 
-	writeElements(&b, visibleElements)
+	writeElements(&b, &l.BaseLayout, visibleElements)
 
 	return b.String()
 }
@@ -0,0 +1,129 @@
+package orvyn
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Rect is an area of the screen: its top left corner and its size.
+type Rect struct {
+	Position
+	Size
+}
+
+// NewRect returns a new Rect.
+func NewRect(position Position, size Size) Rect {
+	return Rect{position, size}
+}
+
+// Contains returns true if the position is inside the rectangle.
+func (r Rect) Contains(p Position) bool {
+	return p.X >= r.X && p.X < r.X+r.Width &&
+		p.Y >= r.Y && p.Y < r.Y+r.Height
+}
+
+// Hit is a Renderable under the mouse pointer, with the rectangle it was
+// given on the screen.
+type Hit struct {
+	Renderable Renderable
+	Rect       Rect
+}
+
+// MouseMsg is the tea.MouseMsg given by the App to the screens and dialogs. It
+// holds the renderables under the pointer, hit-tested against the layout of
+// the last Render. The program must enable the mouse, see
+// tea.WithMouseCellMotion.
+type MouseMsg struct {
+	tea.MouseMsg
+
+	// Hits holds the renderables under the pointer, from the root layout down
+	// to the deepest element.
+	Hits []Hit
+}
+
+// Target returns the deepest Renderable under the pointer, nil if none.
+func (m MouseMsg) Target() Renderable {
+	if len(m.Hits) == 0 {
+		return nil
+	}
+
+	return m.Hits[len(m.Hits)-1].Renderable
+}
+
+// IsOver returns true if the pointer is over the Renderable.
+func (m MouseMsg) IsOver(r Renderable) bool {
+	_, ok := m.Local(r)
+
+	return ok
+}
+
+// Local returns the pointer position relative to the Renderable, false if the
+// pointer is not over it.
+func (m MouseMsg) Local(r Renderable) (Position, bool) {
+	for _, h := range m.Hits {
+		if h.Renderable == r {
+			return NewPosition(m.X-h.Rect.X, m.Y-h.Rect.Y), true
+		}
+	}
+
+	return Position{}, false
+}
+
+// IsLeftClick returns true for a press of the left button.
+func (m MouseMsg) IsLeftClick() bool {
+	return m.Action == tea.MouseActionPress && m.Button == tea.MouseButtonLeft
+}
+
+// IsWheelUp returns true for a wheel step up.
+func (m MouseMsg) IsWheelUp() bool {
+	return m.Action == tea.MouseActionPress && m.Button == tea.MouseButtonWheelUp
+}
+
+// IsWheelDown returns true for a wheel step down.
+func (m MouseMsg) IsWheelDown() bool {
+	return m.Action == tea.MouseActionPress && m.Button == tea.MouseButtonWheelDown
+}
+
+// HitTest walks down the layouts from the root, placed at origin, and returns
+// the renderables whose rectangle contains the point, from the root down to
+// the deepest element. Each element rectangle is its recorded position and
+// the size it was given, as of the last Render. When elements overlap, the
+// last one is preferred, as it is drawn on top.
+func HitTest(root Renderable, origin Position, point Position) []Hit {
+	var hits []Hit
+
+	for root != nil {
+		rect := NewRect(origin, root.GetSize())
+
+		if !rect.Contains(point) {
+			break
+		}
+
+		hits = append(hits, Hit{root, rect})
+
+		layout, ok := root.(PositionedLayout)
+
+		if !ok {
+			break
+		}
+
+		root = nil
+		elements := layout.GetElements()
+
+		for i := len(elements) - 1; i >= 0; i-- {
+			position, ok := layout.GetElementPosition(elements[i])
+
+			if !ok {
+				continue
+			}
+
+			if NewRect(origin.Add(position), elements[i].GetSize()).Contains(point) {
+				root = elements[i]
+				origin = origin.Add(position)
+
+				break
+			}
+		}
+	}
+
+	return hits
+}
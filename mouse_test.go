@@ -0,0 +1,133 @@
+package orvyn_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/widget/checkbox"
+	"github.com/halsten-dev/orvyn/widget/textinput"
+)
+
+// formScreen is a text input above a checkbox, the input taking the keyboard
+// when the screen is entered.
+type formScreen struct {
+	tiName  *textinput.Widget
+	cbAgree *checkbox.Widget
+
+	focusManager *orvyn.FocusManager
+
+	layout orvyn.Layout
+}
+
+func (s *formScreen) OnEnter(any) tea.Cmd {
+	s.focusManager.FocusFirst()
+	s.focusManager.ForceInput(0)
+
+	return nil
+}
+
+func (s *formScreen) OnExit() any {
+	return nil
+}
+
+func (s *formScreen) Update(msg tea.Msg) tea.Cmd {
+	return s.focusManager.Update(msg)
+}
+
+func (s *formScreen) Render() orvyn.Layout {
+	return s.layout
+}
+
+// newFormApp returns an App of 60x20 showing a formScreen, made the default
+// App until the end of the test.
+func newFormApp(t *testing.T) (*orvyn.App, *formScreen) {
+	t.Helper()
+
+	previous := orvyn.DefaultApp()
+
+	app := orvyn.NewApp()
+	app.WindowSize = orvyn.NewSize(60, 20)
+
+	orvyn.SetDefaultApp(app)
+	t.Cleanup(func() { orvyn.SetDefaultApp(previous) })
+
+	s := new(formScreen)
+
+	s.tiName = textinput.New()
+	s.cbAgree = checkbox.New("Agree")
+
+	s.focusManager = orvyn.NewFocusManager()
+	s.focusManager.Add(s.tiName)
+	s.focusManager.Add(s.cbAgree)
+
+	s.layout = layout.NewVBoxLayout(0, s.tiName, s.cbAgree)
+
+	app.RegisterScreen("form", s)
+	app.SwitchScreen("form")
+
+	return app, s
+}
+
+// click renders the App, so the click is hit-tested against the frame, and
+// sends a left click at the cell.
+func click(app *orvyn.App, x, y int) {
+	app.Render()
+	app.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+func TestHitTest(t *testing.T) {
+	app, s := newFormApp(t)
+	app.Render()
+
+	// The text input takes the first 3 lines, the checkbox the next 3.
+	m := orvyn.MouseMsg{
+		MouseMsg: tea.MouseMsg{X: 2, Y: 4},
+		Hits:     orvyn.HitTest(s.layout, orvyn.NewPosition(0, 0), orvyn.NewPosition(2, 4)),
+	}
+
+	if m.Target() != s.cbAgree || m.IsOver(s.tiName) || !m.IsOver(s.layout) {
+		t.Fatalf("hits %v, want the layout then the checkbox", m.Hits)
+	}
+
+	if local, _ := m.Local(s.cbAgree); local != orvyn.NewPosition(2, 1) {
+		t.Errorf("local position %v in the checkbox, want (2, 1)", local)
+	}
+
+	if hits := orvyn.HitTest(s.layout, orvyn.NewPosition(0, 0), orvyn.NewPosition(2, 19)); len(hits) != 1 {
+		t.Errorf("%d hits under the widgets, want the layout only", len(hits))
+	}
+}
+
+func TestClickFocusesAndToggles(t *testing.T) {
+	app, s := newFormApp(t)
+
+	if !s.tiName.IsInputting() {
+		t.Fatalf("the text input is not in input mode")
+	}
+
+	click(app, 2, 4)
+
+	if !s.cbAgree.IsFocused() || s.tiName.IsFocused() {
+		t.Errorf("the click did not focus the checkbox")
+	}
+
+	if s.tiName.IsInputting() {
+		t.Errorf("the click did not exit the input mode of the text input")
+	}
+
+	if !s.cbAgree.IsChecked() {
+		t.Errorf("the click did not check the checkbox")
+	}
+
+	click(app, 2, 1)
+
+	if !s.tiName.IsFocused() {
+		t.Errorf("the click did not focus the text input")
+	}
+
+	if !s.cbAgree.IsChecked() {
+		t.Errorf("a click on another widget toggled the checkbox")
+	}
+}
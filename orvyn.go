@@ -2,102 +2,77 @@
 package orvyn
 
 import (
-	"log"
+	"io"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/halsten-dev/orvyn/theme"
 )
 
 var (
 	// ProcessExit determines if orvyn should manage the global exit keybind.
+	// Mirrors App.ProcessExit of the default App.
 	ProcessExit bool
 
-	// ExitKeybind to manage global exit
+	// ExitKeybind to manage global exit. Mirrors App.ExitKeybind of the
+	// default App.
 	ExitKeybind key.Binding
 
-	// WindowSize hold the size of the Window.
+	// WindowSize hold the size of the Window. Mirrors App.WindowSize of the
+	// default App.
 	WindowSize Size
 
-	// screens is the map holding all Screen that are registered in orvyn.
-	screens map[ScreenID]Screen
-
-	// currentScreenID holds the active ScreenID.
-	currentScreenID ScreenID
-
-	// previousScreenID holds the previously active ScreenID.
-	previousScreenID ScreenID
-
-	activeDialog *dialog
-
-	activeTheme theme.Theme
+	// defaultApp is the App the package level functions operate on.
+	defaultApp *App
 )
 
+func init() {
+	Init()
+}
+
 // Init function is used to initialize orvyn and make it useable.
+// It replaces the default App with a fresh one.
 func Init() {
-	ExitKeybind = key.NewBinding(key.WithKeys("ctrl+c"))
-	ProcessExit = true
-	WindowSize = NewSize(100, 100)
-	screens = make(map[ScreenID]Screen)
-	activeTheme = theme.NewDefaultDarkTheme()
+	SetDefaultApp(NewApp())
 }
 
-func Update(msg tea.Msg) tea.Cmd {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch {
-		case key.Matches(msg, ExitKeybind):
-			if ProcessExit {
-				return tea.Quit
-			}
-		}
-
-	case tea.WindowSizeMsg:
-		WindowSize.Width = msg.Width
-		WindowSize.Height = msg.Height
-	}
-
-	if currentScreenID == "" {
-		return nil
-	}
+// DefaultApp returns the App the package level functions operate on.
+func DefaultApp() *App {
+	return defaultApp
+}
 
-	if activeDialog != nil {
-		return activeDialog.screen.Update(msg)
-	} else {
-		return screens[currentScreenID].Update(msg)
-	}
+// SetDefaultApp changes the App the package level functions operate on.
+// Useful when an App is created with NewApp but its screens and widgets use
+// the package level functions.
+func SetDefaultApp(app *App) {
+	defaultApp = app
 
+	ProcessExit = app.ProcessExit
+	ExitKeybind = app.ExitKeybind
+	WindowSize = app.WindowSize
 }
 
-func Render() string {
-	var layout Layout
+// Update forwards the message to the default App.
+func Update(msg tea.Msg) tea.Cmd {
+	// The exported variables predate App and are still written to by
+	// applications, so they are synced around every call instead of being
+	// read once.
+	defaultApp.ProcessExit = ProcessExit
+	defaultApp.ExitKeybind = ExitKeybind
+	defaultApp.WindowSize = WindowSize
 
-	if currentScreenID == "" {
-		return "Orvyn : No Current Screen"
-	}
+	cmd := defaultApp.update(msg)
 
-	if activeDialog != nil {
-		layout = activeDialog.screen.Render()
-	} else {
-		layout = screens[currentScreenID].Render()
-	}
+	WindowSize = defaultApp.WindowSize
 
-	if layout == nil {
-		return ""
-	}
+	return cmd
+}
 
-	layout.Resize(WindowSize)
+// Render returns the view of the default App.
+func Render() string {
+	defaultApp.WindowSize = WindowSize
 
-	// Clip to the window. A layout can legitimately render taller than the space
-	// it was given - widgets have a minimal height they cannot go under - and
-	// emitting more lines than the terminal has makes the terminal scroll, which
-	// silently eats the *top* of the screen. Cutting the overflow off the bottom
-	// keeps the top anchored where the user expects it.
-	return lipgloss.NewStyle().
-		MaxWidth(WindowSize.Width).
-		MaxHeight(WindowSize.Height).
-		Render(layout.Render())
+	return defaultApp.Render()
 }
 
 // Helper
@@ -113,90 +88,147 @@ func GetKeyMsg(msg tea.Msg) (tea.KeyMsg, bool) {
 // Theme
 
 func GetTheme() theme.Theme {
-	return activeTheme
+	return defaultApp.GetTheme()
 }
 
-func SetTheme(theme theme.Theme) {
-	activeTheme = theme
+// SetTheme changes the theme of the default App. The returned tea.Cmd sends
+// the ThemeChangedMsg restyling the existing widgets, see App.SetTheme.
+func SetTheme(theme theme.Theme) tea.Cmd {
+	return defaultApp.SetTheme(theme)
 }
 
-// Screen management
+// Bindings
 
-// RegisterScreen allows to register a Screen with the given ScreenID.
-func RegisterScreen(id ScreenID, screen Screen) {
-	screens[id] = screen
+// Binding returns the binding of the action in the default App, its default
+// one unless the user overrides it. See Bindings.
+func Binding(id ActionID) key.Binding {
+	return defaultApp.Bindings.Binding(id)
 }
 
-// SwitchScreen change the currently active screen and called OnExit and OnEnter.
-func SwitchScreen(id ScreenID) tea.Cmd {
-	var param any
+// LoadBindings loads the binding overrides of the user in the default App.
+// See App.LoadBindings.
+func LoadBindings(r io.Reader) error {
+	err := defaultApp.LoadBindings(r)
 
-	_, ok := screens[id]
+	ExitKeybind = defaultApp.ExitKeybind
 
-	if !ok {
-		log.Fatalf("Orvyn : Screen with ID %s does not exist", id)
-		return nil
-	}
+	return err
+}
 
-	if currentScreenID != "" {
-		param = screens[currentScreenID].OnExit()
-	}
+// LoadBindingsFile loads the binding overrides of the user from the named
+// file in the default App. See App.LoadBindingsFile.
+func LoadBindingsFile(name string) error {
+	err := defaultApp.LoadBindingsFile(name)
+
+	ExitKeybind = defaultApp.ExitKeybind
 
-	previousScreenID = currentScreenID
+	return err
+}
 
-	currentScreenID = id
+// Key maps
 
-	return screens[currentScreenID].OnEnter(param)
+// RegisterKeyMap declares a KeyMap for the given screen or dialog, global for
+// an empty ScreenID. See App.RegisterKeyMap.
+func RegisterKeyMap(id ScreenID, km KeyMap) {
+	defaultApp.RegisterKeyMap(id, km)
 }
 
-func SwitchToPreviousScreen() tea.Cmd {
-	if previousScreenID == "" {
-		return nil
-	}
+// Screen management
 
-	return SwitchScreen(previousScreenID)
+// RegisterScreen allows to register a Screen with the given ScreenID.
+func RegisterScreen(id ScreenID, screen Screen) {
+	defaultApp.RegisterScreen(id, screen)
+}
+
+// SwitchScreen change the currently active screen and called OnExit and OnEnter.
+func SwitchScreen(id ScreenID) tea.Cmd {
+	return defaultApp.SwitchScreen(id)
+}
+
+func SwitchToPreviousScreen() tea.Cmd {
+	return defaultApp.SwitchToPreviousScreen()
 }
 
 func SetPreviousScreen(id ScreenID) {
-	previousScreenID = id
+	defaultApp.SetPreviousScreen(id)
 }
 
 func GetPreviousScreen() ScreenID {
-	return previousScreenID
+	return defaultApp.GetPreviousScreen()
 }
 
 // GetScreen returns the Screen for the given registered ScreenID.
 func GetScreen(id ScreenID) Screen {
-	_, ok := screens[id]
-
-	if !ok {
-		return nil
-	}
-
-	return screens[id]
+	return defaultApp.GetScreen(id)
 }
 
 // GetCurrentScreenID returns the currently active ScreenID.
 func GetCurrentScreenID() ScreenID {
-	return currentScreenID
+	return defaultApp.GetCurrentScreenID()
+}
+
+// Navigation stack
+
+// PushScreen puts a screen on top of the navigation stack. See App.PushScreen.
+func PushScreen(id ScreenID, param any) tea.Cmd {
+	return defaultApp.PushScreen(id, param)
+}
+
+// PopScreen goes back to the screen underneath. See App.PopScreen.
+func PopScreen() tea.Cmd {
+	return defaultApp.PopScreen()
+}
+
+// PopToRoot goes back to the root of the navigation stack. See App.PopToRoot.
+func PopToRoot() tea.Cmd {
+	return defaultApp.PopToRoot()
+}
+
+// ReplaceScreen replaces the active screen. See App.ReplaceScreen.
+func ReplaceScreen(id ScreenID) tea.Cmd {
+	return defaultApp.ReplaceScreen(id)
+}
+
+// ScreenStackDepth returns the number of screens in the navigation stack.
+func ScreenStackDepth() int {
+	return defaultApp.ScreenStackDepth()
+}
+
+// GetScreenStack returns a copy of the navigation stack, from the root to the
+// active screen.
+func GetScreenStack() []ScreenID {
+	return defaultApp.GetScreenStack()
 }
 
 // Dialog API
 
 func OpenDialog(dialogID ScreenID, dialogScreen Screen, param any) tea.Cmd {
-	activeDialog = new(dialog)
+	return defaultApp.OpenDialog(dialogID, dialogScreen, param)
+}
 
-	activeDialog.dialogID = dialogID
-	activeDialog.screen = dialogScreen
+func CloseDialog() tea.Cmd {
+	return defaultApp.CloseDialog()
+}
 
-	return activeDialog.screen.OnEnter(param)
+// DialogStackDepth returns the number of open dialogs.
+func DialogStackDepth() int {
+	return defaultApp.DialogStackDepth()
 }
 
-func CloseDialog() tea.Cmd {
-	param := activeDialog.screen.OnExit()
-	id := activeDialog.dialogID
+// Popup API
+
+// OpenPopup draws content over the view next to the owner, in the default
+// App. See App.OpenPopup.
+func OpenPopup(owner, content Renderable) {
+	defaultApp.OpenPopup(owner, content)
+}
 
-	activeDialog = nil
+// ClosePopup closes the popup of the owner, if open.
+func ClosePopup(owner Renderable) {
+	defaultApp.ClosePopup(owner)
+}
 
-	return dialogExitCmd(id, param)
+// IsPopupOpen returns true if the owner has an open popup.
+func IsPopupOpen(owner Renderable) bool {
+	return defaultApp.IsPopupOpen(owner)
 }
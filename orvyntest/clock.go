@@ -0,0 +1,110 @@
+package orvyntest
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Clock is a fake clock replacing tea.Tick for orvyn.TickCmd. Ticks are only
+// delivered when the clock is advanced.
+type Clock struct {
+	now     time.Time
+	pending []pendingTick
+}
+
+// pendingTick is the message of a tea.Cmd created by Clock.Tick. The harness
+// hands it back to the clock instead of dispatching it.
+type pendingTick struct {
+	deadline time.Time
+	fn       func(time.Time) tea.Msg
+}
+
+// NewClock creates a Clock set at the given time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{
+		now:     now,
+		pending: make([]pendingTick, 0),
+	}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	return c.now
+}
+
+// Tick has the signature of tea.Tick and can replace App.TickFunc.
+func (c *Clock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return pendingTick{
+			deadline: c.now.Add(d),
+			fn:       fn,
+		}
+	}
+}
+
+// Advance moves the clock forward and returns the messages of the ticks that
+// are due, ordered by deadline. The clock stops at each deadline before its
+// tick fires. Advance(0) delivers the ticks already due.
+//
+// The returned ticks can not re-arm before the end, Harness.Advance dispatches
+// each message as its tick fires so the re-armed ticks fire too.
+func (c *Clock) Advance(d time.Duration) []tea.Msg {
+	end := c.now.Add(d)
+	msgs := make([]tea.Msg, 0)
+
+	for {
+		msg, ok := c.next(end)
+
+		if !ok {
+			break
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	c.now = end
+
+	return msgs
+}
+
+// Pending returns the number of ticks waiting for their deadline.
+func (c *Clock) Pending() int {
+	return len(c.pending)
+}
+
+func (c *Clock) schedule(tick pendingTick) {
+	c.pending = append(c.pending, tick)
+}
+
+// next fires the first tick due at the end, moving the clock to its deadline.
+// Returns false when no tick is due.
+func (c *Clock) next(end time.Time) (tea.Msg, bool) {
+	if len(c.pending) == 0 {
+		return nil, false
+	}
+
+	// Ticks of a same deadline fire in the order they were scheduled.
+	first := 0
+
+	for i, p := range c.pending {
+		if p.deadline.Before(c.pending[first].deadline) {
+			first = i
+		}
+	}
+
+	p := c.pending[first]
+
+	if p.deadline.After(end) {
+		return nil, false
+	}
+
+	c.pending = slices.Delete(c.pending, first, first+1)
+
+	if p.deadline.After(c.now) {
+		c.now = p.deadline
+	}
+
+	return p.fn(p.deadline), true
+}
@@ -0,0 +1,60 @@
+package orvyntest
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyTypes maps the name of every named key, as tea.KeyMsg.String writes it,
+// to its tea.KeyType.
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+
+	// Named keys are the control characters and the negative special keys.
+	for k := tea.KeyType(-128); k <= 127; k++ {
+		name := k.String()
+
+		if name == "" || k == tea.KeyRunes {
+			continue
+		}
+
+		if _, ok := types[name]; !ok {
+			types[name] = k
+		}
+	}
+
+	return types
+}()
+
+// KeyMsg returns the tea.KeyMsg matching the given key, written the way
+// key.WithKeys expects them. A single rune gives a rune key, a name prefixed
+// with "alt+" sets Alt. The bool is false for an unknown key name.
+func KeyMsg(k string) (tea.KeyMsg, bool) {
+	var msg tea.KeyMsg
+
+	if name, ok := strings.CutPrefix(k, "alt+"); ok && name != "" {
+		msg.Alt = true
+		k = name
+	}
+
+	if t, ok := keyTypes[k]; ok {
+		msg.Type = t
+
+		if t == tea.KeySpace {
+			msg.Runes = []rune{' '}
+		}
+
+		return msg, true
+	}
+
+	if utf8.RuneCountInString(k) == 1 {
+		msg.Type = tea.KeyRunes
+		msg.Runes = []rune(k)
+
+		return msg, true
+	}
+
+	return msg, false
+}
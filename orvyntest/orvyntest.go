@@ -0,0 +1,374 @@
+// Package orvyntest provides a headless harness to test orvyn screens end to end.
+//
+// A Harness boots an orvyn.App with a fixed window size, feeds it key sequences
+// and messages, executes the returned tea.Cmd synchronously and gives access
+// to the rendered frame:
+//
+//	func TestLogin(t *testing.T) {
+//		h := orvyntest.New(t, orvyn.NewSize(80, 24))
+//
+//		h.Register(LoginScreenID, NewLogin())
+//		h.Start(LoginScreenID)
+//
+//		h.Type("admin")
+//		h.Press("tab", "enter")
+//
+//		h.AssertScreen(HomeScreenID)
+//		h.AssertContains("Welcome admin")
+//	}
+//
+// The harness App is made the orvyn default App for the duration of the test,
+// as screens and widgets use the package level functions. Tests using a
+// Harness can therefore not run in parallel.
+package orvyntest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+)
+
+// maxDispatch bounds the number of messages a single call can dispatch, so a
+// command chain that never ends fails the test instead of hanging it.
+const maxDispatch = 10000
+
+// Harness drives an orvyn.App without a terminal.
+type Harness struct {
+	t testing.TB
+
+	// App is the driven App. It is the orvyn default App while the test runs.
+	App *orvyn.App
+
+	// Clock drives the ticks of the App, through its TickFunc. See Advance.
+	Clock *Clock
+
+	// Messages holds every message dispatched to the App, in order.
+	Messages []tea.Msg
+
+	// skipped holds the code of the commands never run, see Skip.
+	skipped map[uintptr]struct{}
+
+	quit bool
+}
+
+// New creates a Harness with an App of the given window size, made the orvyn
+// default App until the end of the test. The cursor blink is skipped, see
+// Skip.
+func New(t testing.TB, size orvyn.Size) *Harness {
+	t.Helper()
+
+	h := new(Harness)
+
+	h.t = t
+	h.App = orvyn.NewApp()
+	h.App.WindowSize = size
+	h.Clock = NewClock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	h.App.TickFunc = h.Clock.Tick
+	h.Messages = make([]tea.Msg, 0)
+	h.skipped = make(map[uintptr]struct{})
+
+	h.Skip(CursorBlink())
+
+	previousApp := orvyn.DefaultApp()
+
+	orvyn.SetDefaultApp(h.App)
+
+	t.Cleanup(func() {
+		orvyn.SetDefaultApp(previousApp)
+	})
+
+	return h
+}
+
+// Register registers the screen in the App.
+func (h *Harness) Register(id orvyn.ScreenID, screen orvyn.Screen) {
+	h.App.RegisterScreen(id, screen)
+}
+
+// Start switches to the given screen and runs the returned commands.
+func (h *Harness) Start(id orvyn.ScreenID) {
+	h.t.Helper()
+
+	h.Run(h.App.SwitchScreen(id))
+}
+
+// Resize changes the window size by sending a tea.WindowSizeMsg.
+func (h *Harness) Resize(size orvyn.Size) {
+	h.t.Helper()
+
+	h.Send(tea.WindowSizeMsg{Width: size.Width, Height: size.Height})
+}
+
+// Send dispatches the message to the App and runs the returned commands,
+// dispatching their messages in turn until no command is left.
+func (h *Harness) Send(msg tea.Msg) {
+	h.t.Helper()
+
+	queue := []tea.Msg{msg}
+
+	for count := 0; len(queue) > 0; count++ {
+		if count >= maxDispatch {
+			h.t.Fatalf("orvyntest: more than %d messages dispatched, the commands never settle", maxDispatch)
+			return
+		}
+
+		msg, queue = queue[0], queue[1:]
+
+		if _, ok := msg.(tea.QuitMsg); ok {
+			h.quit = true
+			continue
+		}
+
+		h.Messages = append(h.Messages, msg)
+
+		_, cmd := h.App.Update(msg)
+
+		queue = append(queue, h.execute(cmd)...)
+	}
+}
+
+// Run executes the command and dispatches its messages. Useful for commands
+// returned outside of Update, like the ones of App.SwitchScreen.
+func (h *Harness) Run(cmd tea.Cmd) {
+	h.t.Helper()
+
+	for _, msg := range h.execute(cmd) {
+		h.Send(msg)
+	}
+}
+
+// Press sends one tea.KeyMsg per given key, written the way key.WithKeys
+// expects them: "enter", "tab", "ctrl+c", "alt+x", "a", " ".
+func (h *Harness) Press(keys ...string) {
+	h.t.Helper()
+
+	for _, k := range keys {
+		msg, ok := KeyMsg(k)
+
+		if !ok {
+			h.t.Fatalf("orvyntest: unknown key %q", k)
+			return
+		}
+
+		h.Send(msg)
+	}
+}
+
+// Type sends one tea.KeyMsg per rune of the given text.
+func (h *Harness) Type(text string) {
+	h.t.Helper()
+
+	for _, r := range text {
+		msg, _ := KeyMsg(string(r))
+
+		h.Send(msg)
+	}
+}
+
+// Click renders the frame, so the click is hit-tested against what is on
+// screen, and sends a left button press at the given cell.
+func (h *Harness) Click(x, y int) {
+	h.t.Helper()
+
+	h.App.Render()
+
+	h.Send(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+// Wheel renders the frame and sends the given number of mouse wheel steps at
+// the given cell, down for positive steps and up for negative ones.
+func (h *Harness) Wheel(x, y, steps int) {
+	h.t.Helper()
+
+	button := tea.MouseButtonWheelDown
+
+	if steps < 0 {
+		button = tea.MouseButtonWheelUp
+		steps = -steps
+	}
+
+	for range steps {
+		h.App.Render()
+
+		h.Send(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: button})
+	}
+}
+
+// Advance moves the fake clock forward and dispatches the message of every
+// orvyn.TickCmd that is due, in order. The clock stops at each deadline and
+// dispatches its message before going on, so a tick re-armed by its handler
+// fires again if it is due: Advance(3*time.Second) delivers three ticks of a
+// one second ticker.
+func (h *Harness) Advance(d time.Duration) {
+	h.t.Helper()
+
+	end := h.Clock.Now().Add(d)
+
+	for {
+		msg, ok := h.Clock.next(end)
+
+		if !ok {
+			break
+		}
+
+		h.Send(msg)
+	}
+
+	h.Clock.now = end
+}
+
+// Skip makes the harness drop the given commands, and every command created
+// by the same code, instead of running them. Commands run synchronously, so a
+// command waiting on a real timer the harness does not drive, like the cursor
+// blink, blocks the test: tests skip such commands explicitly. The cursor
+// blink is skipped by default.
+func (h *Harness) Skip(cmds ...tea.Cmd) {
+	for _, cmd := range cmds {
+		if cmd != nil {
+			h.skipped[reflect.ValueOf(cmd).Pointer()] = struct{}{}
+		}
+	}
+}
+
+// Quit returns true if a tea.Quit command was executed.
+func (h *Harness) Quit() bool {
+	return h.quit
+}
+
+// Frame returns the current rendered frame, without ANSI sequences.
+func (h *Harness) Frame() string {
+	return ansi.Strip(h.App.Render())
+}
+
+// RawFrame returns the current rendered frame, ANSI sequences included.
+func (h *Harness) RawFrame() string {
+	return h.App.Render()
+}
+
+// Assertions
+
+// AssertContains fails the test if the frame does not contain the text.
+func (h *Harness) AssertContains(text string) {
+	h.t.Helper()
+
+	if frame := h.Frame(); !strings.Contains(frame, text) {
+		h.t.Errorf("frame does not contain %q:\n%s", text, frame)
+	}
+}
+
+// AssertNotContains fails the test if the frame contains the text.
+func (h *Harness) AssertNotContains(text string) {
+	h.t.Helper()
+
+	if frame := h.Frame(); strings.Contains(frame, text) {
+		h.t.Errorf("frame contains %q:\n%s", text, frame)
+	}
+}
+
+// AssertScreen fails the test if the given screen is not the active one.
+func (h *Harness) AssertScreen(id orvyn.ScreenID) {
+	h.t.Helper()
+
+	if current := h.App.GetCurrentScreenID(); current != id {
+		h.t.Errorf("current screen is %q, want %q", current, id)
+	}
+}
+
+// AssertFocused fails the test if the widget is not focused.
+func (h *Harness) AssertFocused(widget orvyn.Focusable) {
+	h.t.Helper()
+
+	if !widget.IsFocused() {
+		h.t.Errorf("widget %T is not focused", widget)
+	}
+}
+
+// AssertNotFocused fails the test if the widget is focused.
+func (h *Harness) AssertNotFocused(widget orvyn.Focusable) {
+	h.t.Helper()
+
+	if widget.IsFocused() {
+		h.t.Errorf("widget %T is focused", widget)
+	}
+}
+
+// AssertInputting fails the test if the widget is not in input mode.
+func (h *Harness) AssertInputting(widget orvyn.Focusable) {
+	h.t.Helper()
+
+	if !widget.IsInputting() {
+		h.t.Errorf("widget %T is not in input mode", widget)
+	}
+}
+
+// AssertDialogDepth fails the test if the number of open dialogs differs.
+func (h *Harness) AssertDialogDepth(depth int) {
+	h.t.Helper()
+
+	if got := h.App.DialogStackDepth(); got != depth {
+		h.t.Errorf("%d dialogs open, want %d", got, depth)
+	}
+}
+
+// Hidden functions
+
+// cmdType is the reflect type of a tea.Cmd, used to recognize the unexported
+// message tea.Sequence produces.
+var cmdType = reflect.TypeOf(tea.Cmd(nil))
+
+// execute runs the command and returns the messages it produced, flattening
+// tea.Batch and tea.Sequence. Ticks of the fake clock are kept for Advance.
+func (h *Harness) execute(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	if _, ok := h.skipped[reflect.ValueOf(cmd).Pointer()]; ok {
+		return nil
+	}
+
+	msg := cmd()
+
+	if msg == nil {
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.BatchMsg:
+		return h.executeAll(msg)
+
+	case pendingTick:
+		h.Clock.schedule(msg)
+		return nil
+	}
+
+	// tea.Sequence returns an unexported []tea.Cmd.
+	v := reflect.ValueOf(msg)
+
+	if v.Kind() == reflect.Slice && v.Type().Elem() == cmdType {
+		cmds := make([]tea.Cmd, v.Len())
+
+		for i := range cmds {
+			cmds[i] = v.Index(i).Interface().(tea.Cmd)
+		}
+
+		return h.executeAll(cmds)
+	}
+
+	return []tea.Msg{msg}
+}
+
+func (h *Harness) executeAll(cmds []tea.Cmd) []tea.Msg {
+	msgs := make([]tea.Msg, 0, len(cmds))
+
+	for _, cmd := range cmds {
+		msgs = append(msgs, h.execute(cmd)...)
+	}
+
+	return msgs
+}
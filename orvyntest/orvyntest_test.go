@@ -0,0 +1,324 @@
+package orvyntest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/dialog"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/widget/checkbox"
+	"github.com/halsten-dev/orvyn/widget/textinput"
+)
+
+// formScreen is a small screen with two focusable widgets, opening a progress
+// dialog with "p" and a detail screen with "d".
+type formScreen struct {
+	tiName   *textinput.Widget
+	cbAgree  *checkbox.Widget
+	progress *dialog.Progress
+
+	focusManager *orvyn.FocusManager
+
+	layout orvyn.Layout
+}
+
+func newFormScreen() *formScreen {
+	s := new(formScreen)
+
+	s.tiName = textinput.New()
+	s.cbAgree = checkbox.New("Agree")
+	s.progress = dialog.NewProgress("Working")
+
+	s.focusManager = orvyn.NewFocusManager()
+	s.focusManager.Add(s.tiName)
+	s.focusManager.Add(s.cbAgree)
+
+	s.layout = layout.NewVBoxLayout(0, s.tiName, s.cbAgree)
+
+	return s
+}
+
+func (s *formScreen) OnEnter(any) tea.Cmd {
+	s.focusManager.FocusFirst()
+	s.focusManager.ForceInput(0)
+
+	return s.tiName.Init()
+}
+
+func (s *formScreen) OnExit() any {
+	return s.tiName.Value()
+}
+
+func (s *formScreen) Update(msg tea.Msg) tea.Cmd {
+	if m, ok := orvyn.GetKeyMsg(msg); ok && !s.focusManager.IsInputting() {
+		switch {
+		case key.Matches(m, key.NewBinding(key.WithKeys("p"))):
+			s.progress.Reset()
+			s.progress.UpdateProgress(1, 2)
+
+			return orvyn.OpenDialog("progress", s.progress, nil)
+
+		case key.Matches(m, key.NewBinding(key.WithKeys("d"))):
+			return orvyn.PushScreen("detail", s.tiName.Value())
+		}
+	}
+
+	return s.focusManager.Update(msg)
+}
+
+func (s *formScreen) Render() orvyn.Layout {
+	return s.layout
+}
+
+// detailScreen shows the param it was entered with.
+type detailScreen struct {
+	text *orvyn.SimpleRenderable
+}
+
+func (s *detailScreen) OnEnter(param any) tea.Cmd {
+	s.text.SetValue("Detail of " + param.(string))
+	return nil
+}
+
+func (s *detailScreen) OnExit() any {
+	return nil
+}
+
+func (s *detailScreen) Update(msg tea.Msg) tea.Cmd {
+	if m, ok := orvyn.GetKeyMsg(msg); ok && m.String() == "esc" {
+		return orvyn.PopScreen()
+	}
+
+	return nil
+}
+
+func (s *detailScreen) Render() orvyn.Layout {
+	return layout.NewVBoxLayout(0, s.text)
+}
+
+func newHarness(t *testing.T) (*Harness, *formScreen) {
+	h := New(t, orvyn.NewSize(60, 20))
+
+	form := newFormScreen()
+
+	h.Register("form", form)
+	h.Register("detail", &detailScreen{text: orvyn.NewSimpleRenderable("")})
+	h.Start("form")
+
+	return h, form
+}
+
+func TestTypeFocusAndNavigate(t *testing.T) {
+	h, form := newHarness(t)
+
+	h.AssertScreen("form")
+	h.AssertFocused(form.tiName)
+	h.AssertInputting(form.tiName)
+
+	h.Type("orvyn")
+	h.Press("esc", "tab")
+
+	h.AssertContains("orvyn")
+	h.AssertFocused(form.cbAgree)
+	h.AssertNotFocused(form.tiName)
+
+	h.Press(" ")
+
+	if !form.cbAgree.IsChecked() {
+		t.Errorf("space did not check the checkbox")
+	}
+
+	h.Press("d")
+
+	h.AssertScreen("detail")
+	h.AssertContains("Detail of orvyn")
+
+	h.Press("esc")
+
+	h.AssertScreen("form")
+}
+
+func TestClickHitTestsTheFrame(t *testing.T) {
+	h, form := newHarness(t)
+
+	// The text input takes the first 3 lines, the checkbox the next 3.
+	h.Click(2, 4)
+
+	h.AssertFocused(form.cbAgree)
+}
+
+func TestTickCmdDrivenByFakeClock(t *testing.T) {
+	h, form := newHarness(t)
+
+	h.Press("esc", "p")
+
+	h.AssertDialogDepth(1)
+
+	if h.Clock.Pending() != 1 {
+		t.Fatalf("%d ticks pending, want the progress one", h.Clock.Pending())
+	}
+
+	h.Advance(0)
+
+	h.AssertContains("Working (1/2)")
+
+	form.progress.UpdateProgress(2, 2)
+
+	h.Advance(500 * time.Millisecond)
+
+	h.AssertDialogDepth(1)
+
+	h.Advance(500 * time.Millisecond)
+
+	// The dialog closes on the message following the complete progress.
+	h.Press("x")
+
+	h.AssertDialogDepth(0)
+}
+
+func TestQuit(t *testing.T) {
+	h, _ := newHarness(t)
+
+	h.Press("ctrl+c")
+
+	if !h.Quit() {
+		t.Errorf("ctrl+c did not quit")
+	}
+}
+
+func TestKeyMsg(t *testing.T) {
+	for _, k := range []string{"enter", "tab", "shift+tab", "ctrl+c", "up", "pgdown", " ", "a", "alt+x", "é"} {
+		msg, ok := KeyMsg(k)
+
+		if !ok {
+			t.Errorf("KeyMsg(%q) unknown", k)
+			continue
+		}
+
+		if msg.String() != k {
+			t.Errorf("KeyMsg(%q).String() = %q", k, msg.String())
+		}
+	}
+
+	if _, ok := KeyMsg("nokey"); ok {
+		t.Errorf("KeyMsg(\"nokey\") must be unknown")
+	}
+}
+
+// tickerScreen counts the ticks of a one second ticker, and runs the command
+// sent to it in a cmdMsg.
+type tickerScreen struct {
+	ticks   []time.Time
+	tickTag uint
+
+	loaded string
+}
+
+type cmdMsg struct {
+	cmd tea.Cmd
+}
+
+type loadedMsg string
+
+func (s *tickerScreen) OnEnter(any) tea.Cmd {
+	return orvyn.TickCmd(1, s.tickTag)
+}
+
+func (s *tickerScreen) OnExit() any {
+	return nil
+}
+
+func (s *tickerScreen) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case orvyn.TickMsg:
+		if msg.Tag != s.tickTag {
+			return nil
+		}
+
+		s.ticks = append(s.ticks, msg.Time)
+
+		s.tickTag++
+		return orvyn.TickCmd(1, s.tickTag)
+
+	case cmdMsg:
+		return msg.cmd
+
+	case loadedMsg:
+		s.loaded = string(msg)
+	}
+
+	return nil
+}
+
+func (s *tickerScreen) Render() orvyn.Layout {
+	return layout.NewVBoxLayout(0)
+}
+
+func newTickerHarness(t *testing.T) (*Harness, *tickerScreen) {
+	h := New(t, orvyn.NewSize(20, 5))
+
+	screen := new(tickerScreen)
+
+	h.Register("ticker", screen)
+	h.Start("ticker")
+
+	return h, screen
+}
+
+func TestAdvanceFiresReArmedTicks(t *testing.T) {
+	h, screen := newTickerHarness(t)
+	start := h.Clock.Now()
+
+	h.Advance(3 * time.Second)
+
+	if len(screen.ticks) != 3 {
+		t.Fatalf("%d ticks after 3s of a 1s ticker, want 3", len(screen.ticks))
+	}
+
+	for i, tick := range screen.ticks {
+		if want := start.Add(time.Duration(i+1) * time.Second); !tick.Equal(want) {
+			t.Errorf("tick %d at %v, want %v", i, tick, want)
+		}
+	}
+
+	if !h.Clock.Now().Equal(start.Add(3 * time.Second)) {
+		t.Errorf("clock at %v after Advance, want the end", h.Clock.Now())
+	}
+
+	h.Advance(500 * time.Millisecond)
+	h.Advance(500 * time.Millisecond)
+
+	if len(screen.ticks) != 4 {
+		t.Errorf("%d ticks after 4s, want 4", len(screen.ticks))
+	}
+}
+
+func TestSlowCmdRunsSynchronously(t *testing.T) {
+	h, screen := newTickerHarness(t)
+
+	h.Send(cmdMsg{func() tea.Msg {
+		time.Sleep(100 * time.Millisecond)
+
+		return loadedMsg("children")
+	}})
+
+	if screen.loaded != "children" {
+		t.Errorf("slow command message not dispatched")
+	}
+}
+
+func TestSkipDropsCmd(t *testing.T) {
+	h, screen := newTickerHarness(t)
+
+	load := func() tea.Msg { return loadedMsg("skipped") }
+
+	h.Skip(load)
+	h.Send(cmdMsg{load})
+
+	if screen.loaded != "" {
+		t.Errorf("skipped command ran")
+	}
+}
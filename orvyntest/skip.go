@@ -0,0 +1,15 @@
+package orvyntest
+
+import (
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CursorBlink returns a cursor blink command of the bubbles cursor, used by the
+// text inputs and the text areas, to give to Harness.Skip. The command waits
+// for the blink speed before returning its message.
+func CursorBlink() tea.Cmd {
+	c := cursor.New()
+
+	return c.BlinkCmd()
+}
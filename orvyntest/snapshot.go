@@ -0,0 +1,148 @@
+package orvyntest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+	"github.com/muesli/termenv"
+)
+
+// update rewrites the golden files instead of comparing against them:
+//
+//	go test ./layout -update
+var update = flag.Bool("update", false, "update the orvyntest golden files")
+
+// GoldenDir is the directory, relative to the package under test, holding the
+// golden files.
+const GoldenDir = "testdata"
+
+// Sizes commonly used for snapshots.
+var (
+	NarrowSize = orvyn.NewSize(20, 6)
+	NormalSize = orvyn.NewSize(80, 24)
+	HugeSize   = orvyn.NewSize(200, 50)
+
+	// DefaultSizes holds the sizes used when a snapshot is given none.
+	DefaultSizes = []orvyn.Size{NarrowSize, NormalSize, HugeSize}
+)
+
+// AssertSnapshot renders the Renderable at every given size, DefaultSizes when
+// none is given, and compares the result without ANSI sequences against the
+// golden file testdata/<name>.golden. With the -update flag, the golden file
+// is written instead.
+func AssertSnapshot(t testing.TB, name string, r orvyn.Renderable, sizes ...orvyn.Size) {
+	t.Helper()
+
+	assertSnapshot(t, name, r, sizes, false)
+}
+
+// AssertSnapshotANSI behaves like AssertSnapshot but keeps the ANSI sequences,
+// rendered with a true color profile so the result does not depend on the
+// terminal running the tests.
+func AssertSnapshotANSI(t testing.TB, name string, r orvyn.Renderable, sizes ...orvyn.Size) {
+	t.Helper()
+
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	defer lipgloss.SetColorProfile(profile)
+
+	assertSnapshot(t, name, r, sizes, true)
+}
+
+// RenderSnapshot returns the snapshot text of the Renderable: one section per
+// size, each headed with the given size and the size actually rendered, so an
+// overflow is visible in the golden file.
+func RenderSnapshot(r orvyn.Renderable, sizes []orvyn.Size, keepANSI bool) string {
+	var b strings.Builder
+
+	if len(sizes) == 0 {
+		sizes = DefaultSizes
+	}
+
+	for i, size := range sizes {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		r.Resize(size)
+
+		view := r.Render()
+		width, height := lipgloss.Size(view)
+
+		if !keepANSI {
+			view = ansi.Strip(view)
+		}
+
+		fmt.Fprintf(&b, "=== %dx%d (rendered %dx%d) ===\n%s\n",
+			size.Width, size.Height, width, height, view)
+	}
+
+	return b.String()
+}
+
+func assertSnapshot(t testing.TB, name string, r orvyn.Renderable, sizes []orvyn.Size, keepANSI bool) {
+	t.Helper()
+
+	got := RenderSnapshot(r, sizes, keepANSI)
+	path := filepath.Join(GoldenDir, name+".golden")
+
+	if *update {
+		if err := os.MkdirAll(GoldenDir, 0o755); err != nil {
+			t.Fatalf("orvyntest: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("orvyntest: %v", err)
+		}
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("orvyntest: %v (run the test with -update to create it)", err)
+		return
+	}
+
+	if got != string(want) {
+		t.Errorf("snapshot %s differs from %s:\n%s", name, path, diffLines(string(want), got))
+	}
+}
+
+// diffLines returns the lines that differ between want and got, numbered, so
+// a failing snapshot points at the faulty row instead of dumping both.
+func diffLines(want, got string) string {
+	var b strings.Builder
+
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	for i := range max(len(wantLines), len(gotLines)) {
+		var w, g string
+
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+
+		if w == g {
+			continue
+		}
+
+		fmt.Fprintf(&b, "line %d:\n  want %q\n  got  %q\n", i+1, w, g)
+	}
+
+	return b.String()
+}
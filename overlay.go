@@ -0,0 +1,62 @@
+package orvyn
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PlaceOverlay draws the foreground view over the background view, with the
+// top left corner of the foreground at the given column and row. The parts of
+// the foreground outside the background are dropped. Background lines shorter
+// than the column are padded with spaces.
+func PlaceOverlay(x, y int, foreground, background string) string {
+	bgLines := strings.Split(background, "\n")
+	fgLines := strings.Split(foreground, "\n")
+
+	x = max(x, 0)
+
+	for i, fgLine := range fgLines {
+		row := y + i
+
+		if row < 0 || row >= len(bgLines) {
+			continue
+		}
+
+		bgLine := bgLines[row]
+		bgWidth := ansi.StringWidth(bgLine)
+
+		if bgWidth < x {
+			bgLine += strings.Repeat(" ", x-bgWidth)
+		}
+
+		fgWidth := ansi.StringWidth(fgLine)
+
+		left := ansi.Truncate(bgLine, x, "")
+		right := ansi.TruncateLeft(bgLine, x+fgWidth, "")
+
+		// Cutting the background can leave one of its styles open, which would
+		// then bleed into the foreground line: reset in between.
+		if strings.Contains(bgLine, "\x1b") {
+			left += ansi.ResetStyle
+			fgLine += ansi.ResetStyle
+		}
+
+		bgLines[row] = left + fgLine + right
+	}
+
+	return strings.Join(bgLines, "\n")
+}
+
+// DimView strips the styles of the given view and renders every line of it
+// with the given style. Used to push a view to the background, behind a dialog.
+func DimView(view string, style lipgloss.Style) string {
+	lines := strings.Split(ansi.Strip(view), "\n")
+
+	for i, line := range lines {
+		lines[i] = style.Render(line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,115 @@
+package orvyn
+
+import "github.com/charmbracelet/lipgloss"
+
+// popup is a Renderable drawn over the view next to the widget owning it, a
+// dropdown list for example. See App.OpenPopup.
+type popup struct {
+	owner   Renderable
+	content Renderable
+
+	// ownerPosition and rect are where the owner was found and the popup was
+	// drawn on the screen by the last Render. rect is empty when the owner was
+	// not rendered.
+	ownerPosition Position
+	rect          Rect
+}
+
+// OpenPopup draws content over the view, under the owner or above it when
+// there is more room there, at least as wide as the owner. The popup follows
+// the owner and is only drawn while the owner is rendered in the active
+// dialog or screen. The clicks on the popup are given to the active dialog or
+// screen as if they were on the owner, with the popup elements as the deepest
+// hits. Only one popup is open at a time, the previous one is replaced.
+func (a *App) OpenPopup(owner, content Renderable) {
+	a.popup = &popup{owner: owner, content: content}
+}
+
+// ClosePopup closes the popup of the owner, if open.
+func (a *App) ClosePopup(owner Renderable) {
+	if a.IsPopupOpen(owner) {
+		a.popup = nil
+	}
+}
+
+// IsPopupOpen returns true if the owner has an open popup.
+func (a *App) IsPopupOpen(owner Renderable) bool {
+	return a.popup != nil && a.popup.owner == owner
+}
+
+// Hidden functions
+
+// renderPopup draws the open popup over the view, next to its owner in the
+// layout receiving the mouse.
+func (a *App) renderPopup(view string) string {
+	p := a.popup
+
+	if p == nil {
+		return view
+	}
+
+	p.rect = Rect{}
+
+	if a.mouseRoot == nil {
+		return view
+	}
+
+	position, ok := FindPosition(a.mouseRoot, p.owner)
+
+	if !ok {
+		return view
+	}
+
+	position = a.mouseOrigin.Add(position)
+	ownerSize := p.owner.GetSize()
+
+	size := p.content.GetPreferredSize()
+	size.Width = min(max(size.Width, ownerSize.Width), a.WindowSize.Width)
+
+	below := a.WindowSize.Height - position.Y - ownerSize.Height
+	above := position.Y
+	y := position.Y + ownerSize.Height
+
+	if size.Height > below && above > below {
+		size.Height = min(size.Height, above)
+		y = position.Y - size.Height
+	} else {
+		size.Height = min(size.Height, max(below, 0))
+	}
+
+	x := max(min(position.X, a.WindowSize.Width-size.Width), 0)
+
+	p.content.Resize(size)
+
+	rendered := p.content.Render()
+
+	p.ownerPosition = position
+	p.rect = NewRect(NewPosition(x, y), p.content.GetSize())
+
+	// The view can be shorter than the window, the popup can go under it.
+	view = lipgloss.Place(a.WindowSize.Width, a.WindowSize.Height,
+		lipgloss.Left, lipgloss.Top, view)
+
+	return a.clip(PlaceOverlay(x, y, rendered, view))
+}
+
+// hitPopup returns the hits of a point over the open popup: the path down to
+// the owner, then the popup elements. False when the point is not over it.
+func (a *App) hitPopup(point Position) ([]Hit, bool) {
+	p := a.popup
+
+	if p == nil || a.mouseRoot == nil || !p.rect.Contains(point) {
+		return nil, false
+	}
+
+	hits := HitTest(a.mouseRoot, a.mouseOrigin, p.ownerPosition)
+
+	for i, h := range hits {
+		if h.Renderable == p.owner {
+			hits = hits[:i+1]
+			break
+		}
+	}
+
+	return append(hits, HitTest(p.content, p.rect.Position, point)...), true
+}
@@ -0,0 +1,57 @@
+package orvyn
+
+// Position is a simple struct to represent a position, in cells from the top
+// left corner.
+type Position struct {
+	X int
+	Y int
+}
+
+// NewPosition returns a new Position.
+func NewPosition(x, y int) Position {
+	return Position{x, y}
+}
+
+// Add returns the position moved by the given one.
+func (p Position) Add(other Position) Position {
+	return NewPosition(p.X+other.X, p.Y+other.Y)
+}
+
+// PositionedLayout is implemented by layouts that record, during Render, the
+// position they gave to each of their elements. BaseLayout implements it.
+type PositionedLayout interface {
+	Layout
+
+	// GetElementPosition returns the position of the element relative to the
+	// layout, as of the last Render.
+	GetElementPosition(Renderable) (Position, bool)
+}
+
+// FindPosition walks down the layouts from the root and returns the position
+// of the target relative to the root, as of the last Render. The bool is false
+// when the target was not found through PositionedLayout elements.
+func FindPosition(root, target Renderable) (Position, bool) {
+	if root == target {
+		return NewPosition(0, 0), true
+	}
+
+	layout, ok := root.(PositionedLayout)
+
+	if !ok {
+		return Position{}, false
+	}
+
+	for _, e := range layout.GetElements() {
+		offset, ok := layout.GetElementPosition(e)
+
+		if !ok {
+			continue
+		}
+
+		if position, ok := FindPosition(e, target); ok {
+			return offset.Add(position), true
+		}
+	}
+
+	return Position{}, false
+}
@@ -0,0 +1,50 @@
+package orvyn
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// ThemeChangedMsg is sent by SetTheme. The App gives it to every registered
+// screen, every open dialog and every element of their layouts, the hidden ones
+// included, so the widgets re-derive their styles from the new theme. Screens
+// holding widgets outside of their layout must forward it to them, a
+// FocusManager gives it to all its widgets.
+type ThemeChangedMsg struct {
+	Theme theme.Theme
+}
+
+// Broadcast gives the message to the renderable, if it is Updatable, then to
+// every element reachable from it through layouts, the inactive ones of a
+// FullLayout included.
+func Broadcast(root Renderable, msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	if u, ok := root.(Updatable); ok {
+		cmds = append(cmds, u.Update(msg))
+	}
+
+	var elements []Renderable
+
+	switch l := root.(type) {
+	case FullLayout:
+		elements = l.GetAllElements()
+	case Layout:
+		elements = l.GetElements()
+	}
+
+	for _, e := range elements {
+		cmds = append(cmds, Broadcast(e, msg))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Hidden functions
+
+// themeChangedCmd returns a tea.Cmd sending a ThemeChangedMsg.
+func themeChangedCmd(t theme.Theme) tea.Cmd {
+	return func() tea.Msg {
+		return ThemeChangedMsg{t}
+	}
+}
@@ -0,0 +1,68 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AdaptiveTheme uses its Dark theme on dark terminal backgrounds and its
+// Light theme otherwise. The background is detected by lipgloss through
+// termenv, once for the process.
+type AdaptiveTheme struct {
+	Dark  Theme
+	Light Theme
+
+	// HasDarkBackground reports the terminal background,
+	// lipgloss.HasDarkBackground by default.
+	HasDarkBackground func() bool
+}
+
+// NewAdaptiveTheme creates an AdaptiveTheme choosing between the
+// DefaultDarkTheme and the LightTheme.
+func NewAdaptiveTheme() *AdaptiveTheme {
+	a := &AdaptiveTheme{}
+
+	a.Dark = NewDefaultDarkTheme()
+	a.Light = NewLightTheme()
+	a.HasDarkBackground = lipgloss.HasDarkBackground
+
+	return a
+}
+
+func (a *AdaptiveTheme) Style(style StyleID) lipgloss.Style {
+	return a.Current().Style(style)
+}
+
+func (a *AdaptiveTheme) Color(color ColorID) lipgloss.Color {
+	return a.Current().Color(color)
+}
+
+func (a *AdaptiveTheme) Size(size SizeID) int {
+	return a.Current().Size(size)
+}
+
+// ANSIColor returns the 16-color of the current theme, see Degrade.
+func (a *AdaptiveTheme) ANSIColor(color ColorID) lipgloss.Color {
+	if p, ok := a.Current().(ANSIPalette); ok {
+		return p.ANSIColor(color)
+	}
+
+	return a.Current().Color(color)
+}
+
+// AdaptiveColor returns both variants of the color, for styles built outside
+// of the theme and rendered by lipgloss on any background.
+func (a *AdaptiveTheme) AdaptiveColor(color ColorID) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{
+		Light: string(a.Light.Color(color)),
+		Dark:  string(a.Dark.Color(color)),
+	}
+}
+
+// Current returns the theme matching the terminal background.
+func (a *AdaptiveTheme) Current() Theme {
+	if a.HasDarkBackground() {
+		return a.Dark
+	}
+
+	return a.Light
+}
@@ -42,6 +42,8 @@ func (d DefaultDarkTheme) Style(style StyleID) lipgloss.Style {
 	case DimSecondaryTextStyleID:
 		s = s.Italic(true).Foreground(d.Theme.Color(DimFontColorID))
 
+	case LabelTextStyleID:
+
 	case FocusedWidgetStyleID:
 		s = s.Border(lipgloss.RoundedBorder()).
 			BorderForeground(d.Theme.Color(FocusedBorderColorID))
@@ -76,6 +78,57 @@ func (d DefaultDarkTheme) Style(style StyleID) lipgloss.Style {
 		s = s.AlignHorizontal(lipgloss.Center).
 			Foreground(d.Theme.Color(StatusNeutralFontColorID))
 
+	case DialogStyleID:
+		s = s.Border(lipgloss.RoundedBorder()).
+			BorderForeground(d.Theme.Color(FocusedBorderColorID)).
+			Padding(1, 2)
+
+	case DimmedBackgroundStyleID:
+		s = s.Faint(true).Foreground(d.Theme.Color(NeutralDimFontColorID))
+
+	case ScrollbarTrackStyleID:
+		s = s.Foreground(d.Theme.Color(DimFontColorID))
+
+	case ScrollbarThumbStyleID:
+		s = s.Foreground(d.Theme.Color(NormalFontColorID))
+
+	case TabActiveStyleID:
+		s = s.Bold(true).Underline(true).Padding(0, 1).
+			Foreground(d.Theme.Color(HighlightFontColorID))
+
+	case TabInactiveStyleID:
+		s = s.Padding(0, 1).Foreground(d.Theme.Color(DimFontColorID))
+
+	case TableHeaderStyleID:
+		s = s.Bold(true).Foreground(d.Theme.Color(TitleFontColorID)).
+			Border(lipgloss.NormalBorder(), false, false, true, false).
+			BorderForeground(d.Theme.Color(DimFontColorID))
+
+	case TableCursorStyleID:
+		s = s.Bold(true).Foreground(d.Theme.Color(HighlightFontColorID))
+
+	case TableSelectedStyleID:
+		s = s.Foreground(d.Theme.Color(NeutralFontColorID))
+
+	case HelpKeyStyleID:
+		s = s.Bold(true).Foreground(d.Theme.Color(NormalFontColorID))
+
+	case HelpDescStyleID:
+		s = s.Foreground(d.Theme.Color(DimFontColorID))
+
+	case ErrorWidgetStyleID:
+		s = s.Border(lipgloss.RoundedBorder()).
+			BorderForeground(d.Theme.Color(StatusErrorFontColorID))
+
+	case SelectionMarkerStyleID:
+		s = s.Bold(true).Foreground(d.Theme.Color(HighlightFontColorID))
+
+	case SectionHeaderStyleID:
+		s = s.Bold(true).Foreground(d.Theme.Color(TitleFontColorID))
+
+	default:
+		s = FallbackStyle(d.Theme, style)
+
 	}
 
 	return s
@@ -112,14 +165,54 @@ func (d DefaultDarkTheme) Color(color ColorID) lipgloss.Color {
 	case StatusNeutralFontColorID:
 		colorHexCode = "#D0D0D0"
 
-	default:
+	case TitleFontColorID, NormalFontColorID, FocusedBorderColorID, FocusedFontColorID:
 		colorHexCode = "#18B718"
 
+	default:
+		return FallbackColor(d.Theme, color)
+
 	}
 
 	return lipgloss.Color(colorHexCode)
 }
 
 func (d DefaultDarkTheme) Size(size SizeID) int {
-	return 0
+	return FallbackSize(size)
+}
+
+// ANSIColor returns the color used on 16-color terminals, see Degrade.
+func (d DefaultDarkTheme) ANSIColor(color ColorID) lipgloss.Color {
+	var ansi string
+
+	switch color {
+	case NeutralFontColorID:
+		ansi = "15"
+
+	case NeutralDimFontColorID, StatusNeutralFontColorID:
+		ansi = "7"
+
+	case BlurredBorderColorID, BlurredFontColorID, DimFontColorID:
+		ansi = "8"
+
+	case HighlightFontColorID, StatusSuccessFontColorID:
+		ansi = "10"
+
+	case StatusErrorFontColorID:
+		ansi = "9"
+
+	case StatusWarningFontColorID:
+		ansi = "11"
+
+	case StatusInformationFontColorID:
+		ansi = "12"
+
+	case TitleFontColorID, NormalFontColorID, FocusedBorderColorID, FocusedFontColorID:
+		ansi = "2"
+
+	default:
+		return d.ANSIColor(fallbackColorID(color))
+
+	}
+
+	return lipgloss.Color(ansi)
 }
@@ -0,0 +1,159 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ANSIPalette is implemented by themes choosing their own colors for
+// 16-color terminals, instead of the nearest ANSI color lipgloss would
+// pick.
+type ANSIPalette interface {
+	ANSIColor(ColorID) lipgloss.Color
+}
+
+// DegradedTheme renders a Theme on a terminal with a limited color profile,
+// see Degrade.
+type DegradedTheme struct {
+	theme   Theme
+	profile termenv.Profile
+
+	// ansi maps the colors of the theme to its 16-color palette.
+	ansi map[lipgloss.Color]lipgloss.Color
+}
+
+// Degrade adapts the theme to the color profile. 256 and true color
+// terminals get the theme as is. 16-color terminals get the ANSIPalette of
+// the theme when it has one. Terminals without colors, NO_COLOR being set
+// for example, get the styles without colors, plus attributes keeping the
+// focus and the cursors visible.
+func Degrade(t Theme, profile termenv.Profile) Theme {
+	if profile == termenv.TrueColor || profile == termenv.ANSI256 {
+		return t
+	}
+
+	d := new(DegradedTheme)
+
+	d.theme = t
+	d.profile = profile
+	d.ansi = make(map[lipgloss.Color]lipgloss.Color)
+
+	if p, ok := t.(ANSIPalette); ok {
+		for id := range colorNames {
+			d.ansi[t.Color(id)] = p.ANSIColor(id)
+		}
+	}
+
+	return d
+}
+
+// ForTerminal adapts the theme to the color profile of the terminal,
+// lipgloss.ColorProfile, see Degrade.
+func ForTerminal(t Theme) Theme {
+	return Degrade(t, lipgloss.ColorProfile())
+}
+
+func (d *DegradedTheme) Style(style StyleID) lipgloss.Style {
+	s := d.theme.Style(style)
+
+	if d.profile != termenv.Ascii {
+		return mapStyleColors(s, d.mapColor)
+	}
+
+	s = s.UnsetForeground().
+		UnsetBackground().
+		UnsetBorderForeground().
+		UnsetBorderBackground()
+
+	switch style {
+	case FocusedWidgetStyleID:
+		s = replaceBorder(s, lipgloss.ThickBorder())
+
+	case ErrorWidgetStyleID:
+		s = replaceBorder(s, lipgloss.DoubleBorder())
+
+	case HighlightTextStyleID, TableCursorStyleID:
+		s = s.Reverse(true)
+
+	case TableSelectedStyleID:
+		s = s.Underline(true)
+
+	}
+
+	return s
+}
+
+func (d *DegradedTheme) Color(color ColorID) lipgloss.Color {
+	if d.profile == termenv.Ascii {
+		return lipgloss.Color("")
+	}
+
+	return d.mapColor(d.theme.Color(color))
+}
+
+func (d *DegradedTheme) Size(size SizeID) int {
+	return d.theme.Size(size)
+}
+
+// Hidden functions
+
+// mapColor returns the 16-color of a color of the theme.
+func (d *DegradedTheme) mapColor(c lipgloss.Color) lipgloss.Color {
+	if ansi, ok := d.ansi[c]; ok {
+		return ansi
+	}
+
+	return c
+}
+
+// replaceBorder changes the visible border of the style, keeping its sides.
+func replaceBorder(s lipgloss.Style, border lipgloss.Border) lipgloss.Style {
+	current := s.GetBorderStyle()
+
+	if current == lipgloss.HiddenBorder() || current == (lipgloss.Border{}) {
+		return s
+	}
+
+	return s.BorderStyle(border)
+}
+
+// mapStyleColors replaces the lipgloss.Color colors of the style.
+func mapStyleColors(s lipgloss.Style, mapColor func(lipgloss.Color) lipgloss.Color) lipgloss.Style {
+	mapped := func(tc lipgloss.TerminalColor) (lipgloss.TerminalColor, bool) {
+		c, ok := tc.(lipgloss.Color)
+
+		if !ok {
+			return tc, false
+		}
+
+		return mapColor(c), true
+	}
+
+	if c, ok := mapped(s.GetForeground()); ok {
+		s = s.Foreground(c)
+	}
+
+	if c, ok := mapped(s.GetBackground()); ok {
+		s = s.Background(c)
+	}
+
+	for _, side := range []struct {
+		get func(lipgloss.Style) lipgloss.TerminalColor
+		set func(lipgloss.Style, lipgloss.TerminalColor) lipgloss.Style
+	}{
+		{lipgloss.Style.GetBorderTopForeground, lipgloss.Style.BorderTopForeground},
+		{lipgloss.Style.GetBorderRightForeground, lipgloss.Style.BorderRightForeground},
+		{lipgloss.Style.GetBorderBottomForeground, lipgloss.Style.BorderBottomForeground},
+		{lipgloss.Style.GetBorderLeftForeground, lipgloss.Style.BorderLeftForeground},
+		{lipgloss.Style.GetBorderTopBackground, lipgloss.Style.BorderTopBackground},
+		{lipgloss.Style.GetBorderRightBackground, lipgloss.Style.BorderRightBackground},
+		{lipgloss.Style.GetBorderBottomBackground, lipgloss.Style.BorderBottomBackground},
+		{lipgloss.Style.GetBorderLeftBackground, lipgloss.Style.BorderLeftBackground},
+	} {
+		if c, ok := mapped(side.get(s)); ok {
+			s = side.set(s, c)
+		}
+	}
+
+	return s
+}
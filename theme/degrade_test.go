@@ -0,0 +1,66 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestDegrade(t *testing.T) {
+	dark := NewDefaultDarkTheme()
+
+	if Degrade(dark, termenv.TrueColor) != Theme(dark) {
+		t.Error("true color terminals should get the theme as is")
+	}
+
+	ansi := Degrade(dark, termenv.ANSI)
+
+	if got := ansi.Style(NormalTextStyleID).GetForeground(); got != lipgloss.Color("2") {
+		t.Errorf("16-color normal text foreground = %v, want 2", got)
+	}
+
+	if got := ansi.Style(FocusedWidgetStyleID).GetBorderTopForeground(); got != lipgloss.Color("2") {
+		t.Errorf("16-color focused border = %v, want 2", got)
+	}
+
+	if got := ansi.Color(StatusErrorFontColorID); got != lipgloss.Color("9") {
+		t.Errorf("16-color error color = %v, want 9", got)
+	}
+
+	ascii := Degrade(dark, termenv.Ascii)
+
+	focused := ascii.Style(FocusedWidgetStyleID)
+
+	if _, ok := focused.GetBorderTopForeground().(lipgloss.NoColor); !ok {
+		t.Errorf("no-color focused border has a color: %v", focused.GetBorderTopForeground())
+	}
+
+	if focused.GetBorderStyle() == ascii.Style(BlurredWidgetStyleID).GetBorderStyle() {
+		t.Error("no-color focused and blurred widgets look the same")
+	}
+
+	if !ascii.Style(TableCursorStyleID).GetReverse() {
+		t.Error("no-color table cursor is not reversed")
+	}
+}
+
+func TestAdaptiveTheme(t *testing.T) {
+	a := NewAdaptiveTheme()
+
+	a.HasDarkBackground = func() bool { return true }
+
+	if got, want := a.Color(NormalFontColorID), NewDefaultDarkTheme().Color(NormalFontColorID); got != want {
+		t.Errorf("dark background color = %v, want %v", got, want)
+	}
+
+	a.HasDarkBackground = func() bool { return false }
+
+	if got, want := a.Style(NormalTextStyleID).GetForeground(), NewLightTheme().Color(NormalFontColorID); got != want {
+		t.Errorf("light background foreground = %v, want %v", got, want)
+	}
+
+	if got := Degrade(a, termenv.ANSI).Color(NeutralFontColorID); got != lipgloss.Color("0") {
+		t.Errorf("light 16-color neutral color = %v, want 0", got)
+	}
+}
@@ -0,0 +1,145 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Inheritable is implemented by themes building their styles from the colors
+// of another Theme. Inherit returns a copy of the theme resolving its colors
+// with child, so that a theme inheriting from it gets the base styles with
+// its own colors.
+type Inheritable interface {
+	Inherit(child Theme) Theme
+}
+
+// ExtendedTheme is a Theme inheriting from a base theme and overriding only
+// some of its entries, see Extend.
+type ExtendedTheme struct {
+	base Theme
+
+	styles map[StyleID]lipgloss.Style
+	colors map[ColorID]lipgloss.Color
+	sizes  map[SizeID]int
+}
+
+// Extend creates an ExtendedTheme inheriting from base. The styles of base
+// use the colors overridden by the ExtendedTheme when base is Inheritable,
+// which every theme of this package is.
+//
+//	t := theme.Extend(theme.NewDefaultDarkTheme()).
+//		SetColor(theme.NormalFontColorID, "#1F6FEB").
+//		SetStyle(BadgeStyleID, lipgloss.NewStyle().Reverse(true))
+func Extend(base Theme) *ExtendedTheme {
+	e := new(ExtendedTheme)
+
+	e.styles = make(map[StyleID]lipgloss.Style)
+	e.colors = make(map[ColorID]lipgloss.Color)
+	e.sizes = make(map[SizeID]int)
+	e.base = inherit(base, e)
+
+	return e
+}
+
+// SetStyle overrides the style of the StyleID.
+func (e *ExtendedTheme) SetStyle(id StyleID, style lipgloss.Style) *ExtendedTheme {
+	e.styles[id] = style
+
+	return e
+}
+
+// SetColor overrides the color of the ColorID.
+func (e *ExtendedTheme) SetColor(id ColorID, color lipgloss.Color) *ExtendedTheme {
+	e.colors[id] = color
+
+	return e
+}
+
+// SetSize overrides the value of the SizeID.
+func (e *ExtendedTheme) SetSize(id SizeID, size int) *ExtendedTheme {
+	e.sizes[id] = size
+
+	return e
+}
+
+func (e *ExtendedTheme) Style(style StyleID) lipgloss.Style {
+	if s, ok := e.styles[style]; ok {
+		return s
+	}
+
+	return e.base.Style(style)
+}
+
+func (e *ExtendedTheme) Color(color ColorID) lipgloss.Color {
+	if c, ok := e.colors[color]; ok {
+		return c
+	}
+
+	return e.base.Color(color)
+}
+
+func (e *ExtendedTheme) Size(size SizeID) int {
+	if s, ok := e.sizes[size]; ok {
+		return s
+	}
+
+	return e.base.Size(size)
+}
+
+// ANSIColor returns the 16-color of the base theme, or the overridden color
+// itself, see Degrade.
+func (e *ExtendedTheme) ANSIColor(color ColorID) lipgloss.Color {
+	if c, ok := e.colors[color]; ok {
+		return c
+	}
+
+	if p, ok := e.base.(ANSIPalette); ok {
+		return p.ANSIColor(color)
+	}
+
+	return e.base.Color(color)
+}
+
+func (e *ExtendedTheme) Inherit(child Theme) Theme {
+	c := *e
+	c.base = inherit(e.base, child)
+
+	return &c
+}
+
+func (d DefaultDarkTheme) Inherit(child Theme) Theme {
+	d.Theme = child
+
+	return d
+}
+
+func (l LightTheme) Inherit(child Theme) Theme {
+	l.Theme = child
+
+	return l
+}
+
+func (h HighContrastTheme) Inherit(child Theme) Theme {
+	h.Theme = child
+
+	return h
+}
+
+func (a *AdaptiveTheme) Inherit(child Theme) Theme {
+	c := *a
+	c.Dark = inherit(a.Dark, child)
+	c.Light = inherit(a.Light, child)
+
+	return &c
+}
+
+// Hidden functions
+
+// inherit returns base resolving its colors with child when it is
+// Inheritable, base itself otherwise.
+func inherit(base Theme, child Theme) Theme {
+	if i, ok := base.(Inheritable); ok {
+		return i.Inherit(child)
+	}
+
+	return base
+}
@@ -0,0 +1,99 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	testBadgeStyleID  = RegisterStyleID("test_badge", HighlightTextStyleID)
+	testAccentColorID = RegisterColorID("test_accent", HighlightFontColorID)
+	testGutterSizeID  = RegisterSizeID("test_gutter", 2)
+)
+
+func TestRegisteredIDsFallback(t *testing.T) {
+	dark := NewDefaultDarkTheme()
+
+	if got, want := dark.Style(testBadgeStyleID).GetForeground(), dark.Color(HighlightFontColorID); got != want {
+		t.Errorf("registered style foreground = %v, want the fallback %v", got, want)
+	}
+
+	if got, want := dark.Color(testAccentColorID), dark.Color(HighlightFontColorID); got != want {
+		t.Errorf("registered color = %v, want the fallback %v", got, want)
+	}
+
+	if got := dark.Size(testGutterSizeID); got != 2 {
+		t.Errorf("registered size = %d, want 2", got)
+	}
+
+	if got, want := dark.Style(StyleID(1000)).GetForeground(), dark.Color(NormalFontColorID); got != want {
+		t.Errorf("unknown style foreground = %v, want the normal text %v", got, want)
+	}
+
+	if id, ok := StyleIDByName("test_badge"); !ok || id != testBadgeStyleID {
+		t.Errorf("StyleIDByName(test_badge) = %v, %v", id, ok)
+	}
+}
+
+func TestExtend(t *testing.T) {
+	base := Extend(NewLightTheme()).
+		SetColor(HighlightFontColorID, "#FF00FF")
+
+	e := Extend(base).
+		SetColor(NormalFontColorID, "#1F6FEB").
+		SetStyle(testBadgeStyleID, lipgloss.NewStyle().Reverse(true)).
+		SetSize(testGutterSizeID, 4)
+
+	// The base styles use the colors of the inheriting themes.
+	if got := e.Style(NormalTextStyleID).GetForeground(); got != lipgloss.Color("#1F6FEB") {
+		t.Errorf("normal text foreground = %v, want #1F6FEB", got)
+	}
+
+	if got := e.Style(TabActiveStyleID).GetForeground(); got != lipgloss.Color("#FF00FF") {
+		t.Errorf("active tab foreground = %v, want #FF00FF", got)
+	}
+
+	if got := e.Color(NeutralFontColorID); got != NewLightTheme().Color(NeutralFontColorID) {
+		t.Errorf("neutral color = %v, want the light one", got)
+	}
+
+	if !e.Style(testBadgeStyleID).GetReverse() || e.Size(testGutterSizeID) != 4 {
+		t.Error("overrides not applied")
+	}
+
+	// The base theme itself is left untouched.
+	if got := base.Style(NormalTextStyleID).GetForeground(); got != NewLightTheme().Color(NormalFontColorID) {
+		t.Errorf("base normal text foreground = %v, want the light one", got)
+	}
+}
+
+func TestLoadBase(t *testing.T) {
+	f, err := Load(strings.NewReader(`{
+		"base": "high_contrast",
+		"colors": {"test_accent": "#123456"},
+		"styles": {"test_badge": {"foreground": "test_accent"}},
+		"sizes": {"test_gutter": 3}
+	}`))
+
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got := f.Style(FocusedWidgetStyleID).GetBorderStyle(); got != lipgloss.ThickBorder() {
+		t.Errorf("focused widget border = %v, want the high contrast thick one", got)
+	}
+
+	if got := f.Style(testBadgeStyleID).GetForeground(); got != lipgloss.Color("#123456") {
+		t.Errorf("badge foreground = %v, want #123456", got)
+	}
+
+	if got := f.Size(testGutterSizeID); got != 3 {
+		t.Errorf("gutter size = %d, want 3", got)
+	}
+
+	if _, err := Load(strings.NewReader(`{"base": "sepia"}`)); err == nil {
+		t.Error("Load accepted an unknown base theme")
+	}
+}
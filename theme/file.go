@@ -0,0 +1,377 @@
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StyleSpec describes a style in a theme file. Colors are either the name of
+// a ColorID of the theme, "normal_font" for example, a hex color "#18B718" or
+// an ANSI color number "2".
+type StyleSpec struct {
+	Foreground    string `json:"foreground,omitempty"`
+	Background    string `json:"background,omitempty"`
+	Bold          bool   `json:"bold,omitempty"`
+	Italic        bool   `json:"italic,omitempty"`
+	Underline     bool   `json:"underline,omitempty"`
+	Faint         bool   `json:"faint,omitempty"`
+	Strikethrough bool   `json:"strikethrough,omitempty"`
+	Reverse       bool   `json:"reverse,omitempty"`
+
+	// Border is one of normal, rounded, thick, double, block or hidden.
+	Border string `json:"border,omitempty"`
+
+	// BorderSides selects the sides of the border: top, right, bottom, left.
+	// Every side by default.
+	BorderSides []bool `json:"border_sides,omitempty"`
+
+	BorderForeground string `json:"border_foreground,omitempty"`
+
+	// Padding and Margin take 1 to 4 values, like lipgloss.Style.Padding.
+	Padding []int `json:"padding,omitempty"`
+	Margin  []int `json:"margin,omitempty"`
+
+	// Align is one of left, center or right.
+	Align string `json:"align,omitempty"`
+}
+
+// FileTheme is a Theme loaded from a file. It inherits from a built-in base
+// theme, the DefaultDarkTheme unless the file names another one: the colors,
+// styles and sizes it does not define are the base ones, built with the colors
+// of the file.
+//
+//	{
+//		"base": "light",
+//		"colors": {
+//			"normal_font": "#1F6FEB",
+//			"highlight_font": "#F0883E"
+//		},
+//		"styles": {
+//			"title": {"foreground": "highlight_font", "bold": true},
+//			"dialog": {
+//				"border": "double",
+//				"border_foreground": "normal_font",
+//				"padding": [1, 2]
+//			}
+//		},
+//		"sizes": {
+//			"sidebar_width": 30
+//		}
+//	}
+type FileTheme struct {
+	base Theme
+
+	// theme resolves the color names of the styles, the FileTheme itself
+	// unless another theme inherits from it.
+	theme Theme
+
+	colors map[ColorID]lipgloss.Color
+	styles map[StyleID]StyleSpec
+	sizes  map[SizeID]int
+}
+
+// baseThemes holds the themes a theme file can inherit from, by name.
+var baseThemes = map[string]func() Theme{
+	"dark":          func() Theme { return NewDefaultDarkTheme() },
+	"light":         func() Theme { return NewLightTheme() },
+	"high_contrast": func() Theme { return NewHighContrastTheme() },
+	"adaptive":      func() Theme { return NewAdaptiveTheme() },
+}
+
+// Load reads a FileTheme from JSON. Every unknown name or invalid value is
+// reported in the returned error.
+func Load(r io.Reader) (*FileTheme, error) {
+	var data struct {
+		Base   string               `json:"base"`
+		Colors map[string]string    `json:"colors"`
+		Styles map[string]StyleSpec `json:"styles"`
+		Sizes  map[string]int       `json:"sizes"`
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("theme: reading theme: %w", err)
+	}
+
+	var errs []error
+
+	newBase := baseThemes["dark"]
+
+	if data.Base != "" {
+		b, ok := baseThemes[data.Base]
+
+		if ok {
+			newBase = b
+		} else {
+			errs = append(errs, fmt.Errorf("theme: unknown base theme %q", data.Base))
+		}
+	}
+
+	f := new(FileTheme)
+
+	f.base = inherit(newBase(), f)
+	f.theme = f
+	f.colors = make(map[ColorID]lipgloss.Color)
+	f.styles = make(map[StyleID]StyleSpec)
+	f.sizes = make(map[SizeID]int)
+
+	for name, value := range data.Colors {
+		id, ok := ColorIDByName(name)
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("theme: unknown color %q", name))
+			continue
+		}
+
+		if !isColor(value) {
+			errs = append(errs, fmt.Errorf("theme: color %s: invalid color %q", name, value))
+			continue
+		}
+
+		f.colors[id] = lipgloss.Color(value)
+	}
+
+	for name, spec := range data.Styles {
+		id, ok := StyleIDByName(name)
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("theme: unknown style %q", name))
+			continue
+		}
+
+		f.styles[id] = spec
+	}
+
+	for name, value := range data.Sizes {
+		id, ok := SizeIDByName(name)
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("theme: unknown size %q", name))
+			continue
+		}
+
+		f.sizes[id] = value
+	}
+
+	// The styles are checked once every color is known.
+	for id, spec := range f.styles {
+		if _, err := spec.Build(f); err != nil {
+			errs = append(errs, fmt.Errorf("theme: style %s: %w", id, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return f, nil
+}
+
+// LoadFile reads a FileTheme from the named JSON file, see Load.
+func LoadFile(name string) (*FileTheme, error) {
+	file, err := os.Open(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return Load(file)
+}
+
+func (f *FileTheme) Style(style StyleID) lipgloss.Style {
+	spec, ok := f.styles[style]
+
+	if !ok {
+		return f.base.Style(style)
+	}
+
+	s, _ := spec.Build(f.theme)
+
+	return s
+}
+
+func (f *FileTheme) Color(color ColorID) lipgloss.Color {
+	c, ok := f.colors[color]
+
+	if !ok {
+		return f.base.Color(color)
+	}
+
+	return c
+}
+
+func (f *FileTheme) Size(size SizeID) int {
+	s, ok := f.sizes[size]
+
+	if !ok {
+		return f.base.Size(size)
+	}
+
+	return s
+}
+
+// ANSIColor returns the 16-color of the base theme, or the color of the file
+// itself, see Degrade.
+func (f *FileTheme) ANSIColor(color ColorID) lipgloss.Color {
+	if c, ok := f.colors[color]; ok {
+		return c
+	}
+
+	if p, ok := f.base.(ANSIPalette); ok {
+		return p.ANSIColor(color)
+	}
+
+	return f.base.Color(color)
+}
+
+func (f *FileTheme) Inherit(child Theme) Theme {
+	c := *f
+	c.base = inherit(f.base, child)
+	c.theme = child
+
+	return &c
+}
+
+// Build returns the lipgloss.Style described by the StyleSpec, the color
+// names being resolved with the given Theme.
+func (s StyleSpec) Build(t Theme) (lipgloss.Style, error) {
+	var errs []error
+
+	style := lipgloss.NewStyle()
+
+	for _, attr := range []struct {
+		set   bool
+		apply func(lipgloss.Style, bool) lipgloss.Style
+	}{
+		{s.Bold, lipgloss.Style.Bold},
+		{s.Italic, lipgloss.Style.Italic},
+		{s.Underline, lipgloss.Style.Underline},
+		{s.Faint, lipgloss.Style.Faint},
+		{s.Strikethrough, lipgloss.Style.Strikethrough},
+		{s.Reverse, lipgloss.Style.Reverse},
+	} {
+		if attr.set {
+			style = attr.apply(style, true)
+		}
+	}
+
+	color := func(field, value string) (lipgloss.TerminalColor, bool) {
+		if value == "" {
+			return nil, false
+		}
+
+		c, err := resolveColor(t, value)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field, err))
+			return nil, false
+		}
+
+		return c, true
+	}
+
+	if c, ok := color("foreground", s.Foreground); ok {
+		style = style.Foreground(c)
+	}
+
+	if c, ok := color("background", s.Background); ok {
+		style = style.Background(c)
+	}
+
+	if s.Border != "" {
+		border, ok := borders[s.Border]
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown border %q", s.Border))
+		}
+
+		if len(s.BorderSides) > 4 {
+			errs = append(errs, fmt.Errorf("border_sides takes at most 4 values"))
+		}
+
+		style = style.Border(border, s.BorderSides...)
+	}
+
+	if c, ok := color("border_foreground", s.BorderForeground); ok {
+		style = style.BorderForeground(c)
+	}
+
+	if len(s.Padding) > 4 {
+		errs = append(errs, fmt.Errorf("padding takes at most 4 values"))
+	} else if len(s.Padding) > 0 {
+		style = style.Padding(s.Padding...)
+	}
+
+	if len(s.Margin) > 4 {
+		errs = append(errs, fmt.Errorf("margin takes at most 4 values"))
+	} else if len(s.Margin) > 0 {
+		style = style.Margin(s.Margin...)
+	}
+
+	if s.Align != "" {
+		align, ok := aligns[s.Align]
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown align %q", s.Align))
+		}
+
+		style = style.Align(align)
+	}
+
+	return style, errors.Join(errs...)
+}
+
+// Hidden functions
+
+var borders = map[string]lipgloss.Border{
+	"normal":  lipgloss.NormalBorder(),
+	"rounded": lipgloss.RoundedBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"block":   lipgloss.BlockBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+}
+
+var aligns = map[string]lipgloss.Position{
+	"left":   lipgloss.Left,
+	"center": lipgloss.Center,
+	"right":  lipgloss.Right,
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// isColor returns true for a hex color or an ANSI color number.
+func isColor(value string) bool {
+	if hexColor.MatchString(value) {
+		return true
+	}
+
+	n, err := strconv.Atoi(value)
+
+	return err == nil && n >= 0 && n <= 255
+}
+
+// resolveColor returns the color of the theme for a ColorID name, or the
+// literal color.
+func resolveColor(t Theme, value string) (lipgloss.TerminalColor, error) {
+	if id, ok := ColorIDByName(value); ok {
+		return t.Color(id), nil
+	}
+
+	if !isColor(value) {
+		return nil, fmt.Errorf("invalid color %q", value)
+	}
+
+	return lipgloss.Color(value), nil
+}
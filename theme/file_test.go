@@ -0,0 +1,61 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoad(t *testing.T) {
+	f, err := Load(strings.NewReader(`{
+		"colors": {"normal_font": "#1F6FEB"},
+		"styles": {
+			"title": {"foreground": "#F0883E", "bold": true, "italic": true},
+			"dialog": {"border": "double", "border_sides": [true, false], "padding": [1, 2], "align": "center"}
+		}
+	}`))
+
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	title := f.Style(TitleStyleID)
+
+	if title.GetForeground() != lipgloss.Color("#F0883E") || !title.GetBold() || !title.GetItalic() {
+		t.Errorf("title style not loaded: %v", title)
+	}
+
+	dialog := f.Style(DialogStyleID)
+
+	if dialog.GetBorderStyle() != lipgloss.DoubleBorder() || !dialog.GetBorderTop() ||
+		dialog.GetBorderRight() || dialog.GetPaddingLeft() != 2 || dialog.GetAlignHorizontal() != lipgloss.Center {
+		t.Errorf("dialog style not loaded: %v", dialog)
+	}
+
+	// The styles the file does not define use its colors.
+	if got := f.Style(NormalTextStyleID).GetForeground(); got != lipgloss.Color("#1F6FEB") {
+		t.Errorf("normal text foreground = %v, want the file normal_font", got)
+	}
+
+	if got := f.Color(HighlightFontColorID); got != NewDefaultDarkTheme().Color(HighlightFontColorID) {
+		t.Errorf("highlight color = %v, want the default one", got)
+	}
+}
+
+func TestLoadReportsEveryError(t *testing.T) {
+	_, err := Load(strings.NewReader(`{
+		"colors": {"normal_font": "green", "nope": "#000"},
+		"styles": {"title": {"foreground": "unknown_font", "border": "wavy"}}
+	}`))
+
+	if err == nil {
+		t.Fatal("invalid theme loaded")
+	}
+
+	for _, want := range []string{`"green"`, `"nope"`, `"unknown_font"`, `"wavy"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not report %s:\n%v", want, err)
+		}
+	}
+}
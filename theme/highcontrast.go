@@ -0,0 +1,112 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HighContrastTheme uses bright colors on the terminal background and does
+// not rely on colors alone: the focused widget has a thick border and the
+// cursors are reversed.
+type HighContrastTheme struct {
+	DefaultDarkTheme
+}
+
+func NewHighContrastTheme() *HighContrastTheme {
+	h := &HighContrastTheme{}
+	h.Theme = h
+
+	return h
+}
+
+func (h HighContrastTheme) Style(style StyleID) lipgloss.Style {
+	s := h.DefaultDarkTheme.Style(style)
+
+	switch style {
+	case FocusedWidgetStyleID, DialogStyleID, ErrorWidgetStyleID:
+		s = s.Border(lipgloss.ThickBorder())
+
+	case BlurredWidgetStyleID:
+		s = s.Border(lipgloss.NormalBorder())
+
+	case HighlightTextStyleID:
+		s = s.Bold(true)
+
+	case TableCursorStyleID:
+		s = s.Reverse(true)
+
+	case TableSelectedStyleID:
+		s = s.Underline(true)
+
+	case DimmedBackgroundStyleID:
+		// Faint text is hard to read, the dim color is enough.
+		s = s.Faint(false)
+
+	}
+
+	return s
+}
+
+func (h HighContrastTheme) Color(color ColorID) lipgloss.Color {
+	var colorHexCode string
+
+	switch color {
+	case TitleFontColorID, HighlightFontColorID, FocusedBorderColorID, FocusedFontColorID:
+		colorHexCode = "#FFFF00"
+
+	case NeutralDimFontColorID, BlurredBorderColorID, BlurredFontColorID, DimFontColorID:
+		colorHexCode = "#C0C0C0"
+
+	case StatusErrorFontColorID:
+		colorHexCode = "#FF5555"
+
+	case StatusSuccessFontColorID:
+		colorHexCode = "#55FF55"
+
+	case StatusWarningFontColorID:
+		colorHexCode = "#FFAA00"
+
+	case StatusInformationFontColorID:
+		colorHexCode = "#55FFFF"
+
+	case NeutralFontColorID, NormalFontColorID, StatusNeutralFontColorID:
+		colorHexCode = "#FFFFFF"
+
+	default:
+		return FallbackColor(h.Theme, color)
+
+	}
+
+	return lipgloss.Color(colorHexCode)
+}
+
+// ANSIColor returns the color used on 16-color terminals, see Degrade.
+func (h HighContrastTheme) ANSIColor(color ColorID) lipgloss.Color {
+	var ansi string
+
+	switch color {
+	case TitleFontColorID, HighlightFontColorID, FocusedBorderColorID, FocusedFontColorID,
+		StatusWarningFontColorID:
+		ansi = "11"
+
+	case NeutralDimFontColorID, BlurredBorderColorID, BlurredFontColorID, DimFontColorID:
+		ansi = "7"
+
+	case StatusErrorFontColorID:
+		ansi = "9"
+
+	case StatusSuccessFontColorID:
+		ansi = "10"
+
+	case StatusInformationFontColorID:
+		ansi = "14"
+
+	case NeutralFontColorID, NormalFontColorID, StatusNeutralFontColorID:
+		ansi = "15"
+
+	default:
+		return h.ANSIColor(fallbackColorID(color))
+
+	}
+
+	return lipgloss.Color(ansi)
+}
@@ -0,0 +1,93 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LightTheme is the DefaultDarkTheme styles with colors readable on light
+// terminal backgrounds.
+type LightTheme struct {
+	DefaultDarkTheme
+}
+
+func NewLightTheme() *LightTheme {
+	l := &LightTheme{}
+	l.Theme = l
+
+	return l
+}
+
+func (l LightTheme) Color(color ColorID) lipgloss.Color {
+	var colorHexCode string
+
+	switch color {
+	case NeutralFontColorID:
+		colorHexCode = "#1A1A1A"
+
+	case NeutralDimFontColorID:
+		colorHexCode = "#6E6E6E"
+
+	case BlurredBorderColorID, BlurredFontColorID, DimFontColorID:
+		colorHexCode = "#8FB08F"
+
+	case HighlightFontColorID:
+		colorHexCode = "#B04A00"
+
+	case StatusErrorFontColorID:
+		colorHexCode = "#B00020"
+
+	case StatusSuccessFontColorID:
+		colorHexCode = "#1B7F1B"
+
+	case StatusWarningFontColorID:
+		colorHexCode = "#A65A00"
+
+	case StatusInformationFontColorID:
+		colorHexCode = "#0062A3"
+
+	case StatusNeutralFontColorID:
+		colorHexCode = "#4D4D4D"
+
+	case TitleFontColorID, NormalFontColorID, FocusedBorderColorID, FocusedFontColorID:
+		colorHexCode = "#146B14"
+
+	default:
+		return FallbackColor(l.Theme, color)
+
+	}
+
+	return lipgloss.Color(colorHexCode)
+}
+
+// ANSIColor returns the color used on 16-color terminals, see Degrade.
+func (l LightTheme) ANSIColor(color ColorID) lipgloss.Color {
+	var ansi string
+
+	switch color {
+	case NeutralFontColorID:
+		ansi = "0"
+
+	case NeutralDimFontColorID, StatusNeutralFontColorID,
+		BlurredBorderColorID, BlurredFontColorID, DimFontColorID:
+		ansi = "8"
+
+	case HighlightFontColorID, StatusWarningFontColorID:
+		ansi = "3"
+
+	case StatusErrorFontColorID:
+		ansi = "1"
+
+	case StatusInformationFontColorID:
+		ansi = "4"
+
+	case TitleFontColorID, NormalFontColorID, FocusedBorderColorID, FocusedFontColorID,
+		StatusSuccessFontColorID:
+		ansi = "2"
+
+	default:
+		return l.ANSIColor(fallbackColorID(color))
+
+	}
+
+	return lipgloss.Color(ansi)
+}
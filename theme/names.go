@@ -0,0 +1,107 @@
+package theme
+
+// styleNames holds the name of every StyleID, as used in theme files.
+var styleNames = map[StyleID]string{
+	TitleStyleID:                 "title",
+	NeutralTextStyleID:           "neutral_text",
+	NeutralDimTextStyleID:        "neutral_dim_text",
+	NormalTextStyleID:            "normal_text",
+	HighlightTextStyleID:         "highlight_text",
+	DimTextStyleID:               "dim_text",
+	DimSecondaryTextStyleID:      "dim_secondary_text",
+	LabelTextStyleID:             "label_text",
+	FocusedWidgetStyleID:         "focused_widget",
+	BlurredWidgetStyleID:         "blurred_widget",
+	PaginatorActiveStyleID:       "paginator_active",
+	PaginatorInactiveStyleID:     "paginator_inactive",
+	StatusErrorTextStyleID:       "status_error_text",
+	StatusSuccessTextStyleID:     "status_success_text",
+	StatusWarningTextStyleID:     "status_warning_text",
+	StatusInformationTextStyleID: "status_information_text",
+	StatusNeutralTextStyleID:     "status_neutral_text",
+	DialogStyleID:                "dialog",
+	DimmedBackgroundStyleID:      "dimmed_background",
+	ScrollbarTrackStyleID:        "scrollbar_track",
+	ScrollbarThumbStyleID:        "scrollbar_thumb",
+	TabActiveStyleID:             "tab_active",
+	TabInactiveStyleID:           "tab_inactive",
+	TableHeaderStyleID:           "table_header",
+	TableCursorStyleID:           "table_cursor",
+	TableSelectedStyleID:         "table_selected",
+	HelpKeyStyleID:               "help_key",
+	HelpDescStyleID:              "help_desc",
+	ErrorWidgetStyleID:           "error_widget",
+	SelectionMarkerStyleID:       "selection_marker",
+	SectionHeaderStyleID:         "section_header",
+}
+
+// colorNames holds the name of every ColorID, as used in theme files.
+var colorNames = map[ColorID]string{
+	TitleFontColorID:             "title_font",
+	NeutralFontColorID:           "neutral_font",
+	NeutralDimFontColorID:        "neutral_dim_font",
+	NormalFontColorID:            "normal_font",
+	HighlightFontColorID:         "highlight_font",
+	DimFontColorID:               "dim_font",
+	FocusedBorderColorID:         "focused_border",
+	FocusedFontColorID:           "focused_font",
+	BlurredBorderColorID:         "blurred_border",
+	BlurredFontColorID:           "blurred_font",
+	StatusErrorFontColorID:       "status_error_font",
+	StatusSuccessFontColorID:     "status_success_font",
+	StatusWarningFontColorID:     "status_warning_font",
+	StatusInformationFontColorID: "status_information_font",
+	StatusNeutralFontColorID:     "status_neutral_font",
+}
+
+// sizeNames holds the name of every SizeID, as used in theme files. There are
+// no built-in sizes, see RegisterSizeID.
+var sizeNames = map[SizeID]string{}
+
+// String returns the name of the StyleID, as used in theme files.
+func (s StyleID) String() string {
+	return styleNames[s]
+}
+
+// String returns the name of the ColorID, as used in theme files.
+func (c ColorID) String() string {
+	return colorNames[c]
+}
+
+// String returns the name of the SizeID, as used in theme files.
+func (s SizeID) String() string {
+	return sizeNames[s]
+}
+
+// StyleIDByName returns the StyleID of the given name, false if none.
+func StyleIDByName(name string) (StyleID, bool) {
+	for id, n := range styleNames {
+		if n == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// ColorIDByName returns the ColorID of the given name, false if none.
+func ColorIDByName(name string) (ColorID, bool) {
+	for id, n := range colorNames {
+		if n == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// SizeIDByName returns the SizeID of the given name, false if none.
+func SizeIDByName(name string) (SizeID, bool) {
+	for id, n := range sizeNames {
+		if n == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,116 @@
+package theme
+
+import (
+	"log"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// styleFallbacks holds the fallback of every registered StyleID.
+var styleFallbacks = map[StyleID]StyleID{}
+
+// colorFallbacks holds the fallback of every registered ColorID.
+var colorFallbacks = map[ColorID]ColorID{}
+
+// sizeDefaults holds the default value of every registered SizeID.
+var sizeDefaults = map[SizeID]int{}
+
+// RegisterStyleID registers a new StyleID under the name, as used in theme
+// files. Themes not defining it return the style of fallback. Widget
+// packages register their IDs in a package variable or an init function, so
+// every ID is known when themes are loaded.
+//
+//	var BadgeStyleID = theme.RegisterStyleID("badge", theme.HighlightTextStyleID)
+func RegisterStyleID(name string, fallback StyleID) StyleID {
+	if _, ok := StyleIDByName(name); ok {
+		log.Fatalf("Orvyn : Style %s is already registered", name)
+	}
+
+	if _, ok := styleNames[fallback]; !ok {
+		log.Fatalf("Orvyn : Fallback of style %s is not registered", name)
+	}
+
+	id := StyleID(len(styleNames))
+
+	styleNames[id] = name
+	styleFallbacks[id] = fallback
+
+	return id
+}
+
+// RegisterColorID registers a new ColorID under the name, as used in theme
+// files. Themes not defining it return the color of fallback.
+func RegisterColorID(name string, fallback ColorID) ColorID {
+	if _, ok := ColorIDByName(name); ok {
+		log.Fatalf("Orvyn : Color %s is already registered", name)
+	}
+
+	if _, ok := colorNames[fallback]; !ok {
+		log.Fatalf("Orvyn : Fallback of color %s is not registered", name)
+	}
+
+	id := ColorID(len(colorNames))
+
+	colorNames[id] = name
+	colorFallbacks[id] = fallback
+
+	return id
+}
+
+// RegisterSizeID registers a new SizeID under the name, as used in theme
+// files. Themes not defining it return value.
+func RegisterSizeID(name string, value int) SizeID {
+	if _, ok := SizeIDByName(name); ok {
+		log.Fatalf("Orvyn : Size %s is already registered", name)
+	}
+
+	id := SizeID(len(sizeNames))
+
+	sizeNames[id] = name
+	sizeDefaults[id] = value
+
+	return id
+}
+
+// FallbackStyle returns the style of a StyleID the theme does not define:
+// the style of its registered fallback, the NormalTextStyleID one for an
+// unknown ID.
+func FallbackStyle(t Theme, style StyleID) lipgloss.Style {
+	if fallback, ok := styleFallbacks[style]; ok {
+		return t.Style(fallback)
+	}
+
+	if style == NormalTextStyleID {
+		return lipgloss.NewStyle()
+	}
+
+	return t.Style(NormalTextStyleID)
+}
+
+// FallbackColor returns the color of a ColorID the theme does not define:
+// the color of its registered fallback, the NormalFontColorID one for an
+// unknown ID.
+func FallbackColor(t Theme, color ColorID) lipgloss.Color {
+	if color == NormalFontColorID {
+		return lipgloss.Color("")
+	}
+
+	return t.Color(fallbackColorID(color))
+}
+
+// FallbackSize returns the default value of a SizeID, 0 for an unknown ID.
+func FallbackSize(size SizeID) int {
+	return sizeDefaults[size]
+}
+
+// Hidden functions
+
+// fallbackColorID returns the ColorID replacing a ColorID a theme does not
+// define.
+func fallbackColorID(color ColorID) ColorID {
+	if fallback, ok := colorFallbacks[color]; ok {
+		return fallback
+	}
+
+	return NormalFontColorID
+}
@@ -24,6 +24,20 @@ const (
 	StatusWarningTextStyleID
 	StatusInformationTextStyleID
 	StatusNeutralTextStyleID
+	DialogStyleID
+	DimmedBackgroundStyleID
+	ScrollbarTrackStyleID
+	ScrollbarThumbStyleID
+	TabActiveStyleID
+	TabInactiveStyleID
+	TableHeaderStyleID
+	TableCursorStyleID
+	TableSelectedStyleID
+	HelpKeyStyleID
+	HelpDescStyleID
+	ErrorWidgetStyleID
+	SelectionMarkerStyleID
+	SectionHeaderStyleID
 )
 
 type ColorID uint
@@ -0,0 +1,73 @@
+package orvyn_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/theme"
+	"github.com/halsten-dev/orvyn/widget/checkbox"
+)
+
+// borderTheme returns a theme with blue focused borders and red blurred ones.
+func borderTheme(t *testing.T) theme.Theme {
+	t.Helper()
+
+	th, err := theme.Load(strings.NewReader(`{"colors": {
+		"focused_border": "#0000FF",
+		"blurred_border": "#FF0000"
+	}}`))
+
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	return th
+}
+
+// setTheme changes the theme of the App and dispatches the ThemeChangedMsg.
+func setTheme(app *orvyn.App, th theme.Theme) {
+	app.Update(app.SetTheme(th)())
+}
+
+func TestSetThemeRestylesWidgets(t *testing.T) {
+	app, s := newFormApp(t)
+
+	setTheme(app, borderTheme(t))
+
+	if got := s.cbAgree.GetStyle().GetBorderTopForeground(); got != lipgloss.Color("#FF0000") {
+		t.Errorf("blurred checkbox border = %v, want the new theme one", got)
+	}
+
+	if got := s.tiName.GetStyle().GetBorderTopForeground(); got != lipgloss.Color("#0000FF") {
+		t.Errorf("focused input border = %v, want the new theme one", got)
+	}
+
+	// Focus changes use the new styles too.
+	press(app, keyEsc, keyTab)
+
+	if got := s.tiName.GetStyle().GetBorderTopForeground(); got != lipgloss.Color("#FF0000") {
+		t.Errorf("blurred input border = %v, want the new theme one", got)
+	}
+}
+
+func TestSetThemeRestylesHiddenWidgets(t *testing.T) {
+	app, s := newFormApp(t)
+
+	// Not in the FocusManager: only the layout reaches it.
+	hidden := checkbox.New("Hidden")
+	hidden.SetActive(false)
+
+	s.layout = layout.NewVBoxLayout(0, s.tiName, s.cbAgree,
+		layout.NewVBoxLayout(0, hidden))
+
+	setTheme(app, borderTheme(t))
+
+	hidden.SetActive(true)
+
+	if got := hidden.GetStyle().GetBorderTopForeground(); got != lipgloss.Color("#FF0000") {
+		t.Errorf("hidden checkbox border = %v, want the new theme one", got)
+	}
+}
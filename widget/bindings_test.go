@@ -0,0 +1,25 @@
+package widget_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/halsten-dev/orvyn"
+)
+
+// TestBindingsDocExample loads the overrides given as example on
+// orvyn.Bindings, with every widget action defined.
+func TestBindingsDocExample(t *testing.T) {
+	a := orvyn.NewApp()
+
+	err := a.LoadBindings(strings.NewReader(`{
+		"list.cursor_up": ["up", "ctrl+p"],
+		"list.cursor_down": ["down", "ctrl+n"],
+		"app.help": "f1",
+		"table.sort": []
+	}`))
+
+	if err != nil {
+		t.Fatalf("LoadBindings: %v", err)
+	}
+}
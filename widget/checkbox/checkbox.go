@@ -8,6 +8,17 @@ import (
 	"github.com/halsten-dev/orvyn/theme"
 )
 
+// Actions of the checkbox widget, see orvyn.Bindings.
+const (
+	CheckAction orvyn.ActionID = "checkbox.toggle"
+)
+
+func init() {
+	orvyn.DefineAction(CheckAction,
+		key.WithKeys(" "),
+		key.WithHelp("space", "toggle"))
+}
+
 // Widget is a checkbox widget holding 2 state : Checked and Unchecked.
 type Widget struct {
 	orvyn.BaseWidget
@@ -30,7 +41,7 @@ func New(label string) *Widget {
 	w.checked = false
 	w.label = label
 
-	w.CheckKeybind = key.NewBinding(key.WithKeys(" "))
+	w.CheckKeybind = orvyn.Binding(CheckAction)
 
 	w.OnBlur()
 
@@ -38,6 +49,12 @@ func New(label string) *Widget {
 }
 
 func (w *Widget) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+
+		return nil
+	}
+
 	if m, ok := orvyn.GetKeyMsg(msg); ok {
 		switch {
 		case key.Matches(m, w.CheckKeybind):
@@ -45,6 +62,10 @@ func (w *Widget) Update(msg tea.Msg) tea.Cmd {
 		}
 	}
 
+	if m, ok := msg.(orvyn.MouseMsg); ok && m.IsLeftClick() && m.IsOver(w) {
+		w.checked = !w.checked
+	}
+
 	return nil
 }
 
@@ -97,3 +118,18 @@ func (w *Widget) SetChecked(checked bool) {
 func (w *Widget) SetLabel(label string) {
 	w.label = label
 }
+
+// ShortHelp implements orvyn.KeyMap.
+func (w *Widget) ShortHelp() []key.Binding {
+	return []key.Binding{w.CheckKeybind}
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (w *Widget) FullHelp() [][]key.Binding {
+	return [][]key.Binding{w.ShortHelp()}
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (w *Widget) KeyMapTitle() string {
+	return "Checkbox"
+}
@@ -0,0 +1,416 @@
+package form
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	bubblesinput "github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+	"github.com/halsten-dev/orvyn/widget/checkbox"
+	"github.com/halsten-dev/orvyn/widget/label"
+	"github.com/halsten-dev/orvyn/widget/radio"
+	"github.com/halsten-dev/orvyn/widget/textarea"
+	"github.com/halsten-dev/orvyn/widget/textinput"
+	"github.com/halsten-dev/orvyn/widget/toggle"
+)
+
+// Field is a field of a Form: a label, the widget editing the value, its
+// validators and the name of the struct field it is bound to. Fields are
+// created with Text, Password, Number, TextArea, Checkbox, Toggle, Select,
+// Radio and HorizontalRadio.
+type Field struct {
+	name  string
+	label string
+
+	input      input
+	validators []Validator
+
+	labelWidget *label.Widget
+	errorWidget *errorLine
+
+	// index is the index of the bound struct field, nil when unbound.
+	index []int
+	err   error
+}
+
+// Option is a choice of a select field.
+type Option struct {
+	Label string
+	Value any
+}
+
+// NewOption creates and returns a new Option.
+func NewOption(label string, value any) Option {
+	return Option{Label: label, Value: value}
+}
+
+// Text creates a single line text field, bound to the string struct field
+// called name. An empty name leaves the field unbound.
+func Text(name, label string) *Field {
+	return newField(name, label, &textInput{textinput.New()})
+}
+
+// Password creates a text field hiding its value.
+func Password(name, label string) *Field {
+	w := textinput.New()
+	w.EchoMode = bubblesinput.EchoPassword
+
+	return newField(name, label, &textInput{w})
+}
+
+// Number creates a text field holding a number, bound to an integer or a
+// float struct field. A value that is not a number of the bound kind is
+// reported under the field.
+func Number(name, label string) *Field {
+	return newField(name, label, &numberInput{Widget: textinput.New()})
+}
+
+// TextArea creates a multiline text field, bound to a string struct field.
+// Enter inserts a new line instead of submitting the form.
+func TextArea(name, label string) *Field {
+	w := textarea.New()
+	w.SetMinSize(orvyn.NewSize(26, 5))
+	w.SetPreferredSize(orvyn.NewSize(46, 5))
+
+	return newField(name, label, &areaInput{w})
+}
+
+// Checkbox creates a checkbox field, bound to a bool struct field. The label
+// is the checkbox one.
+func Checkbox(name, label string) *Field {
+	f := newField(name, label, &checkInput{checkbox.New(label)})
+	f.labelWidget = nil
+
+	return f
+}
+
+// Toggle creates an on/off switch field, bound to a bool struct field. The
+// label is the switch one.
+func Toggle(name, label string) *Field {
+	f := newField(name, label, &toggleInput{toggle.New(label)})
+	f.labelWidget = nil
+
+	return f
+}
+
+// Select creates a field choosing one of the options, bound to a struct field
+// the option values can be converted to.
+func Select(name, label string, options ...Option) *Field {
+	return newField(name, label, newSelectInput(options))
+}
+
+// Radio creates a field choosing one of the options in a vertical radio
+// group, bound like a Select field.
+func Radio(name, label string, options ...Option) *Field {
+	return newField(name, label, newRadioInput(radio.New(), options))
+}
+
+// HorizontalRadio creates a Radio field listing the options on one line.
+func HorizontalRadio(name, label string, options ...Option) *Field {
+	return newField(name, label, newRadioInput(radio.NewHorizontal(), options))
+}
+
+// Validate adds validators to the field, run in order on submit. The first
+// error is shown under the field.
+func (f *Field) Validate(validators ...Validator) *Field {
+	f.validators = append(f.validators, validators...)
+
+	return f
+}
+
+// GetName returns the name of the struct field the field is bound to.
+func (f *Field) GetName() string {
+	return f.name
+}
+
+// GetWidget returns the widget editing the value, a *textinput.Widget for
+// example, to customize it.
+func (f *Field) GetWidget() orvyn.Widget {
+	return f.input.widget()
+}
+
+// GetValue returns the current value of the field, see Validator.
+func (f *Field) GetValue() (any, error) {
+	return f.input.value()
+}
+
+// GetError returns the error shown under the field, nil if none.
+func (f *Field) GetError() error {
+	return f.err
+}
+
+// Hidden functions
+
+// input is the widget editing the value of a Field.
+type input interface {
+	orvyn.Widget
+	orvyn.Focusable
+
+	// widget returns the wrapped widget.
+	widget() orvyn.Widget
+
+	// value returns the value of the input, an error when it cannot be
+	// parsed.
+	value() (any, error)
+
+	// setValue changes the value of the input, an invalid reflect.Value
+	// clears it.
+	setValue(reflect.Value)
+
+	// bind checks the input can be bound to a struct field of the type.
+	bind(reflect.Type) bool
+}
+
+func newField(name, text string, in input) *Field {
+	f := new(Field)
+
+	f.name = name
+	f.label = text
+	f.input = in
+	f.labelWidget = label.New(text)
+	f.errorWidget = newErrorLine()
+
+	return f
+}
+
+// validate runs the validators and shows the first error under the field.
+func (f *Field) validate() error {
+	value, err := f.input.value()
+
+	for _, validator := range f.validators {
+		if err != nil {
+			break
+		}
+
+		err = validator(value)
+	}
+
+	f.setError(err)
+
+	return err
+}
+
+func (f *Field) setError(err error) {
+	f.err = err
+	f.errorWidget.setError(err)
+}
+
+// elements returns the renderables of the field, from top to bottom.
+func (f *Field) elements() []orvyn.Renderable {
+	var elements []orvyn.Renderable
+
+	if f.labelWidget != nil {
+		elements = append(elements, f.labelWidget)
+	}
+
+	return append(elements, f.input, f.errorWidget)
+}
+
+// textInput edits a string on one line.
+type textInput struct {
+	*textinput.Widget
+}
+
+func (t *textInput) widget() orvyn.Widget {
+	return t.Widget
+}
+
+func (t *textInput) value() (any, error) {
+	return t.Value(), nil
+}
+
+func (t *textInput) setValue(v reflect.Value) {
+	if !v.IsValid() {
+		t.SetValue("")
+		return
+	}
+
+	t.SetValue(v.String())
+}
+
+func (t *textInput) bind(typ reflect.Type) bool {
+	return typ.Kind() == reflect.String
+}
+
+// numberInput edits a number on one line.
+type numberInput struct {
+	*textinput.Widget
+
+	// typ is the type of the bound struct field, the value is parsed as a
+	// float64 when unbound.
+	typ reflect.Type
+}
+
+func (n *numberInput) widget() orvyn.Widget {
+	return n.Widget
+}
+
+func (n *numberInput) value() (any, error) {
+	s := strings.TrimSpace(n.Value())
+
+	if s == "" {
+		return nil, nil
+	}
+
+	if n.typ == nil {
+		n.typ = reflect.TypeFor[float64]()
+	}
+
+	zero := reflect.Zero(n.typ)
+
+	switch {
+	case zero.CanInt():
+		v, err := strconv.ParseInt(s, 10, 64)
+
+		if err != nil {
+			return nil, errors.New("must be a whole number")
+		}
+
+		if zero.OverflowInt(v) {
+			return nil, errors.New("out of range")
+		}
+
+		return v, nil
+
+	case zero.CanUint():
+		v, err := strconv.ParseUint(s, 10, 64)
+
+		if err != nil {
+			return nil, errors.New("must be a positive whole number")
+		}
+
+		if zero.OverflowUint(v) {
+			return nil, errors.New("out of range")
+		}
+
+		return v, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return nil, errors.New("must be a number")
+	}
+
+	if zero.OverflowFloat(v) {
+		return nil, errors.New("out of range")
+	}
+
+	return v, nil
+}
+
+func (n *numberInput) setValue(v reflect.Value) {
+	switch {
+	case !v.IsValid():
+		n.SetValue("")
+
+	case v.CanInt():
+		n.SetValue(strconv.FormatInt(v.Int(), 10))
+
+	case v.CanUint():
+		n.SetValue(strconv.FormatUint(v.Uint(), 10))
+
+	case v.CanFloat():
+		n.SetValue(strconv.FormatFloat(v.Float(), 'f', -1, 64))
+	}
+}
+
+func (n *numberInput) bind(typ reflect.Type) bool {
+	n.typ = typ
+
+	zero := reflect.Zero(typ)
+
+	return zero.CanInt() || zero.CanUint() || zero.CanFloat()
+}
+
+// areaInput edits a string on several lines.
+type areaInput struct {
+	*textarea.Widget
+}
+
+func (a *areaInput) widget() orvyn.Widget {
+	return a.Widget
+}
+
+func (a *areaInput) value() (any, error) {
+	return a.Value(), nil
+}
+
+func (a *areaInput) setValue(v reflect.Value) {
+	if !v.IsValid() {
+		a.SetValue("")
+		return
+	}
+
+	a.SetValue(v.String())
+}
+
+func (a *areaInput) bind(typ reflect.Type) bool {
+	return typ.Kind() == reflect.String
+}
+
+// checkInput edits a bool.
+type checkInput struct {
+	*checkbox.Widget
+}
+
+func (c *checkInput) widget() orvyn.Widget {
+	return c.Widget
+}
+
+func (c *checkInput) value() (any, error) {
+	return c.IsChecked(), nil
+}
+
+func (c *checkInput) setValue(v reflect.Value) {
+	c.SetChecked(v.IsValid() && v.Bool())
+}
+
+func (c *checkInput) bind(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Bool
+}
+
+// errorLine shows the error of a field under it. Inactive without error, so
+// the layouts skip it.
+type errorLine struct {
+	orvyn.BaseWidget
+
+	message string
+}
+
+func newErrorLine() *errorLine {
+	e := new(errorLine)
+
+	e.BaseWidget = orvyn.NewBaseWidget()
+	e.updateStyle()
+	e.SetActive(false)
+
+	return e
+}
+
+// Render reads the style from the theme: the line is hidden most of the time
+// and must not keep the theme it was hidden with.
+func (e *errorLine) Render() string {
+	e.updateStyle()
+
+	return e.GetStyle().Width(e.GetContentSize().Width).Render(e.message)
+}
+
+func (e *errorLine) setError(err error) {
+	e.message = ""
+
+	if err != nil {
+		e.message = err.Error()
+	}
+
+	e.SetActive(err != nil)
+}
+
+func (e *errorLine) updateStyle() {
+	e.SetStyle(orvyn.GetTheme().Style(theme.StatusErrorTextStyleID).
+		AlignHorizontal(lipgloss.Left))
+}
@@ -0,0 +1,394 @@
+// Package form builds input forms from declared fields: the labels, the
+// widgets, the focus and the error messages are handled by the Form, which
+// sends a SubmitMsg holding the populated struct once every field is valid.
+//
+//	type User struct {
+//		Name  string
+//		Age   int
+//		Admin bool
+//	}
+//
+//	f := form.New(&user,
+//		form.Text("Name", "Name").Validate(form.Required()),
+//		form.Number("Age", "Age").Validate(form.Range(0, 150)),
+//		form.Checkbox("Admin", "Administrator"),
+//	)
+package form
+
+import (
+	"log"
+	"reflect"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+)
+
+// Actions of the form widget, see orvyn.Bindings.
+const (
+	SubmitAction         orvyn.ActionID = "form.submit"
+	ResetAction          orvyn.ActionID = "form.reset"
+	PreviousOptionAction orvyn.ActionID = "form.previous_option"
+	NextOptionAction     orvyn.ActionID = "form.next_option"
+)
+
+func init() {
+	orvyn.DefineAction(SubmitAction,
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "submit"))
+	orvyn.DefineAction(ResetAction,
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "reset"))
+	orvyn.DefineAction(PreviousOptionAction,
+		key.WithKeys("left"),
+		key.WithHelp("←", "previous option"))
+	orvyn.DefineAction(NextOptionAction,
+		key.WithKeys("right"),
+		key.WithHelp("→", "next option"))
+}
+
+// SubmitMsg is sent when the form is submitted with valid fields. Value holds
+// the populated struct.
+type SubmitMsg[T any] struct {
+	Form  *Form[T]
+	Value T
+}
+
+// Form lays out its fields in a column, each under its label and above its
+// error message, and manages their focus. The fields are bound to the fields
+// of a struct of type T: by their Go name or their `form:"name"` tag.
+type Form[T any] struct {
+	orvyn.BaseWidget
+
+	// SubmitKeybind validates the fields and submits the form. Enter by
+	// default, except in a textarea field and in a field entering or in input
+	// mode, like a radio field.
+	SubmitKeybind key.Binding
+
+	// ResetKeybind sets the fields back to the values of the bound struct.
+	// ctrl+r by default.
+	ResetKeybind key.Binding
+
+	target *T
+
+	fields       []*Field
+	focusManager *orvyn.FocusManager
+	layout       *layout.VBoxLayout
+
+	// submitted is true once a submit was attempted, the errors then follow
+	// the corrections of the user.
+	submitted bool
+}
+
+// New creates and returns a new *Form editing target. The fields take the
+// values of target and target is populated on submit. target can be nil, the
+// fields are then unbound: New[struct{}](nil, fields...).
+func New[T any](target *T, fields ...*Field) *Form[T] {
+	f := new(Form[T])
+
+	f.BaseWidget = orvyn.NewBaseWidget()
+	f.BaseWidget.SetStyle(lipgloss.NewStyle())
+
+	f.SubmitKeybind = orvyn.Binding(SubmitAction)
+	f.ResetKeybind = orvyn.Binding(ResetAction)
+
+	f.target = target
+	f.fields = fields
+	f.focusManager = orvyn.NewFocusManager()
+
+	var elements []orvyn.Renderable
+
+	for _, field := range fields {
+		f.bind(field)
+		f.focusManager.Add(field.input)
+
+		elements = append(elements, field.elements()...)
+	}
+
+	f.layout = layout.NewMaxWidthVBoxLayout(0, elements...)
+
+	f.Reset()
+
+	return f
+}
+
+// Init gives the focus to the first field.
+func (f *Form[T]) Init() tea.Cmd {
+	f.focusManager.FocusFirst()
+
+	return nil
+}
+
+func (f *Form[T]) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		return orvyn.Broadcast(f.layout, msg)
+	}
+
+	if m, ok := orvyn.GetKeyMsg(msg); ok {
+		switch {
+		case key.Matches(m, f.SubmitKeybind) && !f.fieldUsesKey(m):
+			return f.Submit()
+
+		case key.Matches(m, f.ResetKeybind):
+			f.Reset()
+			return nil
+		}
+	}
+
+	cmd := f.focusManager.Update(msg)
+
+	if f.submitted {
+		if field := f.focusedField(); field != nil {
+			field.validate()
+		}
+	}
+
+	return cmd
+}
+
+func (f *Form[T]) Render() string {
+	f.layout.Resize(f.GetContentSize())
+
+	return f.GetStyle().Render(f.layout.Render())
+}
+
+func (f *Form[T]) GetMinSize() orvyn.Size {
+	return f.frameSize(f.layout.GetMinSize())
+}
+
+func (f *Form[T]) GetPreferredSize() orvyn.Size {
+	return f.frameSize(f.layout.GetPreferredSize())
+}
+
+// GetElements returns the layout of the fields, so the form can be walked
+// like a layout, see orvyn.FindPosition.
+func (f *Form[T]) GetElements() []orvyn.Renderable {
+	return []orvyn.Renderable{f.layout}
+}
+
+// GetElementPosition returns the position of the layout of the fields.
+func (f *Form[T]) GetElementPosition(e orvyn.Renderable) (orvyn.Position, bool) {
+	if e != f.layout {
+		return orvyn.Position{}, false
+	}
+
+	style := f.GetStyle()
+
+	return orvyn.NewPosition(
+		style.GetBorderLeftSize()+style.GetPaddingLeft()+style.GetMarginLeft(),
+		style.GetBorderTopSize()+style.GetPaddingTop()+style.GetMarginTop()), true
+}
+
+// Validate runs the validators of every field and shows their errors. The
+// focus moves to the first invalid field. Returns true if every field is
+// valid.
+func (f *Form[T]) Validate() bool {
+	first := -1
+
+	for i, field := range f.fields {
+		if field.validate() != nil && first < 0 {
+			first = i
+		}
+	}
+
+	if first >= 0 {
+		f.focusManager.Focus(first)
+	}
+
+	return first < 0
+}
+
+// Submit validates the fields and, when they are valid, populates the bound
+// struct and returns a tea.Cmd sending a SubmitMsg.
+func (f *Form[T]) Submit() tea.Cmd {
+	f.submitted = true
+
+	if !f.Validate() {
+		return nil
+	}
+
+	var value T
+
+	if f.target != nil {
+		target := reflect.ValueOf(f.target).Elem()
+
+		for _, field := range f.fields {
+			if field.index == nil {
+				continue
+			}
+
+			v, _ := field.input.value()
+			dst := target.FieldByIndex(field.index)
+
+			if v == nil {
+				dst.SetZero()
+				continue
+			}
+
+			dst.Set(reflect.ValueOf(v).Convert(dst.Type()))
+		}
+
+		value = *f.target
+	}
+
+	return func() tea.Msg {
+		return SubmitMsg[T]{Form: f, Value: value}
+	}
+}
+
+// Reset sets the fields back to the values of the bound struct, the unbound
+// ones are cleared, and hides the errors.
+func (f *Form[T]) Reset() {
+	var target reflect.Value
+
+	if f.target != nil {
+		target = reflect.ValueOf(f.target).Elem()
+	}
+
+	for _, field := range f.fields {
+		var v reflect.Value
+
+		if field.index != nil {
+			v = target.FieldByIndex(field.index)
+		}
+
+		field.input.setValue(v)
+		field.setError(nil)
+	}
+
+	f.submitted = false
+}
+
+// GetField returns the field bound to the named struct field, nil if none.
+func (f *Form[T]) GetField(name string) *Field {
+	for _, field := range f.fields {
+		if field.name == name {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// GetFocusManager returns the FocusManager of the fields.
+func (f *Form[T]) GetFocusManager() *orvyn.FocusManager {
+	return f.focusManager
+}
+
+// ShortHelp implements orvyn.KeyMap. Returns the bindings of the focused
+// field, then the keys submitting and resetting the form.
+func (f *Form[T]) ShortHelp() []key.Binding {
+	return append(f.focusManager.ShortHelp(), orvyn.HelpBindings([]key.Binding{
+		f.SubmitKeybind, f.ResetKeybind,
+	})...)
+}
+
+// FullHelp implements orvyn.KeyMap. Returns a column per KeyGroups section.
+func (f *Form[T]) FullHelp() [][]key.Binding {
+	var columns [][]key.Binding
+
+	for _, g := range f.KeyGroups() {
+		columns = append(columns, g.Bindings)
+	}
+
+	return columns
+}
+
+// KeyGroups implements orvyn.KeyGroupsProvider. Returns the keys submitting
+// and resetting the form, then the sections of the fields.
+func (f *Form[T]) KeyGroups() []orvyn.KeyGroup {
+	groups := []orvyn.KeyGroup{{
+		Title:    "Form",
+		Bindings: orvyn.HelpBindings([]key.Binding{f.SubmitKeybind, f.ResetKeybind}),
+	}}
+
+	return append(groups, f.focusManager.KeyGroups()...)
+}
+
+// Hidden functions
+
+// bind finds the struct field of the Field. A missing or incompatible struct
+// field is a programming error.
+func (f *Form[T]) bind(field *Field) {
+	if field.name == "" || f.target == nil {
+		return
+	}
+
+	typ := reflect.TypeOf(f.target).Elem()
+
+	if typ.Kind() != reflect.Struct {
+		log.Fatalf("Orvyn : Form target %s is not a struct", typ)
+	}
+
+	sf, ok := findStructField(typ, field.name)
+
+	if !ok {
+		log.Fatalf("Orvyn : Form field %s not found in %s", field.name, typ)
+	}
+
+	if !field.input.bind(sf.Type) {
+		log.Fatalf("Orvyn : Form field %s cannot be bound to %s %s", field.name, typ, sf.Type)
+	}
+
+	field.index = sf.Index
+}
+
+// focusedField returns the field having the focus, nil if none.
+func (f *Form[T]) focusedField() *Field {
+	focused := f.focusManager.GetFocused()
+
+	for _, field := range f.fields {
+		if field.input == focused {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// fieldUsesKey returns true when the focused field uses the key itself: a
+// textarea, a field in input mode or a field entering it with the key.
+func (f *Form[T]) fieldUsesKey(m tea.KeyMsg) bool {
+	focused := f.focusManager.GetFocused()
+
+	if focused == nil {
+		return false
+	}
+
+	if _, ok := focused.(*areaInput); ok || focused.IsInputting() {
+		return true
+	}
+
+	enter := focused.GetEnterInputKeybind()
+
+	return enter != nil && key.Matches(m, *enter)
+}
+
+func (f *Form[T]) frameSize(size orvyn.Size) orvyn.Size {
+	style := f.GetStyle()
+
+	size.Width += style.GetHorizontalFrameSize()
+	size.Height += style.GetVerticalFrameSize()
+
+	return size
+}
+
+// findStructField returns the exported field of the struct type tagged
+// `form:"name"`, or else called name.
+func findStructField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for _, sf := range reflect.VisibleFields(typ) {
+		if sf.IsExported() && sf.Tag.Get("form") == name {
+			return sf, true
+		}
+	}
+
+	sf, ok := typ.FieldByName(name)
+
+	if !ok || !sf.IsExported() {
+		return reflect.StructField{}, false
+	}
+
+	return sf, true
+}
@@ -0,0 +1,245 @@
+package form
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+	"github.com/halsten-dev/orvyn/widget/textinput"
+)
+
+type user struct {
+	Name  string
+	Age   int
+	Bio   string `form:"biography"`
+	Role  string
+	Admin bool
+}
+
+func newTestForm(t *testing.T, u *user) *Form[user] {
+	t.Helper()
+
+	orvyn.Init()
+
+	f := New(u,
+		Text("Name", "Name").Validate(Required(), MinLength(2)),
+		Number("Age", "Age").Validate(Range(0, 150)),
+		TextArea("biography", "Biography"),
+		Select("Role", "Role", NewOption("Admin", "admin"), NewOption("User", "user")),
+		Checkbox("Admin", "Administrator"),
+	)
+	f.Init()
+
+	return f
+}
+
+func typeText(f *Form[user], text string) {
+	for _, r := range text {
+		f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestFormBindsInitialValues(t *testing.T) {
+	f := newTestForm(t, &user{Name: "Ada", Age: 36, Role: "user", Admin: true})
+
+	tests := []struct {
+		name string
+		want any
+	}{
+		{"Name", "Ada"},
+		{"Age", int64(36)},
+		{"Role", "user"},
+		{"Admin", true},
+	}
+
+	for _, test := range tests {
+		if got, _ := f.GetField(test.name).GetValue(); got != test.want {
+			t.Errorf("%s = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFormSubmitInvalid(t *testing.T) {
+	f := newTestForm(t, &user{})
+
+	// Move to the age field and type something that is not a number.
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(f, "abc")
+
+	if cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatal("invalid form submitted")
+	}
+
+	if f.GetField("Name").GetError() == nil || f.GetField("Age").GetError() == nil {
+		t.Fatal("errors not shown under the invalid fields")
+	}
+
+	if f.GetFocusManager().TabIndex() != 0 {
+		t.Errorf("focus = %d, want the first invalid field", f.GetFocusManager().TabIndex())
+	}
+
+	// The error follows the corrections once a submit was attempted.
+	typeText(f, "Ada")
+
+	if err := f.GetField("Name").GetError(); err != nil {
+		t.Errorf("name error = %v after correction", err)
+	}
+}
+
+func TestFormSubmit(t *testing.T) {
+	u := &user{Role: "admin"}
+	f := newTestForm(t, u)
+
+	typeText(f, "Grace")
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(f, "45")
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(f, "Admiral")
+
+	// Enter is a new line in the textarea.
+	if cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		if _, ok := cmd().(SubmitMsg[user]); ok {
+			t.Fatal("enter submitted the form from the textarea")
+		}
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	f.Update(tea.KeyMsg{Type: tea.KeyRight})
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	f.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd == nil {
+		t.Fatalf("valid form not submitted: name %v, age %v",
+			f.GetField("Name").GetError(), f.GetField("Age").GetError())
+	}
+
+	msg, ok := cmd().(SubmitMsg[user])
+
+	if !ok {
+		t.Fatalf("submit sent %T, want SubmitMsg", cmd())
+	}
+
+	want := user{Name: "Grace", Age: 45, Bio: "Admiral\n", Role: "user", Admin: true}
+
+	if msg.Value != want {
+		t.Errorf("submitted %+v, want %+v", msg.Value, want)
+	}
+
+	if *u != want {
+		t.Errorf("bound struct %+v, want %+v", *u, want)
+	}
+}
+
+func TestFormReset(t *testing.T) {
+	f := newTestForm(t, &user{Name: "Ada"})
+
+	typeText(f, "x")
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(f, "abc")
+
+	if f.Submit() != nil {
+		t.Fatal("invalid form submitted")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+
+	if got := f.GetField("Name").GetWidget().(*textinput.Widget).Value(); got != "Ada" {
+		t.Errorf("name after reset = %q, want Ada", got)
+	}
+
+	if got, _ := f.GetField("Age").GetValue(); got != int64(0) {
+		t.Errorf("age after reset = %v, want 0", got)
+	}
+
+	if err := f.GetField("Age").GetError(); err != nil {
+		t.Errorf("age error = %v after reset", err)
+	}
+}
+
+type settings struct {
+	Size   int
+	Notify bool
+}
+
+func TestFormRadioAndToggle(t *testing.T) {
+	orvyn.Init()
+
+	s := &settings{Size: 2}
+
+	f := New(s,
+		Radio("Size", "Size", NewOption("Small", 1), NewOption("Medium", 2), NewOption("Large", 3)),
+		Toggle("Notify", "Notifications"),
+	)
+	f.Init()
+
+	if got, _ := f.GetField("Size").GetValue(); got != 2 {
+		t.Fatalf("size = %v, want the bound 2", got)
+	}
+
+	// Enter edits the radio group instead of submitting, and leaves it.
+	if cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatal("enter on the radio field submitted the form")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	if cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatal("enter leaving the radio input mode submitted the form")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyTab})
+	f.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd == nil {
+		t.Fatal("form not submitted from the toggle field")
+	}
+
+	want := settings{Size: 3, Notify: true}
+
+	if msg := cmd().(SubmitMsg[settings]); msg.Value != want {
+		t.Errorf("submitted %+v, want %+v", msg.Value, want)
+	}
+}
+
+func TestFormErrorFollowsTheme(t *testing.T) {
+	f := newTestForm(t, &user{})
+
+	// The error lines are hidden while the theme changes.
+	orvyn.SetTheme(theme.NewLightTheme())
+
+	f.Validate()
+	f.Resize(orvyn.NewSize(60, 40))
+	f.Render()
+
+	want := theme.NewLightTheme().Style(theme.StatusErrorTextStyleID).GetForeground()
+
+	if got := f.GetField("Name").errorWidget.GetStyle().GetForeground(); got != want {
+		t.Errorf("error line color = %v, want the light theme one %v", got, want)
+	}
+}
+
+func TestBindOptionsRejectsUncomparableTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		typ     reflect.Type
+		want    bool
+	}{
+		{"string", []Option{NewOption("A", "a")}, reflect.TypeFor[string](), true},
+		{"slice field", []Option{NewOption("A", []string{"a"})}, reflect.TypeFor[[]string](), false},
+		{"slice option", []Option{NewOption("A", []string{"a"})}, reflect.TypeFor[any](), false},
+		{"any field", []Option{NewOption("A", "a")}, reflect.TypeFor[any](), true},
+	}
+
+	for _, test := range tests {
+		if got := bindOptions(test.options, test.typ); got != test.want {
+			t.Errorf("%s: bindOptions = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
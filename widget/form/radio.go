@@ -0,0 +1,69 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/widget/radio"
+	"github.com/halsten-dev/orvyn/widget/toggle"
+)
+
+// radioInput chooses one of its options in a radio group.
+type radioInput struct {
+	*radio.Widget
+
+	options []Option
+}
+
+func newRadioInput(w *radio.Widget, options []Option) *radioInput {
+	labels := make([]string, len(options))
+
+	for i, o := range options {
+		labels[i] = o.Label
+	}
+
+	w.SetOptions(labels...)
+
+	return &radioInput{Widget: w, options: options}
+}
+
+func (r *radioInput) widget() orvyn.Widget {
+	return r.Widget
+}
+
+func (r *radioInput) value() (any, error) {
+	if len(r.options) == 0 {
+		return nil, nil
+	}
+
+	return r.options[r.GetSelected()].Value, nil
+}
+
+func (r *radioInput) setValue(v reflect.Value) {
+	r.SetSelected(max(optionIndex(r.options, v), 0))
+}
+
+func (r *radioInput) bind(typ reflect.Type) bool {
+	return bindOptions(r.options, typ)
+}
+
+// toggleInput edits a bool with a switch.
+type toggleInput struct {
+	*toggle.Widget
+}
+
+func (t *toggleInput) widget() orvyn.Widget {
+	return t.Widget
+}
+
+func (t *toggleInput) value() (any, error) {
+	return t.IsOn(), nil
+}
+
+func (t *toggleInput) setValue(v reflect.Value) {
+	t.SetOn(v.IsValid() && v.Bool())
+}
+
+func (t *toggleInput) bind(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Bool
+}
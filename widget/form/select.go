@@ -0,0 +1,184 @@
+package form
+
+import (
+	"reflect"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// selectInput chooses one of its options, cycling through them.
+type selectInput struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	// PreviousKeybind selects the previous option. Left by default.
+	PreviousKeybind key.Binding
+
+	// NextKeybind selects the next option. Right by default.
+	NextKeybind key.Binding
+
+	options  []Option
+	selected int
+}
+
+func newSelectInput(options []Option) *selectInput {
+	s := new(selectInput)
+
+	s.BaseWidget = orvyn.NewBaseWidget()
+	s.BaseFocusable = orvyn.NewBaseFocusable(s)
+
+	s.PreviousKeybind = orvyn.Binding(PreviousOptionAction)
+	s.NextKeybind = orvyn.Binding(NextOptionAction)
+
+	s.options = options
+
+	s.OnBlur()
+
+	return s
+}
+
+func (s *selectInput) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		s.BaseFocusable.UpdateTheme()
+
+		return nil
+	}
+
+	if len(s.options) == 0 {
+		return nil
+	}
+
+	if m, ok := orvyn.GetKeyMsg(msg); ok {
+		switch {
+		case key.Matches(m, s.PreviousKeybind):
+			s.selected = (s.selected - 1 + len(s.options)) % len(s.options)
+
+		case key.Matches(m, s.NextKeybind):
+			s.selected = (s.selected + 1) % len(s.options)
+		}
+	}
+
+	if m, ok := msg.(orvyn.MouseMsg); ok && m.IsLeftClick() && m.IsOver(s) {
+		s.selected = (s.selected + 1) % len(s.options)
+	}
+
+	return nil
+}
+
+func (s *selectInput) Resize(size orvyn.Size) {
+	size.Height = 1 + s.GetStyle().GetVerticalFrameSize()
+
+	s.BaseWidget.Resize(size)
+}
+
+func (s *selectInput) Render() string {
+	t := orvyn.GetTheme()
+
+	value := ""
+
+	if len(s.options) > 0 {
+		value = t.Style(theme.DimTextStyleID).Render("‹ ") +
+			t.Style(theme.NormalTextStyleID).Render(s.options[s.selected].Label) +
+			t.Style(theme.DimTextStyleID).Render(" ›")
+	}
+
+	return s.GetStyle().
+		Width(s.GetContentSize().Width).
+		Render(value)
+}
+
+func (s *selectInput) GetMinSize() orvyn.Size {
+	return orvyn.NewSize(26, 3)
+}
+
+func (s *selectInput) GetPreferredSize() orvyn.Size {
+	return orvyn.NewSize(46, 3)
+}
+
+// ShortHelp implements orvyn.KeyMap.
+func (s *selectInput) ShortHelp() []key.Binding {
+	return []key.Binding{s.PreviousKeybind, s.NextKeybind}
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (s *selectInput) FullHelp() [][]key.Binding {
+	return [][]key.Binding{s.ShortHelp()}
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (s *selectInput) KeyMapTitle() string {
+	return "Select"
+}
+
+func (s *selectInput) widget() orvyn.Widget {
+	return s
+}
+
+func (s *selectInput) value() (any, error) {
+	if len(s.options) == 0 {
+		return nil, nil
+	}
+
+	return s.options[s.selected].Value, nil
+}
+
+func (s *selectInput) setValue(v reflect.Value) {
+	s.selected = max(optionIndex(s.options, v), 0)
+}
+
+func (s *selectInput) bind(typ reflect.Type) bool {
+	return bindOptions(s.options, typ)
+}
+
+// optionIndex returns the index of the option whose value equals v, -1 if
+// none or if v is invalid.
+func optionIndex(options []Option, v reflect.Value) int {
+	// An interface field can hold a value of any type.
+	if !v.IsValid() || !v.Comparable() {
+		return -1
+	}
+
+	for i, o := range options {
+		if o.Value == nil {
+			continue
+		}
+
+		ov := reflect.ValueOf(o.Value)
+
+		if ov.Type().ConvertibleTo(v.Type()) && ov.Convert(v.Type()).Equal(v) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// bindOptions checks the option values can be stored in a struct field of
+// the type. The values are compared to the field one to find the selected
+// option, the types must be comparable.
+func bindOptions(options []Option, typ reflect.Type) bool {
+	if !typ.Comparable() {
+		return false
+	}
+
+	for _, o := range options {
+		if o.Value == nil {
+			continue
+		}
+
+		ot := reflect.TypeOf(o.Value)
+
+		if !ot.Comparable() {
+			return false
+		}
+
+		if !ot.AssignableTo(typ) && (ot.Kind() != typ.Kind() || !ot.ConvertibleTo(typ)) {
+			return false
+		}
+	}
+
+	return true
+}
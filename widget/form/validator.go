@@ -0,0 +1,101 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks the value of a field and returns the error shown under it,
+// nil when the value is valid. The value is a string for the text, password
+// and textarea fields, an int64, uint64 or float64 for the number fields, nil
+// when empty, a bool for the checkbox fields and the Option value for the
+// select fields.
+type Validator func(value any) error
+
+// Required refuses an empty text, an empty number, an unchecked checkbox and
+// a nil option value.
+func Required() Validator {
+	return func(value any) error {
+		switch v := value.(type) {
+		case nil:
+			return errors.New("required")
+
+		case string:
+			if strings.TrimSpace(v) == "" {
+				return errors.New("required")
+			}
+
+		case bool:
+			if !v {
+				return errors.New("required")
+			}
+		}
+
+		return nil
+	}
+}
+
+// MinLength refuses a text shorter than n characters. An empty text is
+// accepted, combine it with Required to refuse it.
+func MinLength(n int) Validator {
+	return func(value any) error {
+		if s, ok := value.(string); ok && s != "" && utf8.RuneCountInString(s) < n {
+			return fmt.Errorf("must be at least %d characters", n)
+		}
+
+		return nil
+	}
+}
+
+// MaxLength refuses a text longer than n characters.
+func MaxLength(n int) Validator {
+	return func(value any) error {
+		if s, ok := value.(string); ok && utf8.RuneCountInString(s) > n {
+			return fmt.Errorf("must be at most %d characters", n)
+		}
+
+		return nil
+	}
+}
+
+// Pattern refuses a non empty text not matching the regular expression, with
+// the given message.
+func Pattern(re *regexp.Regexp, message string) Validator {
+	return func(value any) error {
+		if s, ok := value.(string); ok && s != "" && !re.MatchString(s) {
+			return errors.New(message)
+		}
+
+		return nil
+	}
+}
+
+// Range refuses a number outside of [min, max].
+func Range(min, max float64) Validator {
+	return func(value any) error {
+		var n float64
+
+		switch v := value.(type) {
+		case int64:
+			n = float64(v)
+
+		case uint64:
+			n = float64(v)
+
+		case float64:
+			n = v
+
+		default:
+			return nil
+		}
+
+		if n < min || n > max {
+			return fmt.Errorf("must be between %v and %v", min, max)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package helpbar
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// Widget is a one line help bar showing the short help of a KeyMap. By
+// default it follows the default App: the bindings of the active screen or
+// dialog, so of the focused widget and its input mode when the screen
+// registers its FocusManager, see orvyn.RegisterKeyMap.
+type Widget struct {
+	orvyn.BaseWidget
+
+	help help.Model
+
+	keyMap orvyn.KeyMap
+}
+
+// New creates and returns a new helpbar *Widget.
+func New() *Widget {
+	w := new(Widget)
+
+	w.BaseWidget = orvyn.NewBaseWidget()
+
+	w.help = help.New()
+	w.updateStyle()
+
+	return w
+}
+
+// SetKeyMap changes the KeyMap shown by the help bar. A nil KeyMap shows the
+// default App one.
+func (w *Widget) SetKeyMap(km orvyn.KeyMap) {
+	w.keyMap = km
+}
+
+func (w *Widget) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.updateStyle()
+	}
+
+	return nil
+}
+
+func (w *Widget) Resize(size orvyn.Size) {
+	size.Height = 1
+
+	w.BaseWidget.Resize(size)
+}
+
+func (w *Widget) Render() string {
+	km := w.keyMap
+
+	if km == nil {
+		km = orvyn.DefaultApp()
+	}
+
+	size := w.GetContentSize()
+
+	w.help.Width = size.Width
+
+	return w.GetStyle().
+		Width(size.Width).
+		MaxHeight(size.Height).
+		Render(w.help.ShortHelpView(orvyn.HelpBindings(km.ShortHelp())))
+}
+
+// Hidden functions
+
+// updateStyle applies the theme to the widget and the help model.
+func (w *Widget) updateStyle() {
+	t := orvyn.GetTheme()
+
+	w.BaseWidget.SetStyle(t.Style(theme.NormalTextStyleID))
+
+	keyStyle := t.Style(theme.HelpKeyStyleID)
+	descStyle := t.Style(theme.HelpDescStyleID)
+	sepStyle := t.Style(theme.DimTextStyleID)
+
+	w.help.Styles = help.Styles{
+		ShortKey:       keyStyle,
+		ShortDesc:      descStyle,
+		ShortSeparator: sepStyle,
+		Ellipsis:       sepStyle,
+		FullKey:        keyStyle,
+		FullDesc:       descStyle,
+		FullSeparator:  sepStyle,
+	}
+}
@@ -0,0 +1,73 @@
+package helpbar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+)
+
+type testKeyMap []key.Binding
+
+func (k testKeyMap) ShortHelp() []key.Binding {
+	return k
+}
+
+func (k testKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k}
+}
+
+func TestRenderShortHelp(t *testing.T) {
+	orvyn.Init()
+
+	w := New()
+	w.SetKeyMap(testKeyMap{
+		key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add")),
+		key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete"), key.WithDisabled()),
+		key.NewBinding(key.WithKeys("x")),
+		key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+	})
+	w.Resize(orvyn.NewSize(40, 3))
+
+	view := ansi.Strip(w.Render())
+
+	if w.GetSize().Height != 1 || strings.Count(view, "\n") != 0 {
+		t.Errorf("help bar taller than a line:\n%s", view)
+	}
+
+	if !strings.Contains(view, "a add") || !strings.Contains(view, "q quit") {
+		t.Errorf("help bar does not show the bindings: %q", view)
+	}
+
+	if strings.Contains(view, "delete") {
+		t.Errorf("help bar shows a disabled binding: %q", view)
+	}
+}
+
+// emptyScreen is a screen without layout.
+type emptyScreen struct{}
+
+func (emptyScreen) OnEnter(any) tea.Cmd    { return nil }
+func (emptyScreen) OnExit() any            { return nil }
+func (emptyScreen) Update(tea.Msg) tea.Cmd { return nil }
+func (emptyScreen) Render() orvyn.Layout   { return nil }
+
+func TestFollowsDefaultApp(t *testing.T) {
+	orvyn.Init()
+
+	orvyn.RegisterScreen("main", emptyScreen{})
+	orvyn.SwitchScreen("main")
+	orvyn.RegisterKeyMap("main", testKeyMap{
+		key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save")),
+	})
+
+	w := New()
+	w.Resize(orvyn.NewSize(80, 1))
+
+	if view := ansi.Strip(w.Render()); !strings.Contains(view, "s save") {
+		t.Errorf("help bar does not show the active screen bindings: %q", view)
+	}
+}
@@ -1,6 +1,7 @@
 package label
 
 import (
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/halsten-dev/orvyn"
 	"github.com/halsten-dev/orvyn/theme"
 )
@@ -28,6 +29,14 @@ func (w *Widget) SetValue(value string) {
 	w.value = value
 }
 
+func (w *Widget) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseWidget.SetStyle(orvyn.GetTheme().Style(theme.LabelTextStyleID))
+	}
+
+	return nil
+}
+
 func (w *Widget) Render() string {
 	size := w.BaseWidget.GetContentSize()
 
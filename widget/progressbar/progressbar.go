@@ -31,6 +31,10 @@ type Widget struct {
 	showTitle                  bool
 	showCurrentMaxValueInTitle bool
 	showPercentage             bool
+
+	// customColor is true once the bar color is set with SetColor, it is then
+	// kept when the theme changes.
+	customColor bool
 }
 
 // New creates and return a new progress bar *Widget.
@@ -61,6 +65,19 @@ func (w *Widget) Update(msg tea.Msg) tea.Cmd {
 		progressModel, cmd := w.Model.Update(msg)
 		w.Model = progressModel.(progress.Model)
 		return cmd
+
+	case orvyn.ThemeChangedMsg:
+		t := orvyn.GetTheme()
+
+		w.SetStyle(t.Style(theme.BlurredWidgetStyleID))
+
+		if !w.customColor {
+			w.Model.FullColor = string(t.Color(theme.NormalFontColorID))
+		}
+
+		w.TitleStyle = t.Style(theme.TitleStyleID).
+			AlignHorizontal(lipgloss.Center).
+			Width(w.TitleStyle.GetWidth())
 	}
 
 	return nil
@@ -120,6 +137,7 @@ func (w *Widget) GetPreferredSize() orvyn.Size {
 // SetColor helps changing the bar color.
 func (w *Widget) SetColor(color lipgloss.Color) {
 	w.Model.FullColor = string(color)
+	w.customColor = true
 }
 
 // SetTitleVisibility changes the visibility of the title.
@@ -0,0 +1,358 @@
+// Package radio provides a radio group: a set of options of which exactly one
+// is selected.
+package radio
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// Actions of the radio widget, see orvyn.Bindings.
+const (
+	EditAction     orvyn.ActionID = "radio.edit"
+	PreviousAction orvyn.ActionID = "radio.previous"
+	NextAction     orvyn.ActionID = "radio.next"
+)
+
+func init() {
+	orvyn.DefineAction(EditAction,
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "choose"))
+	orvyn.DefineAction(PreviousAction,
+		key.WithKeys("up", "left"),
+		key.WithHelp("↑/←", "previous"))
+	orvyn.DefineAction(NextAction,
+		key.WithKeys("down", "right"),
+		key.WithHelp("↓/→", "next"))
+}
+
+// Orientation defines how the options are laid out.
+type Orientation int
+
+const (
+	// Vertical lists the options one per line.
+	Vertical Orientation = iota
+
+	// Horizontal lists the options on a single line.
+	Horizontal
+)
+
+// optionGap is the space between two options of a horizontal group.
+const optionGap = 2
+
+// Widget is a radio group widget. The arrows move the selection while in
+// input mode, entered and left with EditKeybind.
+type Widget struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	// EditKeybind enters the input mode, and leaves it along with the exit
+	// input key. Enter by default.
+	EditKeybind key.Binding
+
+	// PreviousKeybind selects the previous option in input mode. Up and left
+	// by default.
+	PreviousKeybind key.Binding
+
+	// NextKeybind selects the next option in input mode. Down and right by
+	// default.
+	NextKeybind key.Binding
+
+	// SelectedMarker and UnselectedMarker are shown before the options.
+	// "(•)" and "( )" by default.
+	SelectedMarker   string
+	UnselectedMarker string
+
+	options     []string
+	selected    int
+	orientation Orientation
+}
+
+// New creates and returns a new vertical radio *Widget, the first option
+// selected.
+func New(options ...string) *Widget {
+	w := new(Widget)
+
+	w.BaseWidget = orvyn.NewBaseWidget()
+	w.BaseFocusable = orvyn.NewBaseFocusable(w)
+
+	w.EditKeybind = orvyn.Binding(EditAction)
+	w.PreviousKeybind = orvyn.Binding(PreviousAction)
+	w.NextKeybind = orvyn.Binding(NextAction)
+
+	w.SelectedMarker = "(•)"
+	w.UnselectedMarker = "( )"
+
+	w.options = options
+	w.orientation = Vertical
+
+	w.OnBlur()
+
+	return w
+}
+
+// NewHorizontal creates and returns a new radio *Widget listing the options
+// on a single line.
+func NewHorizontal(options ...string) *Widget {
+	w := New(options...)
+	w.orientation = Horizontal
+
+	return w
+}
+
+func (w *Widget) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+
+		return nil
+	}
+
+	if len(w.options) == 0 {
+		return nil
+	}
+
+	if m, ok := orvyn.GetKeyMsg(msg); ok && w.IsInputting() {
+		switch {
+		case key.Matches(m, w.PreviousKeybind):
+			w.selected = max(w.selected-1, 0)
+
+		case key.Matches(m, w.NextKeybind):
+			w.selected = min(w.selected+1, len(w.options)-1)
+		}
+	}
+
+	if m, ok := msg.(orvyn.MouseMsg); ok && m.IsLeftClick() {
+		if position, ok := m.Local(w); ok {
+			if index := w.optionAt(position); index >= 0 {
+				w.selected = index
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *Widget) Resize(size orvyn.Size) {
+	size.Height = w.linesCount() + w.GetStyle().GetVerticalFrameSize()
+
+	w.BaseWidget.Resize(size)
+}
+
+func (w *Widget) Render() string {
+	options := make([]string, len(w.options))
+
+	for i := range w.options {
+		options[i] = w.renderOption(i)
+	}
+
+	separator := "\n"
+
+	if w.orientation == Horizontal {
+		separator = strings.Repeat(" ", optionGap)
+	}
+
+	return w.GetStyle().
+		Width(w.GetContentSize().Width).
+		Render(strings.Join(options, separator))
+}
+
+func (w *Widget) GetMinSize() orvyn.Size {
+	width := w.optionsWidth() + w.GetStyle().GetHorizontalFrameSize()
+
+	return orvyn.NewSize(max(width, 15), w.linesCount()+w.GetStyle().GetVerticalFrameSize())
+}
+
+func (w *Widget) GetPreferredSize() orvyn.Size {
+	size := w.GetMinSize()
+	size.Width = max(size.Width, 46)
+
+	return size
+}
+
+// GetExitInputKeybind returns the exit input key along with EditKeybind, so
+// the same key enters and leaves the input mode.
+func (w *Widget) GetExitInputKeybind() key.Binding {
+	exit := orvyn.Binding(orvyn.ExitInputAction)
+
+	return key.NewBinding(
+		key.WithKeys(append(exit.Keys(), w.EditKeybind.Keys()...)...),
+		key.WithHelp(exit.Help().Key+"/"+w.EditKeybind.Help().Key, "done"))
+}
+
+// GetEnterInputKeybind returns EditKeybind.
+func (w *Widget) GetEnterInputKeybind() *key.Binding {
+	return &w.EditKeybind
+}
+
+// ShortHelp implements orvyn.KeyMap. The keys moving the selection, in
+// input mode only: the FocusManager lists EditKeybind otherwise.
+func (w *Widget) ShortHelp() []key.Binding {
+	if !w.IsInputting() {
+		return nil
+	}
+
+	return []key.Binding{w.PreviousKeybind, w.NextKeybind}
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (w *Widget) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{w.EditKeybind, w.PreviousKeybind, w.NextKeybind}}
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (w *Widget) KeyMapTitle() string {
+	return "Radio"
+}
+
+// Public API
+
+// GetSelected returns the index of the selected option, -1 without options.
+func (w *Widget) GetSelected() int {
+	if len(w.options) == 0 {
+		return -1
+	}
+
+	return w.selected
+}
+
+// SetSelected selects the option at the index. Nothing happens when out of
+// range.
+func (w *Widget) SetSelected(index int) {
+	if index < 0 || index >= len(w.options) {
+		return
+	}
+
+	w.selected = index
+}
+
+// GetSelectedOption returns the selected option, an empty string without
+// options.
+func (w *Widget) GetSelectedOption() string {
+	if len(w.options) == 0 {
+		return ""
+	}
+
+	return w.options[w.selected]
+}
+
+// SetOptions replaces the options, the first one selected.
+func (w *Widget) SetOptions(options ...string) {
+	w.options = options
+	w.selected = 0
+}
+
+// GetOptions returns the options.
+func (w *Widget) GetOptions() []string {
+	return w.options
+}
+
+// SetOrientation changes how the options are laid out.
+func (w *Widget) SetOrientation(orientation Orientation) {
+	w.orientation = orientation
+}
+
+// GetOrientation returns how the options are laid out.
+func (w *Widget) GetOrientation() Orientation {
+	return w.orientation
+}
+
+// Hidden functions
+
+func (w *Widget) renderOption(index int) string {
+	t := orvyn.GetTheme()
+
+	marker := t.Style(theme.DimTextStyleID).Render(w.padMarker(w.UnselectedMarker))
+	label := t.Style(theme.NormalTextStyleID).Render(w.options[index])
+
+	if index == w.selected {
+		marker = t.Style(theme.TitleStyleID).Render(w.padMarker(w.SelectedMarker))
+
+		if w.IsInputting() {
+			label = t.Style(theme.HighlightTextStyleID).Render(w.options[index])
+		}
+	}
+
+	return marker + " " + label
+}
+
+// padMarker brings the marker to the width of the widest one, so the labels
+// stay aligned.
+func (w *Widget) padMarker(marker string) string {
+	return marker + strings.Repeat(" ", w.markerWidth()-lipgloss.Width(marker))
+}
+
+func (w *Widget) markerWidth() int {
+	return max(lipgloss.Width(w.SelectedMarker), lipgloss.Width(w.UnselectedMarker))
+}
+
+// optionWidth returns the width of a rendered option.
+func (w *Widget) optionWidth(index int) int {
+	return w.markerWidth() + 1 + lipgloss.Width(w.options[index])
+}
+
+// optionsWidth returns the width of the options, without the frame.
+func (w *Widget) optionsWidth() int {
+	width := 0
+
+	for i := range w.options {
+		if w.orientation == Horizontal {
+			if i > 0 {
+				width += optionGap
+			}
+
+			width += w.optionWidth(i)
+
+			continue
+		}
+
+		width = max(width, w.optionWidth(i))
+	}
+
+	return width
+}
+
+func (w *Widget) linesCount() int {
+	if w.orientation == Horizontal {
+		return 1
+	}
+
+	return max(len(w.options), 1)
+}
+
+// optionAt returns the index of the option at the position relative to the
+// widget, -1 if none.
+func (w *Widget) optionAt(position orvyn.Position) int {
+	style := w.GetStyle()
+
+	x := position.X - style.GetBorderLeftSize() - style.GetPaddingLeft() - style.GetMarginLeft()
+	y := position.Y - style.GetBorderTopSize() - style.GetPaddingTop() - style.GetMarginTop()
+
+	if w.orientation == Vertical {
+		if y < 0 || y >= len(w.options) {
+			return -1
+		}
+
+		return y
+	}
+
+	if y != 0 {
+		return -1
+	}
+
+	for i := range w.options {
+		width := w.optionWidth(i)
+
+		if x >= 0 && x < width {
+			return i
+		}
+
+		x -= width + optionGap
+	}
+
+	return -1
+}
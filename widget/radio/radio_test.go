@@ -0,0 +1,92 @@
+package radio
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+)
+
+func keyMsg(t tea.KeyType) tea.KeyMsg {
+	return tea.KeyMsg{Type: t}
+}
+
+func TestArrowsInInputMode(t *testing.T) {
+	orvyn.Init()
+
+	w := New("Small", "Medium", "Large")
+
+	f := orvyn.NewFocusManager()
+	f.Add(w)
+	f.FocusFirst()
+
+	// Outside of input mode the arrows are left to the screen.
+	f.Update(keyMsg(tea.KeyDown))
+
+	if w.GetSelected() != 0 {
+		t.Fatalf("selected = %d before entering input mode", w.GetSelected())
+	}
+
+	f.Update(keyMsg(tea.KeyEnter))
+
+	if !w.IsInputting() {
+		t.Fatal("enter did not enter input mode")
+	}
+
+	f.Update(keyMsg(tea.KeyDown))
+	f.Update(keyMsg(tea.KeyDown))
+	f.Update(keyMsg(tea.KeyDown))
+
+	if w.GetSelectedOption() != "Large" {
+		t.Errorf("selected %q, want Large", w.GetSelectedOption())
+	}
+
+	f.Update(keyMsg(tea.KeyLeft))
+
+	if w.GetSelectedOption() != "Medium" {
+		t.Errorf("selected %q, want Medium", w.GetSelectedOption())
+	}
+
+	f.Update(keyMsg(tea.KeyEnter))
+
+	if w.IsInputting() {
+		t.Error("enter did not leave input mode")
+	}
+}
+
+func TestClickHorizontal(t *testing.T) {
+	orvyn.Init()
+
+	w := NewHorizontal("Yes", "No", "Maybe")
+	w.Resize(w.GetPreferredSize())
+	w.Render()
+
+	// Frame, then "(•) Yes  ( ) No  ( ) Maybe". A click between two options
+	// keeps the selection.
+	tests := []struct {
+		x    int
+		want int
+	}{
+		{1, 0},
+		{7, 0},
+		{9, 0},
+		{10, 1},
+		{18, 2},
+		{26, 2},
+	}
+
+	for _, test := range tests {
+		w.SetSelected(0)
+
+		size := w.GetSize()
+
+		w.Update(orvyn.MouseMsg{
+			MouseMsg: tea.MouseMsg{X: test.x, Y: 1, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+			Hits:     []orvyn.Hit{{Renderable: w, Rect: orvyn.NewRect(orvyn.NewPosition(0, 0), size)}},
+		})
+
+		if got := w.GetSelected(); got != test.want {
+			t.Errorf("click at %d selected %d, want %d", test.x, got, test.want)
+		}
+	}
+}
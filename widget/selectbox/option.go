@@ -0,0 +1,64 @@
+package selectbox
+
+import (
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+	"github.com/halsten-dev/orvyn/widget/widgetlist"
+)
+
+// optionItem is the default item of the popup list: the formatted option on
+// one line, highlighted under the cursor.
+type optionItem[T any] struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	data   T
+	format func(T) string
+}
+
+func newOptionItem[T any](data T, format func(T) string) widgetlist.ListItem[T] {
+	o := new(optionItem[T])
+
+	o.BaseWidget = orvyn.NewBaseWidget()
+	o.BaseFocusable = orvyn.NewBaseFocusable(o)
+
+	o.data = data
+	o.format = format
+
+	o.OnBlur()
+
+	return o
+}
+
+func (o *optionItem[T]) OnFocus() {
+	o.SetStyle(orvyn.GetTheme().Style(theme.HighlightTextStyleID).Bold(true))
+}
+
+func (o *optionItem[T]) OnBlur() {
+	o.SetStyle(orvyn.GetTheme().Style(theme.NormalTextStyleID))
+}
+
+func (o *optionItem[T]) Resize(size orvyn.Size) {
+	size.Height = 1
+
+	o.BaseWidget.Resize(size)
+}
+
+func (o *optionItem[T]) Render() string {
+	return o.GetStyle().
+		Width(o.GetContentSize().Width).
+		MaxHeight(1).
+		Render(o.format(o.data))
+}
+
+func (o *optionItem[T]) FilterValue() string {
+	return o.format(o.data)
+}
+
+func (o *optionItem[T]) UpdateData(data T) {
+	o.data = data
+}
+
+func (o *optionItem[T]) GetData() T {
+	return o.data
+}
@@ -0,0 +1,436 @@
+// Package selectbox provides a single line field choosing one value from a
+// set of options, in a popup list opened over the view.
+package selectbox
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+	"github.com/halsten-dev/orvyn/widget/widgetlist"
+)
+
+// Actions of the selectbox widget, see orvyn.Bindings.
+const (
+	OpenAction       orvyn.ActionID = "selectbox.open"
+	CursorUpAction   orvyn.ActionID = "selectbox.cursor_up"
+	CursorDownAction orvyn.ActionID = "selectbox.cursor_down"
+)
+
+func init() {
+	orvyn.DefineAction(OpenAction,
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open"))
+	orvyn.DefineAction(CursorUpAction,
+		key.WithKeys("up"),
+		key.WithHelp("↑", "up"))
+	orvyn.DefineAction(CursorDownAction,
+		key.WithKeys("down"),
+		key.WithHelp("↓", "down"))
+}
+
+// ValueChangedMsg is sent when the user chooses another option. Index is the
+// index of Value in the options.
+type ValueChangedMsg[T any] struct {
+	Widget *Widget[T]
+	Value  T
+	Index  int
+}
+
+// Widget shows the chosen option on one line. The options are listed in a
+// popup under the field, see orvyn.OpenPopup, where typing filters them.
+// T type represents the type of the options.
+type Widget[T any] struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	// OpenKeybind opens the popup list, and chooses the option under the
+	// cursor while open. Enter by default.
+	OpenKeybind key.Binding
+
+	// CursorUpKeybind moves the cursor of the open list up. Up by default.
+	CursorUpKeybind key.Binding
+
+	// CursorDownKeybind moves the cursor of the open list down. Down by
+	// default.
+	CursorDownKeybind key.Binding
+
+	// Placeholder is shown while no option is chosen.
+	Placeholder string
+
+	// MaxVisibleOptions is the number of options listed per page of the popup.
+	// 8 by default.
+	MaxVisibleOptions int
+
+	options  []T
+	selected int
+
+	format          func(T) string
+	itemConstructor widgetlist.ItemConstructor[T]
+
+	list   *widgetlist.Widget[T]
+	open   bool
+	filter string
+}
+
+// New creates and returns a new selectbox *Widget listing the options, none
+// of them chosen.
+func New[T any](options ...T) *Widget[T] {
+	w := new(Widget[T])
+
+	w.BaseWidget = orvyn.NewBaseWidget()
+	w.BaseFocusable = orvyn.NewBaseFocusable(w)
+
+	w.OpenKeybind = orvyn.Binding(OpenAction)
+	w.CursorUpKeybind = orvyn.Binding(CursorUpAction)
+	w.CursorDownKeybind = orvyn.Binding(CursorDownAction)
+
+	w.Placeholder = "Select..."
+	w.MaxVisibleOptions = 8
+
+	w.selected = -1
+	w.format = formatOption[T]
+
+	w.itemConstructor = func(data T) widgetlist.ListItem[T] {
+		return newOptionItem(data, w.formatValue)
+	}
+
+	w.list = widgetlist.New(func(data T) widgetlist.ListItem[T] {
+		return w.itemConstructor(data)
+	})
+	w.list.SetFilterable(false)
+
+	w.SetOptions(options)
+
+	w.OnBlur()
+
+	return w
+}
+
+func (w *Widget[T]) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+
+		return w.list.Update(msg)
+	}
+
+	if m, ok := msg.(orvyn.MouseMsg); ok {
+		return w.updateMouse(m)
+	}
+
+	m, ok := orvyn.GetKeyMsg(msg)
+
+	if !ok {
+		return nil
+	}
+
+	if !w.open {
+		if key.Matches(m, w.OpenKeybind) {
+			w.Open()
+		}
+
+		return nil
+	}
+
+	switch {
+	case key.Matches(m, w.OpenKeybind):
+		return w.choose()
+
+	case key.Matches(m, w.GetExitInputKeybind()):
+		w.Close()
+
+	case key.Matches(m, w.CursorUpKeybind):
+		w.list.PreviousItem()
+		w.list.SetCursor(w.list.GetGlobalIndex())
+
+	case key.Matches(m, w.CursorDownKeybind):
+		w.list.NextItem()
+		w.list.SetCursor(w.list.GetGlobalIndex())
+
+	case m.Type == tea.KeyBackspace:
+		if runes := []rune(w.filter); len(runes) > 0 {
+			w.setFilter(string(runes[:len(runes)-1]))
+		}
+
+	case m.Type == tea.KeyRunes || m.Type == tea.KeySpace:
+		w.setFilter(w.filter + string(m.Runes))
+	}
+
+	return nil
+}
+
+func (w *Widget[T]) Resize(size orvyn.Size) {
+	size.Height = 1 + w.GetStyle().GetVerticalFrameSize()
+
+	w.BaseWidget.Resize(size)
+}
+
+func (w *Widget[T]) Render() string {
+	t := orvyn.GetTheme()
+
+	width := w.GetContentSize().Width
+	textWidth := max(width-2, 0)
+
+	var text string
+
+	switch {
+	case w.open && w.filter != "":
+		text = t.Style(theme.NormalTextStyleID).Render(w.filter)
+
+	case w.selected >= 0:
+		text = t.Style(theme.NormalTextStyleID).Render(w.formatValue(w.options[w.selected]))
+
+	default:
+		text = t.Style(theme.DimTextStyleID).Render(w.Placeholder)
+	}
+
+	arrow := "▾"
+
+	if w.open {
+		arrow = "▴"
+	}
+
+	text = lipgloss.NewStyle().Width(textWidth).Render(ansi.Truncate(text, textWidth, "…"))
+
+	return w.GetStyle().
+		Width(width).
+		Render(text + " " + t.Style(theme.DimTextStyleID).Render(arrow))
+}
+
+func (w *Widget[T]) GetMinSize() orvyn.Size {
+	return orvyn.NewSize(15, 3)
+}
+
+func (w *Widget[T]) GetPreferredSize() orvyn.Size {
+	return orvyn.NewSize(46, 3)
+}
+
+func (w *Widget[T]) OnBlur() {
+	w.Close()
+	w.BaseFocusable.OnBlur()
+}
+
+// IsInputting returns true while the popup is open: the keys then go to the
+// list, and the exit input key closes it.
+func (w *Widget[T]) IsInputting() bool {
+	return w.open || w.BaseFocusable.IsInputting()
+}
+
+// OnExitInput closes the popup without changing the value.
+func (w *Widget[T]) OnExitInput() tea.Cmd {
+	w.Close()
+
+	return nil
+}
+
+// ShortHelp implements orvyn.KeyMap.
+func (w *Widget[T]) ShortHelp() []key.Binding {
+	if !w.open {
+		return []key.Binding{w.OpenKeybind}
+	}
+
+	choose := w.OpenKeybind
+	choose.SetHelp(choose.Help().Key, "choose")
+
+	exit := w.GetExitInputKeybind()
+	exit.SetHelp(exit.Help().Key, "close")
+
+	return []key.Binding{choose, w.CursorUpKeybind, w.CursorDownKeybind, exit}
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (w *Widget[T]) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{w.OpenKeybind, w.CursorUpKeybind, w.CursorDownKeybind}}
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (w *Widget[T]) KeyMapTitle() string {
+	return "Select"
+}
+
+// Public API
+
+// SetOptions replaces the options. The chosen one is kept by index when still
+// in range, and the popup is closed.
+func (w *Widget[T]) SetOptions(options []T) {
+	w.Close()
+
+	w.options = options
+	w.list.SetItems(options)
+
+	if w.selected >= len(options) {
+		w.selected = -1
+	}
+}
+
+// GetOptions returns the options.
+func (w *Widget[T]) GetOptions() []T {
+	return w.options
+}
+
+// GetValue returns the chosen option, false if none.
+func (w *Widget[T]) GetValue() (T, bool) {
+	var none T
+
+	if w.selected < 0 {
+		return none, false
+	}
+
+	return w.options[w.selected], true
+}
+
+// GetSelectedIndex returns the index of the chosen option, -1 if none.
+func (w *Widget[T]) GetSelectedIndex() int {
+	return w.selected
+}
+
+// SetSelectedIndex chooses the option at the index, without sending a
+// ValueChangedMsg. An index out of range clears the choice.
+func (w *Widget[T]) SetSelectedIndex(index int) {
+	if index < 0 || index >= len(w.options) {
+		index = -1
+	}
+
+	w.selected = index
+}
+
+// SetFormat changes how the options are shown, in the field and by the
+// default list items. fmt.Sprint by default, restored by nil.
+func (w *Widget[T]) SetFormat(format func(T) string) {
+	if format == nil {
+		format = formatOption[T]
+	}
+
+	w.format = format
+}
+
+// SetItemConstructor changes the items of the popup list, the ones of a
+// widgetlist.Widget. The items must report their FilterValue for typing to
+// filter them.
+func (w *Widget[T]) SetItemConstructor(itemConstructor widgetlist.ItemConstructor[T]) {
+	w.itemConstructor = itemConstructor
+
+	w.SetOptions(w.options)
+}
+
+// Open opens the popup list, the cursor on the chosen option. Nothing happens
+// without options.
+func (w *Widget[T]) Open() {
+	if w.open || len(w.options) == 0 {
+		return
+	}
+
+	w.open = true
+	w.filter = ""
+
+	w.list.ClearFilter()
+	w.list.SetCursor(max(w.selected, 0))
+	w.list.OnFocus()
+	w.list.SetPreferredSize(orvyn.NewSize(w.GetSize().Width, w.popupHeight()))
+
+	orvyn.OpenPopup(w, w.list)
+}
+
+// Close closes the popup list without changing the value.
+func (w *Widget[T]) Close() {
+	if !w.open {
+		return
+	}
+
+	w.open = false
+	w.filter = ""
+
+	w.list.OnBlur()
+
+	orvyn.ClosePopup(w)
+}
+
+// IsOpen returns true while the popup list is open.
+func (w *Widget[T]) IsOpen() bool {
+	return w.open
+}
+
+// Hidden functions
+
+// updateMouse chooses the clicked option of the open list, and opens or
+// closes the list on a click on the field.
+func (w *Widget[T]) updateMouse(m orvyn.MouseMsg) tea.Cmd {
+	if w.open && m.IsOver(w.list) {
+		cmd := w.list.Update(m)
+
+		if m.IsLeftClick() && m.Target() != w.list {
+			return tea.Batch(cmd, w.choose())
+		}
+
+		return cmd
+	}
+
+	if !m.IsLeftClick() || !m.IsOver(w) {
+		return nil
+	}
+
+	if w.open {
+		w.Close()
+	} else {
+		w.Open()
+	}
+
+	return nil
+}
+
+// choose makes the option under the cursor the value and closes the list.
+// The list stays open when the filter matches nothing.
+func (w *Widget[T]) choose() tea.Cmd {
+	index := w.list.GetGlobalIndex()
+
+	if index < 0 || index >= len(w.options) {
+		return nil
+	}
+
+	w.Close()
+
+	if index == w.selected {
+		return nil
+	}
+
+	w.selected = index
+	value := w.options[index]
+
+	return func() tea.Msg {
+		return ValueChangedMsg[T]{Widget: w, Value: value, Index: index}
+	}
+}
+
+func (w *Widget[T]) setFilter(filter string) {
+	w.filter = filter
+	w.list.ApplyFilter(filter)
+}
+
+// popupHeight returns the height of the list showing a page of options.
+func (w *Widget[T]) popupHeight() int {
+	visible := max(w.MaxVisibleOptions, 1)
+
+	item := w.itemConstructor(w.options[0])
+	item.Resize(orvyn.NewSize(w.GetContentSize().Width, 1))
+
+	height := min(len(w.options), visible) * max(item.GetSize().Height, 1)
+
+	// The paginator takes a line.
+	if len(w.options) > visible {
+		height++
+	}
+
+	return height + w.list.GetStyle().GetVerticalFrameSize()
+}
+
+func (w *Widget[T]) formatValue(v T) string {
+	return w.format(v)
+}
+
+func formatOption[T any](v T) string {
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,222 @@
+package selectbox
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/orvyntest"
+	"github.com/halsten-dev/orvyn/widget/checkbox"
+)
+
+// selectScreen holds a selectbox above a checkbox.
+type selectScreen struct {
+	sbFruit *Widget[string]
+	cbAgree *checkbox.Widget
+
+	focusManager *orvyn.FocusManager
+	layout       orvyn.Layout
+}
+
+func newSelectScreen() *selectScreen {
+	s := new(selectScreen)
+
+	s.sbFruit = New("apple", "banana", "cherry", "blackberry")
+	s.cbAgree = checkbox.New("Agree")
+
+	s.focusManager = orvyn.NewFocusManager()
+	s.focusManager.Add(s.sbFruit)
+	s.focusManager.Add(s.cbAgree)
+
+	s.layout = layout.NewVBoxLayout(0, s.sbFruit, s.cbAgree)
+
+	return s
+}
+
+func (s *selectScreen) OnEnter(any) tea.Cmd {
+	s.focusManager.FocusFirst()
+
+	return nil
+}
+
+func (s *selectScreen) OnExit() any {
+	return nil
+}
+
+func (s *selectScreen) Update(msg tea.Msg) tea.Cmd {
+	return s.focusManager.Update(msg)
+}
+
+func (s *selectScreen) Render() orvyn.Layout {
+	return s.layout
+}
+
+func newTestHarness(t *testing.T) (*orvyntest.Harness, *selectScreen) {
+	t.Helper()
+
+	h := orvyntest.New(t, orvyn.NewSize(40, 20))
+	s := newSelectScreen()
+
+	h.Register("select", s)
+	h.Start("select")
+
+	return h, s
+}
+
+func valueChanges(h *orvyntest.Harness) []ValueChangedMsg[string] {
+	var changes []ValueChangedMsg[string]
+
+	for _, msg := range h.Messages {
+		if m, ok := msg.(ValueChangedMsg[string]); ok {
+			changes = append(changes, m)
+		}
+	}
+
+	return changes
+}
+
+func TestOpenAndChoose(t *testing.T) {
+	h, s := newTestHarness(t)
+
+	h.AssertContains("Select...")
+	h.AssertNotContains("banana")
+
+	h.Press("enter")
+
+	if !s.sbFruit.IsOpen() {
+		t.Fatal("enter did not open the list")
+	}
+
+	h.AssertInputting(s.sbFruit)
+	h.AssertContains("banana")
+
+	h.Press("down", "enter")
+
+	if s.sbFruit.IsOpen() {
+		t.Error("list still open after choosing")
+	}
+
+	if v, ok := s.sbFruit.GetValue(); !ok || v != "banana" {
+		t.Errorf("value = %q, %v, want banana", v, ok)
+	}
+
+	changes := valueChanges(h)
+
+	if len(changes) != 1 || changes[0].Value != "banana" || changes[0].Index != 1 {
+		t.Errorf("ValueChangedMsg sent %+v, want banana at 1", changes)
+	}
+
+	// The list reopens on the chosen option, choosing it again is no change.
+	h.Press("enter", "enter")
+
+	if len(valueChanges(h)) != 1 {
+		t.Error("ValueChangedMsg sent without a change")
+	}
+}
+
+func TestTypeToFilter(t *testing.T) {
+	h, s := newTestHarness(t)
+
+	h.Press("enter")
+	h.Type("bl")
+
+	h.AssertContains("blackberry")
+	h.AssertNotContains("cherry")
+
+	h.Press("backspace", "backspace")
+	h.AssertContains("cherry")
+
+	h.Type("zz")
+	h.Press("enter")
+
+	if !s.sbFruit.IsOpen() {
+		t.Error("list closed with no matching option")
+	}
+
+	h.Press("backspace", "backspace")
+	h.Type("che")
+	h.Press("enter")
+
+	if v, _ := s.sbFruit.GetValue(); v != "cherry" {
+		t.Errorf("value = %q, want cherry", v)
+	}
+}
+
+func TestExitClosesWithoutChange(t *testing.T) {
+	h, s := newTestHarness(t)
+
+	s.sbFruit.SetSelectedIndex(0)
+
+	h.Press("enter", "down", "esc")
+
+	if s.sbFruit.IsOpen() {
+		t.Fatal("esc did not close the list")
+	}
+
+	if v, _ := s.sbFruit.GetValue(); v != "apple" {
+		t.Errorf("value = %q, want apple", v)
+	}
+
+	if len(valueChanges(h)) != 0 {
+		t.Error("ValueChangedMsg sent on esc")
+	}
+
+	// Closed, the focus moves again.
+	h.Press("tab")
+	h.AssertFocused(s.cbAgree)
+}
+
+func TestClickOption(t *testing.T) {
+	h, s := newTestHarness(t)
+
+	h.Click(2, 1)
+
+	if !s.sbFruit.IsOpen() {
+		t.Fatal("click did not open the list")
+	}
+
+	// The popup is drawn under the field: its border, then the options.
+	lines := strings.Split(h.Frame(), "\n")
+	row := -1
+
+	for i, line := range lines {
+		if strings.Contains(line, "cherry") {
+			row = i
+		}
+	}
+
+	if row < 3 {
+		t.Fatalf("cherry not listed under the field:\n%s", h.Frame())
+	}
+
+	h.Click(2, row)
+
+	if v, _ := s.sbFruit.GetValue(); v != "cherry" {
+		t.Errorf("value = %q, want cherry", v)
+	}
+
+	if s.sbFruit.IsOpen() {
+		t.Error("list still open after clicking an option")
+	}
+}
+
+func TestBlurCloses(t *testing.T) {
+	h, s := newTestHarness(t)
+
+	h.Press("enter")
+	h.Click(2, 18)
+
+	if !s.sbFruit.IsOpen() {
+		t.Fatal("click outside any widget closed the list")
+	}
+
+	s.focusManager.Focus(1)
+
+	if s.sbFruit.IsOpen() {
+		t.Error("list still open after losing the focus")
+	}
+
+	h.AssertNotContains("banana")
+}
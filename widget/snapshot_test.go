@@ -0,0 +1,209 @@
+package widget_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/orvyntest"
+	"github.com/halsten-dev/orvyn/widget/checkbox"
+	"github.com/halsten-dev/orvyn/widget/form"
+	"github.com/halsten-dev/orvyn/widget/helpbar"
+	"github.com/halsten-dev/orvyn/widget/label"
+	"github.com/halsten-dev/orvyn/widget/progressbar"
+	"github.com/halsten-dev/orvyn/widget/radio"
+	"github.com/halsten-dev/orvyn/widget/selectbox"
+	"github.com/halsten-dev/orvyn/widget/statusmessage"
+	"github.com/halsten-dev/orvyn/widget/table"
+	"github.com/halsten-dev/orvyn/widget/tabs"
+	"github.com/halsten-dev/orvyn/widget/textarea"
+	"github.com/halsten-dev/orvyn/widget/textinput"
+	"github.com/halsten-dev/orvyn/widget/toggle"
+	"github.com/halsten-dev/orvyn/widget/widgetlist"
+)
+
+func TestWidgetSnapshots(t *testing.T) {
+	tests := []struct {
+		name   string
+		widget func() orvyn.Renderable
+	}{
+		{"label", func() orvyn.Renderable {
+			return label.New("A label")
+		}},
+		{"checkbox", func() orvyn.Renderable {
+			w := checkbox.New("Checked")
+			w.SetChecked(true)
+
+			return w
+		}},
+		{"textinput_placeholder", func() orvyn.Renderable {
+			w := textinput.New()
+			w.Placeholder = "Placeholder"
+
+			return w
+		}},
+		{"textinput_value", func() orvyn.Renderable {
+			w := textinput.New()
+			w.Prompt = "> "
+			w.SetValue("some value")
+
+			return w
+		}},
+		{"textarea", func() orvyn.Renderable {
+			w := textarea.New()
+			w.SetValue("first line\nsecond line")
+
+			return w
+		}},
+		{"progressbar", func() orvyn.Renderable {
+			w := progressbar.New("Progress")
+			w.CurrentValue = 3
+			w.MaxValue = 10
+
+			return w
+		}},
+		{"statusmessage", func() orvyn.Renderable {
+			w := statusmessage.New()
+			w.SetError(errors.New("something failed"))
+
+			return w
+		}},
+		{"widgetlist", func() orvyn.Renderable {
+			w := widgetlist.New(widgetlist.SimpleListItemConstructor)
+			w.SetItems([]string{"first", "second", "third", "fourth", "fifth"})
+			w.NextItem()
+
+			return w
+		}},
+		{"widgetlist_scroll", func() orvyn.Renderable {
+			w := widgetlist.New(widgetlist.SimpleListItemConstructor)
+			w.SetDisplayMode(widgetlist.Scroll)
+			w.SetItems([]string{"one", "two", "three", "four", "five", "six",
+				"seven", "eight", "nine", "ten", "eleven", "twelve"})
+			w.SetCursor(8)
+
+			return w
+		}},
+		{"widgetlist_grouped", func() orvyn.Renderable {
+			w := widgetlist.New(widgetlist.SimpleListItemConstructor)
+			w.SetDisplayMode(widgetlist.Scroll)
+			w.SetFilterable(false)
+			w.SetItems([]string{"apple", "carrot", "banana", "leek", "cherry", "onion", "pear"})
+			w.SetGroupBy(func(s string) string {
+				switch s {
+				case "carrot", "leek", "onion":
+					return "Vegetables"
+				}
+
+				return "Fruits"
+			})
+			w.SetCursor(5)
+
+			return w
+		}},
+		{"widgetlist_virtual", func() orvyn.Renderable {
+			items := make([]string, 1000)
+
+			for i := range items {
+				items[i] = fmt.Sprintf("entry %d", i)
+			}
+
+			w := widgetlist.NewVirtual(widgetlist.SimpleListItemConstructor,
+				func(s string) string { return s })
+			w.SetItems(items)
+			w.SetCursor(500)
+
+			return w
+		}},
+		{"widgetlist_multiselect", func() orvyn.Renderable {
+			w := widgetlist.New(widgetlist.SimpleListItemConstructor)
+			w.MultiSelect = true
+			w.SetFilterable(false)
+			w.SetItems([]string{"first", "second", "third"})
+			w.SetSelected(0, true)
+			w.SetSelected(2, true)
+
+			return w
+		}},
+		{"table", func() orvyn.Renderable {
+			price := table.NewColumn("Price", layout.AutoTrack(), func(r [2]string) string { return r[1] })
+			price.Align = lipgloss.Right
+
+			w := table.New(
+				table.NewColumn("Item", layout.FractionTrack(1), func(r [2]string) string { return r[0] }),
+				price)
+			w.SetRows([][2]string{{"apple", "1.20"}, {"pear", "0.80"}, {"watermelon", "12.00"}})
+			w.SortBy(0, false)
+			w.MoveCursor(1)
+
+			return w
+		}},
+		{"helpbar", func() orvyn.Renderable {
+			w := helpbar.New()
+			w.SetKeyMap(checkbox.New("Checkbox"))
+
+			return w
+		}},
+		{"tabs", func() orvyn.Renderable {
+			w := tabs.New(
+				tabs.NewTab("General", layout.NewMaxWidthVBoxLayout(0, label.New("General page")), nil),
+				tabs.NewTab("Advanced", layout.NewMaxWidthVBoxLayout(0, label.New("Advanced page")), nil))
+			w.NextTab()
+
+			return w
+		}},
+		{"form", func() orvyn.Renderable {
+			w := form.New[struct{}](nil,
+				form.Text("", "Name").Validate(form.Required()),
+				form.Select("", "Role", form.NewOption("Admin", 1), form.NewOption("User", 2)),
+				form.Checkbox("", "Active"))
+			w.Init()
+			w.Submit()
+
+			return w
+		}},
+		{"radio", func() orvyn.Renderable {
+			w := radio.New("Small", "Medium", "Large")
+			w.SetSelected(1)
+
+			return w
+		}},
+		{"radio_horizontal", func() orvyn.Renderable {
+			return radio.NewHorizontal("Yes", "No")
+		}},
+		{"toggle", func() orvyn.Renderable {
+			w := toggle.New("Notifications")
+			w.SetOn(true)
+
+			return w
+		}},
+		{"selectbox", func() orvyn.Renderable {
+			w := selectbox.New("Small", "Medium", "Large")
+			w.SetSelectedIndex(1)
+
+			return w
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orvyn.Init()
+
+			orvyntest.AssertSnapshot(t, test.name, test.widget())
+		})
+	}
+}
+
+// The theme colors are part of the rendering too: one snapshot keeps them.
+func TestWidgetSnapshotANSI(t *testing.T) {
+	orvyn.Init()
+
+	w := checkbox.New("Checked")
+	w.SetChecked(true)
+	w.OnFocus()
+
+	orvyntest.AssertSnapshotANSI(t, "checkbox_ansi", w, orvyntest.NormalSize)
+}
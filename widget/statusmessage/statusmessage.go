@@ -43,6 +43,14 @@ func (w *Widget) Init() tea.Cmd {
 	return nil
 }
 
+func (w *Widget) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.updateStyle()
+	}
+
+	return nil
+}
+
 func (w *Widget) Render() string {
 	size := w.GetContentSize()
 
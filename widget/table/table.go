@@ -0,0 +1,896 @@
+package table
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// Actions of the table widget, see orvyn.Bindings.
+const (
+	CursorUpAction     orvyn.ActionID = "table.cursor_up"
+	CursorDownAction   orvyn.ActionID = "table.cursor_down"
+	PageUpAction       orvyn.ActionID = "table.page_up"
+	PageDownAction     orvyn.ActionID = "table.page_down"
+	HomeAction         orvyn.ActionID = "table.home"
+	EndAction          orvyn.ActionID = "table.end"
+	ScrollLeftAction   orvyn.ActionID = "table.scroll_left"
+	ScrollRightAction  orvyn.ActionID = "table.scroll_right"
+	SortAction         orvyn.ActionID = "table.sort"
+	ReverseSortAction  orvyn.ActionID = "table.reverse_sort"
+	ToggleSelectAction orvyn.ActionID = "table.toggle_select"
+	SelectAllAction    orvyn.ActionID = "table.select_all"
+)
+
+func init() {
+	orvyn.DefineAction(CursorUpAction,
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"))
+	orvyn.DefineAction(CursorDownAction,
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"))
+	orvyn.DefineAction(PageUpAction,
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "page up"))
+	orvyn.DefineAction(PageDownAction,
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdown", "page down"))
+	orvyn.DefineAction(HomeAction,
+		key.WithKeys("home", "g"),
+		key.WithHelp("home/g", "first row"))
+	orvyn.DefineAction(EndAction,
+		key.WithKeys("end", "G"),
+		key.WithHelp("end/G", "last row"))
+	orvyn.DefineAction(ScrollLeftAction,
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "scroll left"))
+	orvyn.DefineAction(ScrollRightAction,
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "scroll right"))
+	orvyn.DefineAction(SortAction,
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort by next column"))
+	orvyn.DefineAction(ReverseSortAction,
+		key.WithKeys("S"),
+		key.WithHelp("S", "reverse sort"))
+	orvyn.DefineAction(ToggleSelectAction,
+		key.WithKeys(" "),
+		key.WithHelp("space", "select"))
+	orvyn.DefineAction(SelectAllAction,
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("ctrl+a", "select all"))
+}
+
+// Column defines a column of the table.
+// T type represents the type of the row data.
+type Column[T any] struct {
+	// Title is shown in the header row.
+	Title string
+
+	// Width is a fixed width, a fraction of the width left by the other
+	// columns, or the width of the widest cell, see layout.Track.
+	Width layout.Track
+
+	// Value returns the text of the cell of the column for a row.
+	Value func(T) string
+
+	// Align is the horizontal alignment of the cells. lipgloss.Left by default.
+	Align lipgloss.Position
+
+	// Less reports whether row a sorts before row b. When nil, the cell
+	// values are compared.
+	Less func(a, b T) bool
+}
+
+// NewColumn creates and returns a new left aligned Column.
+func NewColumn[T any](title string, width layout.Track, value func(T) string) Column[T] {
+	return Column[T]{
+		Title: title,
+		Width: width,
+		Value: value,
+		Align: lipgloss.Left,
+	}
+}
+
+const (
+	// sortIndicatorWidth is the room kept after the titles for the sort arrow.
+	sortIndicatorWidth = 2
+
+	// markerWidth is the width of the selection marker in multi-select mode.
+	markerWidth = 2
+)
+
+// Widget is a table showing one row per data item. Only the visible rows are
+// rendered, so the table holds large data sets.
+// T type represents the type of the row data.
+type Widget[T any] struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	// CursorUpKeybind moves the cursor one row up. up and k by default.
+	CursorUpKeybind key.Binding
+
+	// CursorDownKeybind moves the cursor one row down. down and j by default.
+	CursorDownKeybind key.Binding
+
+	// PageUpKeybind moves the cursor one page up. pgup by default.
+	PageUpKeybind key.Binding
+
+	// PageDownKeybind moves the cursor one page down. pgdown by default.
+	PageDownKeybind key.Binding
+
+	// HomeKeybind moves the cursor to the first row. home and g by default.
+	HomeKeybind key.Binding
+
+	// EndKeybind moves the cursor to the last row. end and G by default.
+	EndKeybind key.Binding
+
+	// ScrollLeftKeybind scrolls a wide table left. left and h by default.
+	ScrollLeftKeybind key.Binding
+
+	// ScrollRightKeybind scrolls a wide table right. right and l by default.
+	ScrollRightKeybind key.Binding
+
+	// SortKeybind sorts by the next column. s by default.
+	SortKeybind key.Binding
+
+	// ReverseSortKeybind reverses the sort order. S by default.
+	ReverseSortKeybind key.Binding
+
+	// ToggleSelectKeybind toggles the selection of the cursor row in
+	// multi-select mode. space by default.
+	ToggleSelectKeybind key.Binding
+
+	// SelectAllKeybind selects every row in multi-select mode, or clears the
+	// selection when every row is already selected. ctrl+a by default.
+	SelectAllKeybind key.Binding
+
+	// ColumnGap is the space between two columns. 1 by default.
+	ColumnGap int
+
+	// ScrollStep is the number of cells scrolled horizontally. 4 by default.
+	ScrollStep int
+
+	// MultiSelect allows selecting several rows.
+	MultiSelect bool
+
+	// CursorMovedCallback is called with the row index under the cursor.
+	CursorMovedCallback func(int)
+
+	// SelectionChangedCallback is called when the multi-selection changes.
+	SelectionChangedCallback func()
+
+	columns []Column[T]
+	rows    []T
+
+	// order holds the row indexes in display order.
+	order []int
+
+	// cursor and offset are positions in order.
+	cursor int
+	offset int
+
+	xOffset int
+
+	sortColumn     int
+	sortDescending bool
+
+	selected map[int]struct{}
+
+	// autoWidths caches the content width of every column.
+	autoWidths []int
+
+	// preferredSet is true once SetPreferredSize was called, the preferred
+	// size then no longer following the content.
+	preferredSet bool
+}
+
+// New creates and returns a new table *Widget with the given columns.
+// T type represents the type of the row data.
+func New[T any](columns ...Column[T]) *Widget[T] {
+	w := new(Widget[T])
+
+	w.BaseWidget = orvyn.NewBaseWidget()
+	w.BaseFocusable = orvyn.NewBaseFocusable(w)
+
+	w.CursorUpKeybind = orvyn.Binding(CursorUpAction)
+	w.CursorDownKeybind = orvyn.Binding(CursorDownAction)
+	w.PageUpKeybind = orvyn.Binding(PageUpAction)
+	w.PageDownKeybind = orvyn.Binding(PageDownAction)
+	w.HomeKeybind = orvyn.Binding(HomeAction)
+	w.EndKeybind = orvyn.Binding(EndAction)
+	w.ScrollLeftKeybind = orvyn.Binding(ScrollLeftAction)
+	w.ScrollRightKeybind = orvyn.Binding(ScrollRightAction)
+	w.SortKeybind = orvyn.Binding(SortAction)
+	w.ReverseSortKeybind = orvyn.Binding(ReverseSortAction)
+	w.ToggleSelectKeybind = orvyn.Binding(ToggleSelectAction)
+	w.SelectAllKeybind = orvyn.Binding(SelectAllAction)
+
+	w.ColumnGap = 1
+	w.ScrollStep = 4
+	w.MultiSelect = false
+
+	w.columns = columns
+	w.sortColumn = -1
+	w.selected = make(map[int]struct{})
+
+	w.BaseRenderable.SetMinSize(orvyn.NewSize(10, 5))
+
+	w.OnBlur()
+
+	return w
+}
+
+func (w *Widget[T]) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+
+		return nil
+	}
+
+	if m, ok := msg.(orvyn.MouseMsg); ok {
+		w.updateMouse(m)
+		return nil
+	}
+
+	m, ok := orvyn.GetKeyMsg(msg)
+
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case key.Matches(m, w.CursorUpKeybind):
+		w.MoveCursor(-1)
+
+	case key.Matches(m, w.CursorDownKeybind):
+		w.MoveCursor(1)
+
+	case key.Matches(m, w.PageUpKeybind):
+		w.MoveCursor(-w.bodyHeight())
+
+	case key.Matches(m, w.PageDownKeybind):
+		w.MoveCursor(w.bodyHeight())
+
+	case key.Matches(m, w.HomeKeybind):
+		w.SetCursor(0)
+
+	case key.Matches(m, w.EndKeybind):
+		w.SetCursor(len(w.order) - 1)
+
+	case key.Matches(m, w.ScrollLeftKeybind):
+		w.ScrollBy(-w.ScrollStep)
+
+	case key.Matches(m, w.ScrollRightKeybind):
+		w.ScrollBy(w.ScrollStep)
+
+	case key.Matches(m, w.SortKeybind):
+		w.SortBy((w.sortColumn+1)%max(len(w.columns), 1), w.sortDescending)
+
+	case key.Matches(m, w.ReverseSortKeybind):
+		if w.sortColumn >= 0 {
+			w.SortBy(w.sortColumn, !w.sortDescending)
+		}
+
+	case key.Matches(m, w.ToggleSelectKeybind):
+		if w.MultiSelect {
+			if index, ok := w.GetCursorIndex(); ok {
+				w.SetSelected(index, !w.IsSelected(index))
+			}
+		}
+
+	case key.Matches(m, w.SelectAllKeybind):
+		if w.MultiSelect {
+			if len(w.selected) == len(w.rows) {
+				w.ClearSelection()
+			} else {
+				w.SelectAll()
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *Widget[T]) Render() string {
+	contentSize := w.GetContentSize()
+
+	widths := w.columnWidths(contentSize.Width)
+
+	t := orvyn.GetTheme()
+	headerStyle := t.Style(theme.TableHeaderStyleID)
+	rowStyle := t.Style(theme.NormalTextStyleID)
+	cursorStyle := t.Style(theme.TableCursorStyleID)
+	selectedStyle := t.Style(theme.TableSelectedStyleID)
+
+	w.clampXOffset(widths, contentSize.Width)
+
+	lines := make([]string, 0, contentSize.Height)
+
+	lines = append(lines, w.renderLine(headerStyle, w.headerLine(widths), contentSize.Width))
+
+	bodyHeight := w.bodyHeight()
+	w.clampOffset()
+
+	end := min(w.offset+bodyHeight, len(w.order))
+
+	for i := w.offset; i < end; i++ {
+		index := w.order[i]
+		style := rowStyle
+
+		if w.IsSelected(index) {
+			style = selectedStyle
+		}
+
+		if i == w.cursor {
+			style = cursorStyle
+		}
+
+		lines = append(lines, w.renderLine(style, w.rowLine(index, widths), contentSize.Width))
+	}
+
+	view := lipgloss.NewStyle().
+		Width(contentSize.Width).
+		Height(contentSize.Height).
+		MaxHeight(contentSize.Height).
+		Render(strings.Join(lines, "\n"))
+
+	return w.GetStyle().Render(view)
+}
+
+// SetPreferredSize sets the preferred size, replacing the one fitting the
+// content.
+func (w *Widget[T]) SetPreferredSize(size orvyn.Size) {
+	w.BaseRenderable.SetPreferredSize(size)
+	w.preferredSet = true
+}
+
+// GetPreferredSize returns the size set with SetPreferredSize, otherwise the
+// size fitting the columns and up to 10 rows.
+func (w *Widget[T]) GetPreferredSize() orvyn.Size {
+	if w.preferredSet {
+		return w.BaseRenderable.GetPreferredSize()
+	}
+
+	widths := w.columnWidths(0)
+	style := w.GetStyle()
+	headerStyle := orvyn.GetTheme().Style(theme.TableHeaderStyleID)
+
+	return orvyn.NewSize(
+		w.lineWidth(widths)+style.GetHorizontalFrameSize(),
+		min(len(w.rows), 10)+1+headerStyle.GetVerticalFrameSize()+style.GetVerticalFrameSize())
+}
+
+// ShortHelp implements orvyn.KeyMap.
+func (w *Widget[T]) ShortHelp() []key.Binding {
+	bindings := []key.Binding{w.CursorUpKeybind, w.CursorDownKeybind, w.SortKeybind}
+
+	if w.MultiSelect {
+		bindings = append(bindings, w.ToggleSelectKeybind)
+	}
+
+	return bindings
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (w *Widget[T]) FullHelp() [][]key.Binding {
+	columns := [][]key.Binding{
+		{w.CursorUpKeybind, w.CursorDownKeybind, w.PageUpKeybind, w.PageDownKeybind,
+			w.HomeKeybind, w.EndKeybind},
+		{w.ScrollLeftKeybind, w.ScrollRightKeybind, w.SortKeybind, w.ReverseSortKeybind},
+	}
+
+	if w.MultiSelect {
+		columns = append(columns, []key.Binding{w.ToggleSelectKeybind, w.SelectAllKeybind})
+	}
+
+	return columns
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (w *Widget[T]) KeyMapTitle() string {
+	return "Table"
+}
+
+// Public API
+
+// SetColumns replaces the columns of the table. The sort is cleared.
+func (w *Widget[T]) SetColumns(columns ...Column[T]) {
+	w.columns = columns
+	w.autoWidths = nil
+	w.xOffset = 0
+
+	w.ClearSort()
+}
+
+// GetColumns returns the columns of the table.
+func (w *Widget[T]) GetColumns() []Column[T] {
+	return w.columns
+}
+
+// SetRows replaces the rows of the table. The current sort is applied to the
+// new rows, and the selection is cleared.
+func (w *Widget[T]) SetRows(rows []T) {
+	w.rows = rows
+	w.autoWidths = nil
+
+	w.order = make([]int, len(rows))
+
+	for i := range w.order {
+		w.order[i] = i
+	}
+
+	clear(w.selected)
+
+	w.sortRows()
+
+	w.cursor = min(w.cursor, len(w.order)-1)
+	w.cursor = max(w.cursor, 0)
+	w.clampOffset()
+}
+
+// GetRows returns the rows of the table, in the order they were given.
+func (w *Widget[T]) GetRows() []T {
+	return w.rows
+}
+
+// UpdateRow replaces the data of the row at the given index. The sort is not
+// applied again, call SortBy to do so.
+func (w *Widget[T]) UpdateRow(index int, row T) {
+	if index < 0 || index >= len(w.rows) {
+		return
+	}
+
+	w.rows[index] = row
+	w.autoWidths = nil
+}
+
+// GetCursorIndex returns the index of the row under the cursor, false when
+// the table is empty.
+func (w *Widget[T]) GetCursorIndex() (int, bool) {
+	if w.cursor < 0 || w.cursor >= len(w.order) {
+		return 0, false
+	}
+
+	return w.order[w.cursor], true
+}
+
+// GetCursorRow returns the row under the cursor, false when the table is empty.
+func (w *Widget[T]) GetCursorRow() (T, bool) {
+	index, ok := w.GetCursorIndex()
+
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	return w.rows[index], true
+}
+
+// SetCursor moves the cursor to the given position in the displayed rows.
+func (w *Widget[T]) SetCursor(position int) {
+	if len(w.order) == 0 {
+		return
+	}
+
+	position = min(position, len(w.order)-1)
+	position = max(position, 0)
+
+	if position == w.cursor {
+		return
+	}
+
+	w.cursor = position
+	w.clampOffset()
+
+	if w.CursorMovedCallback != nil {
+		w.CursorMovedCallback(w.order[w.cursor])
+	}
+}
+
+// MoveCursor moves the cursor by the given number of rows, negative to move up.
+func (w *Widget[T]) MoveCursor(delta int) {
+	w.SetCursor(w.cursor + delta)
+}
+
+// GetCursor returns the position of the cursor in the displayed rows.
+func (w *Widget[T]) GetCursor() int {
+	return w.cursor
+}
+
+// SortBy sorts the rows by the given column. The cursor stays on its row.
+func (w *Widget[T]) SortBy(column int, descending bool) {
+	if column < 0 || column >= len(w.columns) {
+		return
+	}
+
+	index, ok := w.GetCursorIndex()
+
+	w.sortColumn = column
+	w.sortDescending = descending
+
+	w.sortRows()
+
+	if ok {
+		w.cursor = slices.Index(w.order, index)
+		w.clampOffset()
+	}
+}
+
+// ClearSort gives the rows back their original order.
+func (w *Widget[T]) ClearSort() {
+	index, ok := w.GetCursorIndex()
+
+	w.sortColumn = -1
+	w.sortDescending = false
+
+	slices.Sort(w.order)
+
+	if ok {
+		w.cursor = index
+		w.clampOffset()
+	}
+}
+
+// GetSort returns the sort column, -1 when unsorted, and the sort direction.
+func (w *Widget[T]) GetSort() (int, bool) {
+	return w.sortColumn, w.sortDescending
+}
+
+// ScrollBy scrolls a wide table horizontally by the given number of cells,
+// negative to scroll left.
+func (w *Widget[T]) ScrollBy(cells int) {
+	w.xOffset = max(w.xOffset+cells, 0)
+}
+
+// GetXOffset returns the first column of cells shown.
+func (w *Widget[T]) GetXOffset() int {
+	return w.xOffset
+}
+
+// IsSelected returns true if the row at the given index is selected.
+func (w *Widget[T]) IsSelected(index int) bool {
+	_, ok := w.selected[index]
+
+	return ok
+}
+
+// SetSelected selects or deselects the row at the given index.
+func (w *Widget[T]) SetSelected(index int, selected bool) {
+	if index < 0 || index >= len(w.rows) || w.IsSelected(index) == selected {
+		return
+	}
+
+	if selected {
+		w.selected[index] = struct{}{}
+	} else {
+		delete(w.selected, index)
+	}
+
+	w.selectionChanged()
+}
+
+// SelectAll selects every row.
+func (w *Widget[T]) SelectAll() {
+	for i := range w.rows {
+		w.selected[i] = struct{}{}
+	}
+
+	w.selectionChanged()
+}
+
+// ClearSelection deselects every row.
+func (w *Widget[T]) ClearSelection() {
+	clear(w.selected)
+
+	w.selectionChanged()
+}
+
+// GetSelectedIndexes returns the indexes of the selected rows, ascending.
+// Without MultiSelect, it returns the index of the cursor row.
+func (w *Widget[T]) GetSelectedIndexes() []int {
+	if !w.MultiSelect {
+		if index, ok := w.GetCursorIndex(); ok {
+			return []int{index}
+		}
+
+		return nil
+	}
+
+	indexes := make([]int, 0, len(w.selected))
+
+	for index := range w.selected {
+		indexes = append(indexes, index)
+	}
+
+	slices.Sort(indexes)
+
+	return indexes
+}
+
+// GetSelectedRows returns the selected rows, see GetSelectedIndexes.
+func (w *Widget[T]) GetSelectedRows() []T {
+	indexes := w.GetSelectedIndexes()
+	rows := make([]T, 0, len(indexes))
+
+	for _, index := range indexes {
+		rows = append(rows, w.rows[index])
+	}
+
+	return rows
+}
+
+// Hidden functions
+
+// updateMouse moves the cursor to the clicked row, or by one row per wheel step.
+func (w *Widget[T]) updateMouse(msg orvyn.MouseMsg) {
+	if !msg.IsOver(w) {
+		return
+	}
+
+	switch {
+	case msg.IsWheelUp():
+		w.MoveCursor(-1)
+
+	case msg.IsWheelDown():
+		w.MoveCursor(1)
+
+	case msg.IsLeftClick():
+		local, ok := msg.Local(w)
+
+		if !ok {
+			return
+		}
+
+		style := w.GetStyle()
+		headerStyle := orvyn.GetTheme().Style(theme.TableHeaderStyleID)
+
+		row := local.Y - style.GetBorderTopSize() - style.GetPaddingTop() - style.GetMarginTop() -
+			1 - headerStyle.GetVerticalFrameSize()
+
+		if row >= 0 && row < w.bodyHeight() && w.offset+row < len(w.order) {
+			w.SetCursor(w.offset + row)
+		}
+	}
+}
+
+func (w *Widget[T]) selectionChanged() {
+	if w.SelectionChangedCallback != nil {
+		w.SelectionChangedCallback()
+	}
+}
+
+// sortRows sorts the display order by the sort column, keeping the original
+// order between equal rows.
+func (w *Widget[T]) sortRows() {
+	if w.sortColumn < 0 || w.sortColumn >= len(w.columns) {
+		return
+	}
+
+	column := w.columns[w.sortColumn]
+
+	compare := func(a, b int) int {
+		if column.Less != nil {
+			switch {
+			case column.Less(w.rows[a], w.rows[b]):
+				return -1
+			case column.Less(w.rows[b], w.rows[a]):
+				return 1
+			}
+
+			return 0
+		}
+
+		return cmp.Compare(column.Value(w.rows[a]), column.Value(w.rows[b]))
+	}
+
+	slices.SortStableFunc(w.order, func(a, b int) int {
+		if w.sortDescending {
+			return compare(b, a)
+		}
+
+		return compare(a, b)
+	})
+}
+
+// bodyHeight returns the number of rows fitting under the header.
+func (w *Widget[T]) bodyHeight() int {
+	headerStyle := orvyn.GetTheme().Style(theme.TableHeaderStyleID)
+
+	return max(w.GetContentSize().Height-1-headerStyle.GetVerticalFrameSize(), 1)
+}
+
+// clampOffset keeps the cursor row inside the visible rows.
+func (w *Widget[T]) clampOffset() {
+	height := w.bodyHeight()
+
+	w.offset = min(w.offset, w.cursor)
+	w.offset = max(w.offset, w.cursor-height+1)
+	w.offset = min(w.offset, len(w.order)-height)
+	w.offset = max(w.offset, 0)
+}
+
+// clampXOffset keeps the horizontal scrolling inside the table width.
+func (w *Widget[T]) clampXOffset(widths []int, viewWidth int) {
+	w.xOffset = min(w.xOffset, w.lineWidth(widths)-viewWidth)
+	w.xOffset = max(w.xOffset, 0)
+}
+
+// contentWidths returns the width of the widest cell of every column, title
+// and sort indicator included. Every row is measured, so the result is cached
+// until the rows or the columns change.
+func (w *Widget[T]) contentWidths() []int {
+	if w.autoWidths != nil {
+		return w.autoWidths
+	}
+
+	w.autoWidths = make([]int, len(w.columns))
+
+	for i, c := range w.columns {
+		width := lipgloss.Width(c.Title) + sortIndicatorWidth
+
+		if c.Value != nil {
+			for _, row := range w.rows {
+				width = max(width, lipgloss.Width(c.Value(row)))
+			}
+		}
+
+		w.autoWidths[i] = width
+	}
+
+	return w.autoWidths
+}
+
+// columnWidths computes the width of every column within the available
+// width. Fixed and auto columns are served first and fraction columns share
+// what is left, never narrower than their title. When the columns do not fit,
+// the table is wider than the view and scrolls horizontally.
+func (w *Widget[T]) columnWidths(available int) []int {
+	contentWidths := w.contentWidths()
+	widths := make([]int, len(w.columns))
+
+	if w.MultiSelect {
+		available -= markerWidth
+	}
+
+	remaining := available - w.ColumnGap*max(len(w.columns)-1, 0)
+	fractionTotal, lastFraction := 0, -1
+
+	for i, c := range w.columns {
+		switch c.Width.Kind {
+		case layout.TrackFixed:
+			widths[i] = c.Width.Value
+			remaining -= widths[i]
+
+		case layout.TrackAuto:
+			widths[i] = contentWidths[i]
+			remaining -= widths[i]
+
+		case layout.TrackFraction:
+			fractionTotal += c.Width.Value
+			lastFraction = i
+		}
+	}
+
+	remaining = max(remaining, 0)
+	left := remaining
+
+	for i, c := range w.columns {
+		if c.Width.Kind != layout.TrackFraction {
+			continue
+		}
+
+		if i == lastFraction {
+			widths[i] = left
+		} else if fractionTotal > 0 {
+			widths[i] = int(math.Floor(
+				float64(remaining) * float64(c.Width.Value) / float64(fractionTotal)))
+		}
+
+		left -= widths[i]
+
+		widths[i] = max(widths[i], lipgloss.Width(c.Title)+sortIndicatorWidth)
+	}
+
+	return widths
+}
+
+// lineWidth returns the width of a full table line.
+func (w *Widget[T]) lineWidth(widths []int) int {
+	width := w.ColumnGap * max(len(widths)-1, 0)
+
+	for _, cw := range widths {
+		width += cw
+	}
+
+	if w.MultiSelect {
+		width += markerWidth
+	}
+
+	return width
+}
+
+// headerLine returns the plain text of the header row.
+func (w *Widget[T]) headerLine(widths []int) string {
+	cells := make([]string, len(w.columns))
+
+	for i, c := range w.columns {
+		title := c.Title
+
+		if i == w.sortColumn {
+			if w.sortDescending {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+
+		cells[i] = alignCell(title, widths[i], c.Align)
+	}
+
+	marker := ""
+
+	if w.MultiSelect {
+		marker = strings.Repeat(" ", markerWidth)
+	}
+
+	return marker + strings.Join(cells, strings.Repeat(" ", w.ColumnGap))
+}
+
+// rowLine returns the plain text of the row at the given index.
+func (w *Widget[T]) rowLine(index int, widths []int) string {
+	cells := make([]string, len(w.columns))
+
+	for i, c := range w.columns {
+		value := ""
+
+		if c.Value != nil {
+			value = c.Value(w.rows[index])
+		}
+
+		cells[i] = alignCell(value, widths[i], c.Align)
+	}
+
+	marker := ""
+
+	if w.MultiSelect {
+		marker = strings.Repeat(" ", markerWidth)
+
+		if w.IsSelected(index) {
+			marker = "✓ "
+		}
+	}
+
+	return marker + strings.Join(cells, strings.Repeat(" ", w.ColumnGap))
+}
+
+// renderLine cuts the horizontally scrolled part of the line that fits the
+// view and styles it.
+func (w *Widget[T]) renderLine(style lipgloss.Style, line string, viewWidth int) string {
+	width := max(viewWidth-style.GetHorizontalFrameSize(), 0)
+	line = ansi.Cut(line, w.xOffset, w.xOffset+width)
+
+	return style.Width(width).MaxWidth(viewWidth).Render(line)
+}
+
+// alignCell truncates the value to the width and pads it following align.
+func alignCell(value string, width int, align lipgloss.Position) string {
+	if width <= 0 {
+		return ""
+	}
+
+	value = ansi.Truncate(value, width, "…")
+	space := width - lipgloss.Width(value)
+	left := int(math.Round(float64(space) * float64(align)))
+
+	return strings.Repeat(" ", left) + value + strings.Repeat(" ", space-left)
+}
@@ -0,0 +1,235 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func newTestTable(t *testing.T, rows []person, size orvyn.Size) *Widget[person] {
+	t.Helper()
+
+	orvyn.Init()
+
+	age := NewColumn("Age", layout.FixedTrack(5), func(p person) string {
+		return fmt.Sprint(p.age)
+	})
+	age.Less = func(a, b person) bool { return a.age < b.age }
+
+	w := New(
+		NewColumn("Name", layout.FractionTrack(1), func(p person) string { return p.name }),
+		age)
+	w.Resize(size)
+	w.SetRows(rows)
+
+	return w
+}
+
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case "pgdown":
+		return tea.KeyMsg{Type: tea.KeyPgDown}
+	case "ctrl+a":
+		return tea.KeyMsg{Type: tea.KeyCtrlA}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "space":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	}
+
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func TestTableSortKeepsCursorRow(t *testing.T) {
+	w := newTestTable(t, []person{{"carol", 41}, {"alice", 30}, {"bob", 25}}, orvyn.NewSize(30, 10))
+
+	w.SetCursor(1) // alice
+
+	w.Update(keyMsg("s"))
+
+	if col, desc := w.GetSort(); col != 0 || desc {
+		t.Fatalf("sort = (%d, %v), want (0, false)", col, desc)
+	}
+
+	if row, _ := w.GetCursorRow(); row.name != "alice" || w.GetCursor() != 0 {
+		t.Errorf("cursor on %q at %d, want alice at 0", row.name, w.GetCursor())
+	}
+
+	w.Update(keyMsg("s"))
+	w.Update(keyMsg("S"))
+
+	want := []string{"carol", "alice", "bob"}
+
+	for i, index := range w.order {
+		if got := w.rows[index].name; got != want[i] {
+			t.Fatalf("row %d = %q, want %q (sorted by age, descending)", i, got, want[i])
+		}
+	}
+
+	if row, _ := w.GetCursorRow(); row.name != "alice" {
+		t.Errorf("cursor on %q, want alice", row.name)
+	}
+
+	w.ClearSort()
+
+	if row, _ := w.GetCursorRow(); row.name != "alice" || w.GetCursor() != 1 {
+		t.Errorf("after ClearSort, cursor on %q at %d, want alice at 1", row.name, w.GetCursor())
+	}
+}
+
+// Only the rows fitting the view are rendered, whatever the number of rows.
+func TestTableRendersVisibleRows(t *testing.T) {
+	rows := make([]person, 10000)
+
+	for i := range rows {
+		rows[i] = person{fmt.Sprintf("person %d", i), i % 90}
+	}
+
+	w := newTestTable(t, rows, orvyn.NewSize(30, 10))
+
+	w.Update(keyMsg("pgdown"))
+	w.Update(keyMsg("pgdown"))
+
+	lines := strings.Split(ansi.Strip(w.Render()), "\n")
+
+	if len(lines) != 10 {
+		t.Fatalf("rendered %d lines, want 10", len(lines))
+	}
+
+	// Border, header, header underline, then the rows.
+	body := lines[3 : len(lines)-1]
+
+	if len(body) != w.bodyHeight() {
+		t.Fatalf("rendered %d rows, want %d", len(body), w.bodyHeight())
+	}
+
+	last := body[len(body)-1]
+
+	if !strings.Contains(last, fmt.Sprintf("person %d", w.GetCursor())) {
+		t.Errorf("last visible row %q is not the cursor row %d", last, w.GetCursor())
+	}
+}
+
+func TestTableColumnWidths(t *testing.T) {
+	orvyn.Init()
+
+	w := New(
+		NewColumn("A", layout.FixedTrack(4), func(s string) string { return s }),
+		NewColumn("B", layout.AutoTrack(), func(s string) string { return s }),
+		NewColumn("C", layout.FractionTrack(1), func(s string) string { return s }),
+		NewColumn("D", layout.FractionTrack(2), func(s string) string { return s }))
+	w.SetRows([]string{"a long value"})
+
+	got := w.columnWidths(40)
+	want := []int{4, 12, 7, 14}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("widths = %v, want %v", got, want)
+		}
+	}
+
+	// Out of space, the fractions keep their title and the table scrolls.
+	got = w.columnWidths(10)
+
+	if got[2] != 3 || got[3] != 3 {
+		t.Errorf("squeezed fraction widths = %v, want 3 for C and D", got)
+	}
+}
+
+func TestTableHorizontalScroll(t *testing.T) {
+	orvyn.Init()
+
+	w := New(
+		NewColumn("First", layout.FixedTrack(20), func(s string) string { return "left" }),
+		NewColumn("Second", layout.FixedTrack(20), func(s string) string { return "right" }))
+	w.Resize(orvyn.NewSize(24, 6))
+	w.SetRows([]string{"row"})
+
+	if view := ansi.Strip(w.Render()); strings.Contains(view, "right") {
+		t.Fatalf("second column visible before scrolling:\n%s", view)
+	}
+
+	for range 10 {
+		w.Update(keyMsg("right"))
+	}
+
+	view := ansi.Strip(w.Render())
+
+	if !strings.Contains(view, "right") || strings.Contains(view, "left") {
+		t.Errorf("after scrolling right, want only the second column:\n%s", view)
+	}
+
+	if w.GetXOffset() != 41-22 {
+		t.Errorf("x offset = %d, want %d (clamped to the table width)", w.GetXOffset(), 41-22)
+	}
+}
+
+func TestTableMultiSelect(t *testing.T) {
+	w := newTestTable(t, []person{{"carol", 41}, {"alice", 30}, {"bob", 25}}, orvyn.NewSize(30, 10))
+
+	changes := 0
+	w.SelectionChangedCallback = func() { changes++ }
+
+	w.Update(keyMsg("space"))
+
+	if len(w.GetSelectedIndexes()) != 1 {
+		t.Fatalf("without MultiSelect, the selection is the cursor row")
+	}
+
+	if changes != 0 {
+		t.Fatalf("space selected a row without MultiSelect")
+	}
+
+	w.MultiSelect = true
+
+	w.Update(keyMsg("space"))
+	w.Update(keyMsg("j"))
+	w.Update(keyMsg("j"))
+	w.Update(keyMsg("space"))
+
+	if got := w.GetSelectedIndexes(); len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Errorf("selected = %v, want [0 2]", got)
+	}
+
+	w.Update(keyMsg("ctrl+a"))
+
+	if got := w.GetSelectedRows(); len(got) != 3 {
+		t.Errorf("after select all, %d rows selected, want 3", len(got))
+	}
+
+	w.Update(keyMsg("ctrl+a"))
+
+	if got := w.GetSelectedRows(); len(got) != 0 {
+		t.Errorf("select all on a full selection left %d rows selected, want 0", len(got))
+	}
+
+	if changes != 4 {
+		t.Errorf("selection changed %d times, want 4", changes)
+	}
+}
+
+func TestTablePreferredSize(t *testing.T) {
+	w := newTestTable(t, []person{{"carol", 41}, {"alice", 30}, {"bob", 25}}, orvyn.NewSize(30, 10))
+
+	if got := w.GetPreferredSize(); got == orvyn.NewSize(1, 1) {
+		t.Fatalf("preferred size %v, want the size fitting the content", got)
+	}
+
+	// An explicit 1x1 is kept, not taken for the default.
+	w.SetPreferredSize(orvyn.NewSize(1, 1))
+
+	if got := w.GetPreferredSize(); got != orvyn.NewSize(1, 1) {
+		t.Errorf("preferred size %v, want the 1x1 set", got)
+	}
+}
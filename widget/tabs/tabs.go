@@ -0,0 +1,449 @@
+package tabs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// Actions of the tabs widget, see orvyn.Bindings.
+const (
+	NextTabAction     orvyn.ActionID = "tabs.next"
+	PreviousTabAction orvyn.ActionID = "tabs.previous"
+)
+
+func init() {
+	orvyn.DefineAction(NextTabAction,
+		key.WithKeys("ctrl+pgdown"),
+		key.WithHelp("ctrl+pgdown", "next tab"))
+	orvyn.DefineAction(PreviousTabAction,
+		key.WithKeys("ctrl+pgup"),
+		key.WithHelp("ctrl+pgup", "previous tab"))
+}
+
+// Tab is a page of the tabs widget, with its own layout and focus manager.
+type Tab struct {
+	// Title is shown in the tab bar.
+	Title string
+
+	// Layout is rendered when the tab is the active one.
+	Layout orvyn.Layout
+
+	// FocusManager receives the messages when the tab is the active one.
+	// Can be nil for a tab without focusable widgets.
+	FocusManager *orvyn.FocusManager
+
+	// FocusKeybind activates the tab directly when defined.
+	FocusKeybind *key.Binding
+
+	// OnEnter is called when the tab becomes the active one. Can be nil.
+	OnEnter func() tea.Cmd
+
+	// OnExit is called when the tab stops being the active one. Can be nil.
+	OnExit func()
+}
+
+// NewTab creates and returns a new *Tab.
+func NewTab(title string, layout orvyn.Layout, focusManager *orvyn.FocusManager) *Tab {
+	return &Tab{
+		Title:        title,
+		Layout:       layout,
+		FocusManager: focusManager,
+	}
+}
+
+// Widget shows a tab bar and the layout of the active tab. The messages are
+// given to the focus manager of the active tab, except the ones switching tab.
+type Widget struct {
+	orvyn.BaseWidget
+
+	// NextTabKeybind activates the next tab. ctrl+pgdown by default.
+	NextTabKeybind key.Binding
+
+	// PreviousTabKeybind activates the previous tab. ctrl+pgup by default.
+	PreviousTabKeybind key.Binding
+
+	// NumberKeybinds activates the tabs with the keys 1 to 9, when the active
+	// tab is not in input mode. True by default.
+	NumberKeybinds bool
+
+	// InfiniteScroll loops from the last tab to the first one and back.
+	// True by default.
+	InfiniteScroll bool
+
+	// TabChangedCallback is called with the index of the new active tab.
+	TabChangedCallback func(int)
+
+	tabs      []*Tab
+	activeTab int
+
+	// barHeight is the height of the tab bar as of the last Render.
+	barHeight int
+}
+
+// New creates and returns a new tabs *Widget holding the given tabs.
+func New(tabs ...*Tab) *Widget {
+	w := new(Widget)
+
+	w.BaseWidget = orvyn.NewBaseWidget()
+	w.BaseWidget.SetStyle(lipgloss.NewStyle())
+
+	w.NextTabKeybind = orvyn.Binding(NextTabAction)
+	w.PreviousTabKeybind = orvyn.Binding(PreviousTabAction)
+
+	w.NumberKeybinds = true
+	w.InfiniteScroll = true
+
+	w.tabs = tabs
+	w.activeTab = 0
+
+	return w
+}
+
+// Init enters the active tab.
+func (w *Widget) Init() tea.Cmd {
+	return w.enterTab()
+}
+
+func (w *Widget) Update(msg tea.Msg) tea.Cmd {
+	// Every tab restyles, not only the active one.
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		var cmds []tea.Cmd
+
+		for _, tab := range w.tabs {
+			if tab.Layout != nil {
+				cmds = append(cmds, orvyn.Broadcast(tab.Layout, msg))
+			}
+
+			if tab.FocusManager != nil {
+				cmds = append(cmds, tab.FocusManager.Update(msg))
+			}
+		}
+
+		return tea.Batch(cmds...)
+	}
+
+	tab := w.GetActiveTab()
+
+	if tab == nil {
+		return nil
+	}
+
+	if m, ok := orvyn.GetKeyMsg(msg); ok {
+		switch {
+		case key.Matches(m, w.NextTabKeybind):
+			return w.NextTab()
+
+		case key.Matches(m, w.PreviousTabKeybind):
+			return w.PreviousTab()
+		}
+
+		if !w.isInputting() {
+			if index, ok := w.matchTabKeybind(m); ok {
+				return w.SetActiveTab(index)
+			}
+		}
+	}
+
+	if tab.FocusManager == nil {
+		return nil
+	}
+
+	return tab.FocusManager.Update(msg)
+}
+
+func (w *Widget) Render() string {
+	tab := w.GetActiveTab()
+
+	if tab == nil {
+		return ""
+	}
+
+	bar := w.renderBar()
+	w.barHeight = lipgloss.Height(bar)
+
+	contentSize := w.GetContentSize()
+	contentSize.Height = max(contentSize.Height-w.barHeight, 0)
+
+	content := ""
+
+	if tab.Layout != nil {
+		tab.Layout.Resize(contentSize)
+		content = tab.Layout.Render()
+	}
+
+	content = lipgloss.NewStyle().
+		Width(contentSize.Width).
+		Height(contentSize.Height).
+		MaxWidth(contentSize.Width).
+		MaxHeight(contentSize.Height).
+		Render(content)
+
+	return w.GetStyle().Render(lipgloss.JoinVertical(lipgloss.Left, bar, content))
+}
+
+func (w *Widget) GetMinSize() orvyn.Size {
+	return w.contentSize(orvyn.Renderable.GetMinSize)
+}
+
+func (w *Widget) GetPreferredSize() orvyn.Size {
+	return w.contentSize(orvyn.Renderable.GetPreferredSize)
+}
+
+// GetElements returns the layout of the active tab, so the tabs widget can be
+// walked like a layout, see orvyn.FindPosition.
+func (w *Widget) GetElements() []orvyn.Renderable {
+	tab := w.GetActiveTab()
+
+	if tab == nil || tab.Layout == nil {
+		return nil
+	}
+
+	return []orvyn.Renderable{tab.Layout}
+}
+
+// GetElementPosition returns the position of the active tab layout, under the
+// tab bar.
+func (w *Widget) GetElementPosition(e orvyn.Renderable) (orvyn.Position, bool) {
+	tab := w.GetActiveTab()
+
+	if tab == nil || tab.Layout == nil || e != tab.Layout {
+		return orvyn.Position{}, false
+	}
+
+	style := w.GetStyle()
+
+	return orvyn.NewPosition(
+		style.GetBorderLeftSize()+style.GetPaddingLeft()+style.GetMarginLeft(),
+		style.GetBorderTopSize()+style.GetPaddingTop()+style.GetMarginTop()+w.barHeight), true
+}
+
+// ShortHelp implements orvyn.KeyMap. Returns the bindings of the active tab
+// FocusManager, then the keys switching tabs.
+func (w *Widget) ShortHelp() []key.Binding {
+	var bindings []key.Binding
+
+	if tab := w.GetActiveTab(); tab != nil && tab.FocusManager != nil {
+		bindings = tab.FocusManager.ShortHelp()
+	}
+
+	return append(bindings, w.NextTabKeybind, w.PreviousTabKeybind)
+}
+
+// FullHelp implements orvyn.KeyMap. Returns a column per KeyGroups section.
+func (w *Widget) FullHelp() [][]key.Binding {
+	var columns [][]key.Binding
+
+	for _, g := range w.KeyGroups() {
+		columns = append(columns, g.Bindings)
+	}
+
+	return columns
+}
+
+// KeyGroups implements orvyn.KeyGroupsProvider. Returns the keys switching
+// tabs, then the sections of the active tab FocusManager.
+func (w *Widget) KeyGroups() []orvyn.KeyGroup {
+	bindings := []key.Binding{w.NextTabKeybind, w.PreviousTabKeybind}
+
+	for _, tab := range w.tabs {
+		if tab.FocusKeybind != nil {
+			bindings = append(bindings, *tab.FocusKeybind)
+		}
+	}
+
+	groups := []orvyn.KeyGroup{{Title: "Tabs", Bindings: orvyn.HelpBindings(bindings)}}
+
+	if tab := w.GetActiveTab(); tab != nil && tab.FocusManager != nil {
+		groups = append(groups, tab.FocusManager.KeyGroups()...)
+	}
+
+	return groups
+}
+
+// IsInputting returns true while a widget of the active tab is in input mode.
+func (w *Widget) IsInputting() bool {
+	return w.isInputting()
+}
+
+// Public API
+
+// AddTab appends a tab.
+func (w *Widget) AddTab(tab *Tab) {
+	w.tabs = append(w.tabs, tab)
+}
+
+// GetTabs returns the tabs of the widget.
+func (w *Widget) GetTabs() []*Tab {
+	return w.tabs
+}
+
+// GetActiveIndex returns the index of the active tab.
+func (w *Widget) GetActiveIndex() int {
+	return w.activeTab
+}
+
+// GetActiveTab returns the active tab, nil when the widget has no tab.
+func (w *Widget) GetActiveTab() *Tab {
+	if w.activeTab < 0 || w.activeTab >= len(w.tabs) {
+		return nil
+	}
+
+	return w.tabs[w.activeTab]
+}
+
+// SetActiveTab exits the active tab and enters the one at the given index.
+func (w *Widget) SetActiveTab(index int) tea.Cmd {
+	if index < 0 || index >= len(w.tabs) || index == w.activeTab {
+		return nil
+	}
+
+	if tab := w.GetActiveTab(); tab != nil && tab.OnExit != nil {
+		tab.OnExit()
+	}
+
+	w.activeTab = index
+
+	if w.TabChangedCallback != nil {
+		w.TabChangedCallback(index)
+	}
+
+	return w.enterTab()
+}
+
+// NextTab activates the next tab.
+func (w *Widget) NextTab() tea.Cmd {
+	index := w.activeTab + 1
+
+	if index >= len(w.tabs) {
+		if !w.InfiniteScroll {
+			return nil
+		}
+
+		index = 0
+	}
+
+	return w.SetActiveTab(index)
+}
+
+// PreviousTab activates the previous tab.
+func (w *Widget) PreviousTab() tea.Cmd {
+	index := w.activeTab - 1
+
+	if index < 0 {
+		if !w.InfiniteScroll {
+			return nil
+		}
+
+		index = len(w.tabs) - 1
+	}
+
+	return w.SetActiveTab(index)
+}
+
+// Hidden functions
+
+// enterTab focuses the first widget of the active tab the first time it is
+// shown, and calls its OnEnter.
+func (w *Widget) enterTab() tea.Cmd {
+	tab := w.GetActiveTab()
+
+	if tab == nil {
+		return nil
+	}
+
+	if tab.FocusManager != nil && tab.FocusManager.GetFocused() == nil {
+		tab.FocusManager.FocusFirst()
+	}
+
+	if tab.OnEnter == nil {
+		return nil
+	}
+
+	return tab.OnEnter()
+}
+
+// isInputting returns true if a widget of the active tab is in input mode, in
+// which case the plain keys belong to it.
+func (w *Widget) isInputting() bool {
+	tab := w.GetActiveTab()
+
+	return tab != nil && tab.FocusManager != nil && tab.FocusManager.IsInputting()
+}
+
+// matchTabKeybind returns the index of the tab activated by the key.
+func (w *Widget) matchTabKeybind(msg tea.KeyMsg) (int, bool) {
+	for i, tab := range w.tabs {
+		if tab.FocusKeybind != nil && key.Matches(msg, *tab.FocusKeybind) {
+			return i, true
+		}
+	}
+
+	if !w.NumberKeybinds {
+		return 0, false
+	}
+
+	for i := range min(len(w.tabs), 9) {
+		if msg.String() == fmt.Sprint(i+1) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// renderBar returns the tab bar, cut to the content width.
+func (w *Widget) renderBar() string {
+	t := orvyn.GetTheme()
+	activeStyle := t.Style(theme.TabActiveStyleID)
+	inactiveStyle := t.Style(theme.TabInactiveStyleID)
+	separator := t.Style(theme.DimTextStyleID).Render("│")
+
+	titles := make([]string, 0, len(w.tabs))
+
+	for i, tab := range w.tabs {
+		style := inactiveStyle
+
+		if i == w.activeTab {
+			style = activeStyle
+		}
+
+		titles = append(titles, style.Render(tab.Title))
+	}
+
+	return lipgloss.NewStyle().
+		MaxWidth(w.GetContentSize().Width).
+		Render(strings.Join(titles, separator))
+}
+
+// contentSize returns the size of the tab bar over the biggest tab layout,
+// every layout measured with sizeOf.
+func (w *Widget) contentSize(sizeOf func(orvyn.Renderable) orvyn.Size) orvyn.Size {
+	var size orvyn.Size
+
+	for _, tab := range w.tabs {
+		if tab.Layout == nil {
+			continue
+		}
+
+		layoutSize := sizeOf(tab.Layout)
+
+		size.Width = max(size.Width, layoutSize.Width)
+		size.Height = max(size.Height, layoutSize.Height)
+	}
+
+	bar := w.renderBar()
+
+	size.Width = max(size.Width, lipgloss.Width(bar))
+	size.Height += lipgloss.Height(bar)
+
+	size.Width += w.GetStyle().GetHorizontalFrameSize()
+	size.Height += w.GetStyle().GetVerticalFrameSize()
+
+	return size
+}
@@ -0,0 +1,124 @@
+package tabs
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/widget/textinput"
+)
+
+func newTestTabs(t *testing.T) (*Widget, *textinput.Widget) {
+	t.Helper()
+
+	orvyn.Init()
+
+	input := textinput.New()
+	fm := orvyn.NewFocusManager()
+	fm.Add(input)
+
+	first := NewTab("First", nil, fm)
+	second := NewTab("Second", nil, nil)
+	third := NewTab("Third", nil, nil)
+
+	binding := key.NewBinding(key.WithKeys("f3"))
+	third.FocusKeybind = &binding
+
+	w := New(first, second, third)
+	w.Init()
+
+	return w, input
+}
+
+func TestTabsSwitchKeys(t *testing.T) {
+	w, _ := newTestTabs(t)
+
+	tests := []struct {
+		key  tea.KeyMsg
+		want int
+	}{
+		{tea.KeyMsg{Type: tea.KeyCtrlPgDown}, 1},
+		{tea.KeyMsg{Type: tea.KeyCtrlPgDown}, 2},
+		{tea.KeyMsg{Type: tea.KeyCtrlPgDown}, 0},
+		{tea.KeyMsg{Type: tea.KeyCtrlPgUp}, 2},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")}, 1},
+		{tea.KeyMsg{Type: tea.KeyF3}, 2},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")}, 2},
+	}
+
+	for _, test := range tests {
+		w.Update(test.key)
+
+		if got := w.GetActiveIndex(); got != test.want {
+			t.Fatalf("after %q: active tab = %d, want %d", test.key, got, test.want)
+		}
+	}
+}
+
+func TestTabsHooks(t *testing.T) {
+	orvyn.Init()
+
+	var events []string
+
+	newTab := func(title string) *Tab {
+		tab := NewTab(title, nil, nil)
+		tab.OnEnter = func() tea.Cmd {
+			events = append(events, "enter "+title)
+			return nil
+		}
+		tab.OnExit = func() {
+			events = append(events, "exit "+title)
+		}
+
+		return tab
+	}
+
+	w := New(newTab("a"), newTab("b"))
+	w.Init()
+	w.NextTab()
+	w.SetActiveTab(1)
+
+	want := []string{"enter a", "exit a", "enter b"}
+
+	if len(events) != len(want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("events = %v, want %v", events, want)
+		}
+	}
+}
+
+// The number keys belong to the widget in input mode.
+func TestTabsNumberKeysWhileInputting(t *testing.T) {
+	w, input := newTestTabs(t)
+
+	if !input.IsFocused() {
+		t.Fatalf("entering the tab did not focus its first widget")
+	}
+
+	w.GetActiveTab().FocusManager.ForceInput(0)
+
+	if !w.GetActiveTab().FocusManager.IsInputting() {
+		t.Fatalf("test setup: the input did not enter input mode")
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+
+	if w.GetActiveIndex() != 0 {
+		t.Errorf("active tab = %d, want 0 while inputting", w.GetActiveIndex())
+	}
+
+	if input.Value() != "2" {
+		t.Errorf("input value = %q, want %q", input.Value(), "2")
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyCtrlPgDown})
+
+	if w.GetActiveIndex() != 1 {
+		t.Errorf("active tab = %d, want 1: ctrl+pgdown switches even while inputting", w.GetActiveIndex())
+	}
+}
@@ -38,6 +38,12 @@ func (w *Widget) Init() tea.Cmd {
 func (w *Widget) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+
+		return nil
+	}
+
 	w.Model, cmd = w.Model.Update(msg)
 
 	return cmd
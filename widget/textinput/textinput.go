@@ -1,6 +1,8 @@
 package textinput
 
 import (
+	"unicode"
+
 	"github.com/halsten-dev/orvyn"
 	"github.com/halsten-dev/orvyn/theme"
 
@@ -9,26 +11,38 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Widget is a single line text input. Its value can be checked by
+// validators, on every change and when exiting the input mode, and the typed
+// characters restricted by a CharFilter or a mask.
 type Widget struct {
 	orvyn.BaseWidget
 	orvyn.BaseFocusable
 
 	textinput.Model
+
+	// CharFilter rejects the typed characters it returns false for. Nil by
+	// default, every character is accepted.
+	CharFilter CharFilter
+
+	validators []Validator
+	err        error
+
+	// mask holds the mask set by SetMask, nil without mask.
+	mask []rune
+
+	errorStyle       lipgloss.Style
+	customErrorStyle bool
 }
 
 func New() *Widget {
 	w := new(Widget)
 
-	t := orvyn.GetTheme()
-
 	w.BaseWidget = orvyn.NewBaseWidget()
 	w.BaseFocusable = orvyn.NewBaseFocusable(w)
 
 	w.Model = textinput.New()
 	w.Prompt = ""
-	w.TextStyle = t.Style(theme.NormalTextStyleID)
-	w.Cursor.Style = t.Style(theme.NormalTextStyleID)
-	w.Cursor.TextStyle = t.Style(theme.NormalTextStyleID)
+	w.updateStyle()
 
 	w.OnBlur()
 
@@ -43,8 +57,35 @@ func (w *Widget) Init() tea.Cmd {
 func (w *Widget) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.updateStyle()
+		w.BaseFocusable.UpdateTheme()
+
+		return nil
+	}
+
+	if m, ok := msg.(tea.KeyMsg); ok && (m.Type == tea.KeyRunes || m.Type == tea.KeySpace) {
+		m.Runes = w.filterRunes(m.Runes)
+
+		if len(m.Runes) == 0 {
+			return nil
+		}
+
+		msg = m
+	}
+
+	value := w.Model.Value()
+
 	w.Model, cmd = w.Model.Update(msg)
 
+	if w.mask != nil {
+		w.applyMask()
+	}
+
+	if w.Model.Value() != value {
+		w.validate()
+	}
+
 	return cmd
 }
 
@@ -62,11 +103,79 @@ func (w *Widget) SetValue(value string) {
 func (w *Widget) OnFocus() {
 	w.BaseFocusable.OnFocus()
 	w.Model.Focus()
+
+	if w.err != nil {
+		w.BaseWidget.SetStyle(w.errorStyle)
+	}
 }
 
 func (w *Widget) OnBlur() {
 	w.BaseFocusable.OnBlur()
 	w.Model.Blur()
+
+	if w.err != nil {
+		w.BaseWidget.SetStyle(w.errorStyle)
+	}
+}
+
+// OnExitInput validates the value.
+func (w *Widget) OnExitInput() tea.Cmd {
+	w.validate()
+
+	return nil
+}
+
+// CanExitInputting returns false while the value is invalid.
+func (w *Widget) CanExitInputting() bool {
+	return w.validate() == nil
+}
+
+// SetValidators replaces the validators of the input, run in order. The
+// first error is returned by GetError and the error style of the theme is
+// applied while the value is invalid.
+func (w *Widget) SetValidators(validators ...Validator) {
+	w.validators = validators
+}
+
+// IsValid validates the value and returns true if it is valid.
+func (w *Widget) IsValid() bool {
+	return w.validate() == nil
+}
+
+// GetError returns the error of the last validation, nil if the value was
+// valid.
+func (w *Widget) GetError() error {
+	return w.err
+}
+
+// SetErrorStyle changes the style applied while the value is invalid. By
+// default theme.ErrorWidgetStyleID.
+func (w *Widget) SetErrorStyle(style lipgloss.Style) {
+	w.errorStyle = style
+	w.customErrorStyle = true
+}
+
+// SetMask restricts the value to the mask: '9' accepts a digit, 'a' a letter,
+// '*' any character, every other character is a literal inserted while
+// typing. "9999-99-99" for a date for example. The character limit becomes
+// the mask length. An empty mask removes it.
+func (w *Widget) SetMask(mask string) {
+	if mask == "" {
+		w.mask = nil
+		w.Model.CharLimit = 0
+
+		return
+	}
+
+	w.mask = []rune(mask)
+	w.Model.CharLimit = len(w.mask)
+
+	w.applyMask()
+}
+
+// GetMask returns the mask of the input, empty if none.
+func (w *Widget) GetMask() string {
+	return string(w.mask)
 }
 
 func (w *Widget) Render() string {
@@ -114,3 +223,131 @@ func (w *Widget) GetMinSize() orvyn.Size {
 func (w *Widget) GetPreferredSize() orvyn.Size {
 	return orvyn.NewSize(46, 3)
 }
+
+// Hidden functions
+
+func (w *Widget) updateStyle() {
+	t := orvyn.GetTheme()
+
+	w.TextStyle = t.Style(theme.NormalTextStyleID)
+	w.Cursor.Style = t.Style(theme.NormalTextStyleID)
+	w.Cursor.TextStyle = t.Style(theme.NormalTextStyleID)
+
+	if !w.customErrorStyle {
+		w.errorStyle = t.Style(theme.ErrorWidgetStyleID)
+	}
+}
+
+// validate runs the validators and applies the error style while the value
+// is invalid.
+func (w *Widget) validate() error {
+	var err error
+
+	for _, validator := range w.validators {
+		if err = validator(w.Model.Value()); err != nil {
+			break
+		}
+	}
+
+	w.err = err
+	w.Model.Err = err
+
+	if err != nil {
+		w.BaseWidget.SetStyle(w.errorStyle)
+	} else if w.Model.Focused() {
+		w.BaseFocusable.OnFocus()
+	} else {
+		w.BaseFocusable.OnBlur()
+	}
+
+	return err
+}
+
+// filterRunes returns the typed characters accepted by the CharFilter.
+func (w *Widget) filterRunes(runes []rune) []rune {
+	if w.CharFilter == nil {
+		return runes
+	}
+
+	filtered := make([]rune, 0, len(runes))
+
+	for _, r := range runes {
+		if w.CharFilter(r) {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
+
+// applyMask fits the value in the mask, keeping the cursor at the end when it
+// was there.
+func (w *Widget) applyMask() {
+	value := w.Model.Value()
+	atEnd := w.Model.Position() >= len([]rune(value))
+
+	masked := maskValue(w.mask, value)
+
+	if masked == value {
+		return
+	}
+
+	w.Model.SetValue(masked)
+
+	if atEnd {
+		w.Model.CursorEnd()
+	}
+}
+
+// maskValue returns the characters of value fitting the slots of the mask,
+// with the literals of the mask between them. The literals after the last
+// character are left out, so they can be deleted.
+func maskValue(mask []rune, value string) string {
+	masked := make([]rune, 0, len(mask))
+	m := 0
+
+	// kept is the length of masked up to the last typed character.
+	kept := 0
+
+runes:
+	for _, r := range value {
+		for m < len(mask) && !isMaskSlot(mask[m]) {
+			masked = append(masked, mask[m])
+			m++
+
+			// The literal itself was typed.
+			if r == mask[m-1] {
+				kept = len(masked)
+				continue runes
+			}
+		}
+
+		if m >= len(mask) {
+			break
+		}
+
+		if matchesMaskSlot(mask[m], r) {
+			masked = append(masked, r)
+			m++
+			kept = len(masked)
+		}
+	}
+
+	return string(masked[:kept])
+}
+
+func isMaskSlot(r rune) bool {
+	return r == '9' || r == 'a' || r == '*'
+}
+
+func matchesMaskSlot(slot, r rune) bool {
+	switch slot {
+	case '9':
+		return unicode.IsDigit(r)
+
+	case 'a':
+		return unicode.IsLetter(r)
+	}
+
+	return true
+}
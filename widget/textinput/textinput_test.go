@@ -0,0 +1,107 @@
+package textinput
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+func typeText(w *Widget, text string) {
+	for _, r := range text {
+		w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestValidators(t *testing.T) {
+	orvyn.Init()
+
+	w := New()
+	w.SetValidators(Required(), IntRange(1, 10))
+	w.OnFocus()
+
+	typeText(w, "12")
+
+	if w.GetError() == nil {
+		t.Fatal("out of range value accepted")
+	}
+
+	if w.CanExitInputting() {
+		t.Error("can exit input mode while invalid")
+	}
+
+	if got, want := w.GetStyle().GetBorderTopForeground(), orvyn.GetTheme().Color(theme.StatusErrorFontColorID); got != want {
+		t.Errorf("border color = %v, want the error color %v", got, want)
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+
+	if w.GetError() != nil || !w.CanExitInputting() {
+		t.Fatalf("valid value refused: %v", w.GetError())
+	}
+
+	if got, want := w.GetStyle().GetBorderTopForeground(), orvyn.GetTheme().Color(theme.FocusedBorderColorID); got != want {
+		t.Errorf("border color = %v, want the focused color %v", got, want)
+	}
+}
+
+func TestValidatorFuncs(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		value     string
+		valid     bool
+	}{
+		{"required empty", Required(), " ", false},
+		{"required", Required(), "a", true},
+		{"float", FloatRange(0, 1), "0.5", true},
+		{"float out of range", FloatRange(0, 1), "1.5", false},
+		{"email", Email(), "ada@example.com", true},
+		{"email name", Email(), "Ada <ada@example.com>", false},
+		{"ipv4", IP(), "192.168.1.1", true},
+		{"ip", IP(), "192.168.1", false},
+	}
+
+	for _, test := range tests {
+		if err := test.validator(test.value); (err == nil) != test.valid {
+			t.Errorf("%s: %q valid = %v, want %v", test.name, test.value, err == nil, test.valid)
+		}
+	}
+}
+
+func TestCharFilter(t *testing.T) {
+	orvyn.Init()
+
+	w := New()
+	w.CharFilter = Digits
+	w.OnFocus()
+
+	typeText(w, "1a2 b3")
+
+	if got := w.Value(); got != "123" {
+		t.Errorf("value = %q, want 123", got)
+	}
+}
+
+func TestMask(t *testing.T) {
+	orvyn.Init()
+
+	w := New()
+	w.SetMask("9999-99-99")
+	w.OnFocus()
+
+	typeText(w, "2026x10-18999")
+
+	if got := w.Value(); got != "2026-10-18" {
+		t.Errorf("value = %q, want 2026-10-18", got)
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	w.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	w.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+
+	if got := w.Value(); got != "2026-10" {
+		t.Errorf("value after deleting = %q, want 2026-10", got)
+	}
+}
@@ -0,0 +1,130 @@
+package textinput
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Validator checks the value of the input, nil when valid. Any func can be
+// used as a custom validator.
+type Validator func(value string) error
+
+// CharFilter returns true for the characters the input accepts.
+type CharFilter func(r rune) bool
+
+// Required refuses an empty or blank value.
+func Required() Validator {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New("required")
+		}
+
+		return nil
+	}
+}
+
+// Pattern refuses a non empty value not matching the regular expression,
+// with the given message.
+func Pattern(re *regexp.Regexp, message string) Validator {
+	return func(value string) error {
+		if value != "" && !re.MatchString(value) {
+			return errors.New(message)
+		}
+
+		return nil
+	}
+}
+
+// IntRange refuses a non empty value that is not an integer in [min, max].
+func IntRange(min, max int64) Validator {
+	return func(value string) error {
+		if value == "" {
+			return nil
+		}
+
+		n, err := strconv.ParseInt(value, 10, 64)
+
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+
+		if n < min || n > max {
+			return fmt.Errorf("must be between %d and %d", min, max)
+		}
+
+		return nil
+	}
+}
+
+// FloatRange refuses a non empty value that is not a number in [min, max].
+func FloatRange(min, max float64) Validator {
+	return func(value string) error {
+		if value == "" {
+			return nil
+		}
+
+		n, err := strconv.ParseFloat(value, 64)
+
+		if err != nil {
+			return errors.New("must be a number")
+		}
+
+		if n < min || n > max {
+			return fmt.Errorf("must be between %v and %v", min, max)
+		}
+
+		return nil
+	}
+}
+
+// Email refuses a non empty value that is not a bare email address.
+func Email() Validator {
+	return func(value string) error {
+		if value == "" {
+			return nil
+		}
+
+		address, err := mail.ParseAddress(value)
+
+		if err != nil || address.Address != value {
+			return errors.New("must be an email address")
+		}
+
+		return nil
+	}
+}
+
+// IP refuses a non empty value that is not an IPv4 or IPv6 address.
+func IP() Validator {
+	return func(value string) error {
+		if value != "" && net.ParseIP(value) == nil {
+			return errors.New("must be an IP address")
+		}
+
+		return nil
+	}
+}
+
+// Digits accepts the digits only.
+func Digits(r rune) bool {
+	return unicode.IsDigit(r)
+}
+
+// Decimal accepts the characters of a decimal number: the digits, the sign
+// and the decimal point.
+func Decimal(r rune) bool {
+	return unicode.IsDigit(r) || r == '-' || r == '+' || r == '.'
+}
+
+// AllowRunes returns a CharFilter accepting the characters of allowed only.
+func AllowRunes(allowed string) CharFilter {
+	return func(r rune) bool {
+		return strings.ContainsRune(allowed, r)
+	}
+}
@@ -0,0 +1,162 @@
+// Package toggle provides a compact on/off switch, drawn on a single line.
+package toggle
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+)
+
+// Actions of the toggle widget, see orvyn.Bindings.
+const (
+	SwitchAction orvyn.ActionID = "toggle.switch"
+)
+
+func init() {
+	orvyn.DefineAction(SwitchAction,
+		key.WithKeys(" "),
+		key.WithHelp("space", "switch"))
+}
+
+// Widget is an on/off switch followed by its label, on a single line. Having
+// no border, its focused and blurred styles are by default the highlighted and
+// the normal text ones, applied to the label. A frame in the styles, set with
+// SetFocusedStyle or SetBlurredStyle, is drawn around the line.
+type Widget struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	// SwitchKeybind turns the switch on or off. Space by default.
+	SwitchKeybind key.Binding
+
+	// OnText and OffText are shown after the switch, before the label.
+	// "On" and "Off" by default.
+	OnText  string
+	OffText string
+
+	label string
+	on    bool
+}
+
+// New creates and returns a new toggle *Widget, off.
+func New(label string) *Widget {
+	w := new(Widget)
+
+	w.BaseWidget = orvyn.NewBaseWidget()
+	w.BaseFocusable = orvyn.NewStyledBaseFocusable(w,
+		theme.HighlightTextStyleID, theme.NormalTextStyleID)
+
+	w.SwitchKeybind = orvyn.Binding(SwitchAction)
+
+	w.OnText = "On"
+	w.OffText = "Off"
+
+	w.label = label
+
+	w.OnBlur()
+
+	return w
+}
+
+func (w *Widget) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+
+		return nil
+	}
+
+	if m, ok := orvyn.GetKeyMsg(msg); ok && key.Matches(m, w.SwitchKeybind) {
+		w.on = !w.on
+	}
+
+	if m, ok := msg.(orvyn.MouseMsg); ok && m.IsLeftClick() && m.IsOver(w) {
+		w.on = !w.on
+	}
+
+	return nil
+}
+
+func (w *Widget) Resize(size orvyn.Size) {
+	size.Height = 1 + w.GetStyle().GetVerticalFrameSize()
+
+	w.BaseWidget.Resize(size)
+}
+
+func (w *Widget) Render() string {
+	t := orvyn.GetTheme()
+
+	track := t.Style(theme.DimTextStyleID).Render("●──")
+	state := t.Style(theme.DimTextStyleID).Render(w.stateText(w.OffText))
+
+	if w.on {
+		track = t.Style(theme.TitleStyleID).Render("──●")
+		state = t.Style(theme.TitleStyleID).Render(w.stateText(w.OnText))
+	}
+
+	// The colors of the style go to the label, its frame around the line.
+	style := w.GetStyle()
+	view := track + " " + state + " " + style.Inline(true).Render(w.label)
+	width := w.GetContentSize().Width
+
+	return style.Render(lipgloss.NewStyle().Width(width).Render(ansi.Truncate(view, width, "…")))
+}
+
+func (w *Widget) GetMinSize() orvyn.Size {
+	style := w.GetStyle()
+
+	return orvyn.NewSize(5+w.stateWidth()+lipgloss.Width(w.label)+style.GetHorizontalFrameSize(),
+		1+style.GetVerticalFrameSize())
+}
+
+func (w *Widget) GetPreferredSize() orvyn.Size {
+	size := w.GetMinSize()
+
+	return orvyn.NewSize(max(size.Width, 46+w.GetStyle().GetHorizontalFrameSize()), size.Height)
+}
+
+// ShortHelp implements orvyn.KeyMap.
+func (w *Widget) ShortHelp() []key.Binding {
+	return []key.Binding{w.SwitchKeybind}
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (w *Widget) FullHelp() [][]key.Binding {
+	return [][]key.Binding{w.ShortHelp()}
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (w *Widget) KeyMapTitle() string {
+	return "Toggle"
+}
+
+// Public API
+
+// IsOn returns true when the switch is on.
+func (w *Widget) IsOn() bool {
+	return w.on
+}
+
+// SetOn turns the switch on or off.
+func (w *Widget) SetOn(on bool) {
+	w.on = on
+}
+
+// SetLabel changes the label shown after the switch.
+func (w *Widget) SetLabel(label string) {
+	w.label = label
+}
+
+// Hidden functions
+
+// stateText pads the state text to the widest one, so the label does not
+// move when switching.
+func (w *Widget) stateText(text string) string {
+	return lipgloss.NewStyle().Width(w.stateWidth()).Render(text)
+}
+
+func (w *Widget) stateWidth() int {
+	return max(lipgloss.Width(w.OnText), lipgloss.Width(w.OffText))
+}
@@ -0,0 +1,98 @@
+package toggle
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+)
+
+func newTestToggle(t *testing.T) *Widget {
+	t.Helper()
+
+	orvyn.Init()
+
+	w := New("Notifications")
+	w.Resize(orvyn.NewSize(30, 1))
+
+	return w
+}
+
+// click returns a left click at the cell of the toggle.
+func click(w *Widget, x int) orvyn.MouseMsg {
+	return orvyn.MouseMsg{
+		MouseMsg: tea.MouseMsg{X: x, Y: 0, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+		Hits:     orvyn.HitTest(w, orvyn.NewPosition(0, 0), orvyn.NewPosition(x, 0)),
+	}
+}
+
+func TestKeySwitches(t *testing.T) {
+	w := newTestToggle(t)
+
+	w.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	if !w.IsOn() || !strings.Contains(ansi.Strip(w.Render()), "──● On") {
+		t.Fatalf("space did not switch the toggle on:\n%s", w.Render())
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !w.IsOn() {
+		t.Errorf("enter switched the toggle")
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	if w.IsOn() || !strings.Contains(ansi.Strip(w.Render()), "●── Off") {
+		t.Errorf("space did not switch the toggle off:\n%s", w.Render())
+	}
+}
+
+func TestClickSwitches(t *testing.T) {
+	w := newTestToggle(t)
+
+	w.Update(click(w, 2))
+
+	if !w.IsOn() {
+		t.Fatalf("the click did not switch the toggle on")
+	}
+
+	// A click outside of the toggle is not for it.
+	w.Update(click(w, 40))
+
+	if !w.IsOn() {
+		t.Errorf("a click outside of the toggle switched it")
+	}
+
+	w.Update(click(w, 20))
+
+	if w.IsOn() {
+		t.Errorf("a click on the label did not switch the toggle off")
+	}
+}
+
+func TestFocusStyles(t *testing.T) {
+	w := newTestToggle(t)
+
+	w.SetFocusedStyle(lipgloss.NewStyle().Border(lipgloss.NormalBorder()))
+
+	fm := orvyn.NewFocusManager()
+	fm.Add(w)
+	fm.FocusFirst()
+
+	w.Resize(orvyn.NewSize(30, 1))
+
+	if lines := strings.Split(ansi.Strip(w.Render()), "\n"); len(lines) != 3 || !strings.HasPrefix(lines[0], "┌") {
+		t.Errorf("focused style frame not drawn:\n%s", w.Render())
+	}
+
+	fm.BlurCurrent()
+	w.Resize(orvyn.NewSize(30, 1))
+
+	if view := ansi.Strip(w.Render()); strings.Contains(view, "\n") {
+		t.Errorf("the blurred toggle is not a single line:\n%s", view)
+	}
+}
@@ -0,0 +1,780 @@
+package tree
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/theme"
+	"github.com/halsten-dev/orvyn/widget/textinput"
+	"github.com/halsten-dev/orvyn/widget/widgetlist"
+	"github.com/sahilm/fuzzy"
+)
+
+// Actions of the tree widget, see orvyn.Bindings.
+const (
+	CursorUpAction    orvyn.ActionID = "tree.cursor_up"
+	CursorDownAction  orvyn.ActionID = "tree.cursor_down"
+	ExpandAction      orvyn.ActionID = "tree.expand"
+	CollapseAction    orvyn.ActionID = "tree.collapse"
+	EnterFilterAction orvyn.ActionID = "tree.filter"
+	ApplyFilterAction orvyn.ActionID = "tree.apply_filter"
+	ClearFilterAction orvyn.ActionID = "tree.clear_filter"
+)
+
+func init() {
+	orvyn.DefineAction(CursorUpAction,
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"))
+	orvyn.DefineAction(CursorDownAction,
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"))
+	orvyn.DefineAction(ExpandAction,
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "expand"))
+	orvyn.DefineAction(CollapseAction,
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "collapse"))
+	orvyn.DefineAction(EnterFilterAction,
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"))
+	orvyn.DefineAction(ApplyFilterAction,
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "apply filter"))
+	orvyn.DefineAction(ClearFilterAction,
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear filter"))
+}
+
+// NodeProvider gives the tree what it needs to know about the node data.
+// T type represents the type of the node data.
+type NodeProvider[T any] interface {
+	// Title returns the text shown for the node, also used by the filter.
+	Title(node T) string
+
+	// HasChildren returns true if the node can be expanded.
+	HasChildren(node T) bool
+
+	// LoadChildren returns the tea.Cmd loading the children of the node. The
+	// cmd must return a ChildrenLoadedMsg[T]. It is called the first time the
+	// node is expanded.
+	LoadChildren(node T) tea.Cmd
+}
+
+// ChildrenLoadedMsg carries the children loaded by NodeProvider.LoadChildren.
+// The tree must receive it, even when it is not the focused widget: a
+// FocusManager gives it to all its widgets, a screen holding the tree
+// otherwise must forward it.
+type ChildrenLoadedMsg[T any] struct {
+	Children []T
+	Err      error
+
+	// node is set by the tree wrapping the cmd of LoadChildren.
+	node *Node[T]
+}
+
+// BroadcastMsg implements orvyn.BroadcastMsg.
+func (m ChildrenLoadedMsg[T]) BroadcastMsg() {}
+
+// Node is a node of the tree, holding its data and its loaded children.
+type Node[T any] struct {
+	Data T
+
+	tree     *Widget[T]
+	parent   *Node[T]
+	children []*Node[T]
+
+	expanded bool
+	loaded   bool
+	loading  bool
+	err      error
+}
+
+// GetParent returns the parent node, nil for a root.
+func (n *Node[T]) GetParent() *Node[T] {
+	return n.parent
+}
+
+// GetChildren returns the loaded children of the node.
+func (n *Node[T]) GetChildren() []*Node[T] {
+	return n.children
+}
+
+// IsExpanded returns true if the children of the node are shown.
+func (n *Node[T]) IsExpanded() bool {
+	return n.expanded
+}
+
+// IsLoaded returns true if the children of the node were loaded.
+func (n *Node[T]) IsLoaded() bool {
+	return n.loaded
+}
+
+// GetError returns the error of the last children loading, if any.
+func (n *Node[T]) GetError() error {
+	return n.err
+}
+
+// row is a visible line of the tree.
+type row[T any] struct {
+	node   *Node[T]
+	prefix string
+}
+
+// Widget shows hierarchical data as a tree of expandable nodes.
+// T type represents the type of the node data.
+type Widget[T any] struct {
+	orvyn.BaseWidget
+	orvyn.BaseFocusable
+
+	// CursorUpKeybind moves the cursor up. up and k by default.
+	CursorUpKeybind key.Binding
+
+	// CursorDownKeybind moves the cursor down. down and j by default.
+	CursorDownKeybind key.Binding
+
+	// ExpandKeybind expands the node, or moves to its first child when it is
+	// already expanded. right and l by default.
+	ExpandKeybind key.Binding
+
+	// CollapseKeybind collapses the node, or moves to its parent when it is
+	// already collapsed. left and h by default.
+	CollapseKeybind key.Binding
+
+	// EnterFilterKeybind starts typing a filter. / by default.
+	EnterFilterKeybind key.Binding
+
+	// ApplyFilterKeybind applies the typed filter. enter by default.
+	ApplyFilterKeybind key.Binding
+
+	// ClearFilterKeybind clears the filter. esc by default.
+	ClearFilterKeybind key.Binding
+
+	CursorMovingCallback func(T)
+	CursorMovedCallback  func(T)
+
+	provider NodeProvider[T]
+
+	roots []*Node[T]
+	rows  []row[T]
+
+	cursor int
+	offset int
+
+	filterable  bool
+	filterState widgetlist.FilterState
+
+	// kept holds the nodes matching the filter, and their ancestors.
+	kept map[*Node[T]]bool
+
+	tiFilter *textinput.Widget
+}
+
+// New creates and returns a new tree *Widget getting its data from provider.
+// T type represents the type of the node data.
+func New[T any](provider NodeProvider[T]) *Widget[T] {
+	w := new(Widget[T])
+
+	w.BaseWidget = orvyn.NewBaseWidget()
+	w.BaseFocusable = orvyn.NewBaseFocusable(w)
+
+	w.CursorUpKeybind = orvyn.Binding(CursorUpAction)
+	w.CursorDownKeybind = orvyn.Binding(CursorDownAction)
+	w.ExpandKeybind = orvyn.Binding(ExpandAction)
+	w.CollapseKeybind = orvyn.Binding(CollapseAction)
+	w.EnterFilterKeybind = orvyn.Binding(EnterFilterAction)
+	w.ApplyFilterKeybind = orvyn.Binding(ApplyFilterAction)
+	w.ClearFilterKeybind = orvyn.Binding(ClearFilterAction)
+
+	w.provider = provider
+
+	w.filterable = true
+	w.filterState = widgetlist.Unfiltered
+
+	w.tiFilter = textinput.New()
+	w.tiFilter.Placeholder = "Press '/' to filter"
+
+	w.BaseRenderable.SetMinSize(orvyn.NewSize(10, 5))
+	w.BaseRenderable.SetPreferredSize(orvyn.NewSize(30, 10))
+
+	w.OnBlur()
+
+	return w
+}
+
+func (w *Widget[T]) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+
+		return w.tiFilter.Update(msg)
+	}
+
+	if m, ok := msg.(ChildrenLoadedMsg[T]); ok {
+		w.childrenLoaded(m)
+		return nil
+	}
+
+	if m, ok := msg.(orvyn.MouseMsg); ok && w.filterState != widgetlist.Filtering {
+		return w.updateMouse(m)
+	}
+
+	m, ok := orvyn.GetKeyMsg(msg)
+
+	if w.filterState == widgetlist.Filtering {
+		if ok {
+			switch {
+			case key.Matches(m, w.ApplyFilterKeybind):
+				w.SetFilter(w.tiFilter.Value())
+				return nil
+
+			case key.Matches(m, w.ClearFilterKeybind):
+				w.ClearFilter()
+				return nil
+			}
+		}
+
+		return w.tiFilter.Update(msg)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case key.Matches(m, w.CursorUpKeybind):
+		w.SetCursor(w.cursor - 1)
+
+	case key.Matches(m, w.CursorDownKeybind):
+		w.SetCursor(w.cursor + 1)
+
+	case key.Matches(m, w.ExpandKeybind):
+		node := w.GetCursorNode()
+
+		if node == nil {
+			return nil
+		}
+
+		if w.isOpen(node) {
+			w.SetCursor(w.cursor + 1)
+			return nil
+		}
+
+		return w.Expand(node)
+
+	case key.Matches(m, w.CollapseKeybind):
+		node := w.GetCursorNode()
+
+		if node == nil {
+			return nil
+		}
+
+		if node.expanded && w.filterState == widgetlist.Unfiltered {
+			w.Collapse(node)
+			return nil
+		}
+
+		w.SetCursorNode(node.parent)
+
+	case key.Matches(m, w.EnterFilterKeybind):
+		if w.filterable {
+			w.filterState = widgetlist.Filtering
+			w.tiFilter.OnFocus()
+		}
+
+	case key.Matches(m, w.ClearFilterKeybind):
+		if w.filterState == widgetlist.FilterApplied {
+			w.ClearFilter()
+		}
+	}
+
+	return nil
+}
+
+func (w *Widget[T]) Resize(size orvyn.Size) {
+	w.BaseWidget.Resize(size)
+	w.tiFilter.Resize(w.GetContentSize())
+
+	w.clampOffset()
+}
+
+func (w *Widget[T]) Render() string {
+	contentSize := w.GetContentSize()
+
+	t := orvyn.GetTheme()
+	guideStyle := t.Style(theme.DimTextStyleID)
+	normalStyle := t.Style(theme.NormalTextStyleID)
+	cursorStyle := t.Style(theme.HighlightTextStyleID).Bold(true)
+	errorStyle := t.Style(theme.StatusErrorTextStyleID).UnsetAlign()
+
+	lines := make([]string, 0, contentSize.Height)
+
+	if w.filterable {
+		lines = append(lines, w.tiFilter.Render())
+	}
+
+	w.clampOffset()
+
+	end := min(w.offset+w.bodyHeight(), len(w.rows))
+
+	for i := w.offset; i < end; i++ {
+		r := w.rows[i]
+		style := normalStyle
+
+		if i == w.cursor {
+			style = cursorStyle
+		}
+
+		line := guideStyle.Render(r.prefix+w.marker(r.node)) +
+			style.Render(w.provider.Title(r.node.Data))
+
+		if r.node.err != nil {
+			line += " " + errorStyle.Render(r.node.err.Error())
+		}
+
+		lines = append(lines, ansi.Truncate(line, contentSize.Width, "…"))
+	}
+
+	view := lipgloss.NewStyle().
+		Width(contentSize.Width).
+		Height(contentSize.Height).
+		MaxHeight(contentSize.Height).
+		Render(strings.Join(lines, "\n"))
+
+	return w.GetStyle().Render(view)
+}
+
+// OnBlur clears the filter being typed, the tree no longer getting its keys.
+func (w *Widget[T]) OnBlur() {
+	if w.filterState == widgetlist.Filtering {
+		w.ClearFilter()
+	}
+
+	w.BaseFocusable.OnBlur()
+}
+
+// IsInputting returns true while the filter is typed: the keys then go to the
+// filter, and the exit input key clears it.
+func (w *Widget[T]) IsInputting() bool {
+	return w.filterState == widgetlist.Filtering || w.BaseFocusable.IsInputting()
+}
+
+// ShortHelp implements orvyn.KeyMap. Returns the filter keys while filtering.
+func (w *Widget[T]) ShortHelp() []key.Binding {
+	if w.filterState == widgetlist.Filtering {
+		return []key.Binding{w.ApplyFilterKeybind, w.ClearFilterKeybind}
+	}
+
+	bindings := []key.Binding{w.CursorUpKeybind, w.CursorDownKeybind,
+		w.ExpandKeybind, w.CollapseKeybind}
+
+	if w.filterable {
+		bindings = append(bindings, w.EnterFilterKeybind)
+	}
+
+	if w.filterState == widgetlist.FilterApplied {
+		bindings = append(bindings, w.ClearFilterKeybind)
+	}
+
+	return bindings
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (w *Widget[T]) FullHelp() [][]key.Binding {
+	columns := [][]key.Binding{
+		{w.CursorUpKeybind, w.CursorDownKeybind, w.ExpandKeybind, w.CollapseKeybind},
+	}
+
+	if w.filterable {
+		columns = append(columns, []key.Binding{w.EnterFilterKeybind,
+			w.ApplyFilterKeybind, w.ClearFilterKeybind})
+	}
+
+	return columns
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (w *Widget[T]) KeyMapTitle() string {
+	return "Tree"
+}
+
+// Public API
+
+// SetRoots replaces the nodes of the tree by the given roots.
+func (w *Widget[T]) SetRoots(roots []T) {
+	w.roots = w.newNodes(nil, roots)
+	w.cursor = 0
+	w.offset = 0
+
+	w.refresh()
+}
+
+// GetRoots returns the root nodes.
+func (w *Widget[T]) GetRoots() []*Node[T] {
+	return w.roots
+}
+
+// GetCursorNode returns the node under the cursor, nil if the tree is empty.
+func (w *Widget[T]) GetCursorNode() *Node[T] {
+	if w.cursor < 0 || w.cursor >= len(w.rows) {
+		return nil
+	}
+
+	return w.rows[w.cursor].node
+}
+
+// SetCursor moves the cursor to the given visible line.
+func (w *Widget[T]) SetCursor(cursor int) {
+	if len(w.rows) == 0 {
+		return
+	}
+
+	cursor = min(cursor, len(w.rows)-1)
+	cursor = max(cursor, 0)
+
+	if cursor == w.cursor {
+		return
+	}
+
+	if w.CursorMovingCallback != nil {
+		w.CursorMovingCallback(w.rows[w.cursor].node.Data)
+	}
+
+	w.cursor = cursor
+	w.clampOffset()
+
+	if w.CursorMovedCallback != nil {
+		w.CursorMovedCallback(w.rows[w.cursor].node.Data)
+	}
+}
+
+// SetCursorNode moves the cursor to the given node, when it is visible.
+func (w *Widget[T]) SetCursorNode(node *Node[T]) {
+	if index := w.rowIndex(node); index >= 0 {
+		w.SetCursor(index)
+	}
+}
+
+// Expand shows the children of the node. The first time, the children are
+// loaded through the returned tea.Cmd and the node expands once they arrive.
+func (w *Widget[T]) Expand(node *Node[T]) tea.Cmd {
+	if node == nil || node.tree != w || !w.provider.HasChildren(node.Data) {
+		return nil
+	}
+
+	if node.loaded {
+		node.expanded = true
+		w.refresh()
+
+		return nil
+	}
+
+	if node.loading {
+		return nil
+	}
+
+	cmd := w.provider.LoadChildren(node.Data)
+
+	if cmd == nil {
+		return nil
+	}
+
+	node.loading = true
+	node.err = nil
+
+	return func() tea.Msg {
+		msg := cmd()
+
+		if m, ok := msg.(ChildrenLoadedMsg[T]); ok {
+			m.node = node
+			return m
+		}
+
+		return msg
+	}
+}
+
+// Collapse hides the children of the node.
+func (w *Widget[T]) Collapse(node *Node[T]) {
+	if node == nil || node.tree != w {
+		return
+	}
+
+	node.expanded = false
+
+	w.refresh()
+}
+
+// SetFilterable defines if the tree can be filtered.
+func (w *Widget[T]) SetFilterable(filterable bool) {
+	w.filterable = filterable
+	w.tiFilter.SetActive(filterable)
+}
+
+// FilterState returns the current filtering state.
+func (w *Widget[T]) FilterState() widgetlist.FilterState {
+	return w.filterState
+}
+
+// SetFilter shows the loaded nodes fuzzy matching s, with their ancestors. An
+// empty s clears the filter.
+func (w *Widget[T]) SetFilter(s string) {
+	if s == "" {
+		w.ClearFilter()
+		return
+	}
+
+	w.tiFilter.SetValue(s)
+	w.tiFilter.OnBlur()
+	w.tiFilter.Resize(w.GetContentSize())
+
+	var nodes []*Node[T]
+	var titles []string
+
+	w.walk(w.roots, func(n *Node[T]) {
+		nodes = append(nodes, n)
+		titles = append(titles, w.provider.Title(n.Data))
+	})
+
+	w.kept = make(map[*Node[T]]bool)
+
+	for _, m := range fuzzy.Find(s, titles) {
+		for n := nodes[m.Index]; n != nil && !w.kept[n]; n = n.parent {
+			w.kept[n] = true
+		}
+	}
+
+	w.filterState = widgetlist.FilterApplied
+
+	w.refresh()
+}
+
+// ClearFilter shows the whole tree again.
+func (w *Widget[T]) ClearFilter() {
+	w.tiFilter.SetValue("")
+	w.tiFilter.OnBlur()
+	w.tiFilter.Resize(w.GetContentSize())
+
+	w.kept = nil
+	w.filterState = widgetlist.Unfiltered
+
+	w.refresh()
+}
+
+// Hidden functions
+
+// updateMouse moves the cursor to the clicked node, or by one node per wheel
+// step. A click on the expansion marker expands or collapses the node.
+func (w *Widget[T]) updateMouse(msg orvyn.MouseMsg) tea.Cmd {
+	if !msg.IsOver(w) {
+		return nil
+	}
+
+	switch {
+	case msg.IsWheelUp():
+		w.SetCursor(w.cursor - 1)
+
+	case msg.IsWheelDown():
+		w.SetCursor(w.cursor + 1)
+
+	case msg.IsLeftClick():
+		local, ok := msg.Local(w)
+
+		if !ok {
+			return nil
+		}
+
+		style := w.GetStyle()
+		x := local.X - style.GetBorderLeftSize() - style.GetPaddingLeft() - style.GetMarginLeft()
+		y := local.Y - style.GetBorderTopSize() - style.GetPaddingTop() - style.GetMarginTop()
+
+		if w.filterable {
+			y -= w.tiFilter.GetSize().Height
+		}
+
+		if y < 0 || y >= w.bodyHeight() || w.offset+y >= len(w.rows) {
+			return nil
+		}
+
+		w.SetCursor(w.offset + y)
+
+		r := w.rows[w.cursor]
+		markerStart := lipgloss.Width(r.prefix)
+
+		if x < markerStart || x >= markerStart+lipgloss.Width(w.marker(r.node)) {
+			return nil
+		}
+
+		if w.isOpen(r.node) {
+			if w.filterState == widgetlist.Unfiltered {
+				w.Collapse(r.node)
+			}
+
+			return nil
+		}
+
+		return w.Expand(r.node)
+	}
+
+	return nil
+}
+
+func (w *Widget[T]) newNodes(parent *Node[T], data []T) []*Node[T] {
+	nodes := make([]*Node[T], 0, len(data))
+
+	for _, d := range data {
+		nodes = append(nodes, &Node[T]{Data: d, tree: w, parent: parent})
+	}
+
+	return nodes
+}
+
+func (w *Widget[T]) childrenLoaded(msg ChildrenLoadedMsg[T]) {
+	node := msg.node
+
+	if node == nil || node.tree != w {
+		return
+	}
+
+	node.loading = false
+
+	if msg.Err != nil {
+		node.err = msg.Err
+		return
+	}
+
+	node.children = w.newNodes(node, msg.Children)
+	node.loaded = true
+	node.expanded = true
+
+	w.refresh()
+}
+
+// walk calls fn on every loaded node, depth first.
+func (w *Widget[T]) walk(nodes []*Node[T], fn func(*Node[T])) {
+	for _, n := range nodes {
+		fn(n)
+		w.walk(n.children, fn)
+	}
+}
+
+// isShown returns true if the node is part of the rows, ignoring collapsed
+// ancestors.
+func (w *Widget[T]) isShown(node *Node[T]) bool {
+	return w.filterState != widgetlist.FilterApplied || w.kept[node]
+}
+
+// isOpen returns true if the children of the node are part of the rows. A
+// filter opens every ancestor of a match.
+func (w *Widget[T]) isOpen(node *Node[T]) bool {
+	if w.filterState == widgetlist.FilterApplied {
+		for _, c := range node.children {
+			if w.kept[c] {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return node.expanded && len(node.children) > 0
+}
+
+// refresh rebuilds the visible rows, keeping the cursor on its node when it
+// is still visible.
+func (w *Widget[T]) refresh() {
+	current := w.GetCursorNode()
+
+	w.rows = w.rows[:0]
+	w.appendRows(w.roots, "", true)
+
+	if index := w.rowIndex(current); index >= 0 {
+		w.cursor = index
+	}
+
+	w.cursor = min(w.cursor, len(w.rows)-1)
+	w.cursor = max(w.cursor, 0)
+
+	w.clampOffset()
+}
+
+// appendRows appends the shown nodes and their open descendants, with the
+// indentation guides leading to them. Roots have no guide.
+func (w *Widget[T]) appendRows(nodes []*Node[T], prefix string, roots bool) {
+	shown := make([]*Node[T], 0, len(nodes))
+
+	for _, n := range nodes {
+		if w.isShown(n) {
+			shown = append(shown, n)
+		}
+	}
+
+	for i, n := range shown {
+		guide, childPrefix := "├─ ", prefix+"│  "
+
+		if i == len(shown)-1 {
+			guide, childPrefix = "└─ ", prefix+"   "
+		}
+
+		if roots {
+			guide, childPrefix = "", ""
+		}
+
+		w.rows = append(w.rows, row[T]{node: n, prefix: prefix + guide})
+
+		if w.isOpen(n) {
+			w.appendRows(n.children, childPrefix, false)
+		}
+	}
+}
+
+func (w *Widget[T]) rowIndex(node *Node[T]) int {
+	if node == nil {
+		return -1
+	}
+
+	for i, r := range w.rows {
+		if r.node == node {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// marker returns the expansion state of the node.
+func (w *Widget[T]) marker(node *Node[T]) string {
+	switch {
+	case !w.provider.HasChildren(node.Data):
+		return "  "
+	case node.loading:
+		return "… "
+	case w.isOpen(node):
+		return "▾ "
+	}
+
+	return "▸ "
+}
+
+// bodyHeight returns the number of rows fitting under the filter.
+func (w *Widget[T]) bodyHeight() int {
+	height := w.GetContentSize().Height
+
+	if w.filterable {
+		height -= w.tiFilter.GetSize().Height
+	}
+
+	return max(height, 1)
+}
+
+// clampOffset keeps the cursor row inside the visible rows.
+func (w *Widget[T]) clampOffset() {
+	height := w.bodyHeight()
+
+	w.offset = min(w.offset, w.cursor)
+	w.offset = max(w.offset, w.cursor-height+1)
+	w.offset = min(w.offset, len(w.rows)-height)
+	w.offset = max(w.offset, 0)
+}
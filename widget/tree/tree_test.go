@@ -0,0 +1,278 @@
+package tree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/orvyntest"
+	"github.com/halsten-dev/orvyn/widget/widgetlist"
+)
+
+// pathProvider serves a file hierarchy where a node is its path.
+type pathProvider struct {
+	children map[string][]string
+	loads    int
+}
+
+func (p *pathProvider) Title(node string) string {
+	return node[strings.LastIndex(node, "/")+1:]
+}
+
+func (p *pathProvider) HasChildren(node string) bool {
+	_, ok := p.children[node]
+
+	return ok
+}
+
+func (p *pathProvider) LoadChildren(node string) tea.Cmd {
+	p.loads++
+
+	return func() tea.Msg {
+		if node == "broken" {
+			return ChildrenLoadedMsg[string]{Err: errors.New("permission denied")}
+		}
+
+		return ChildrenLoadedMsg[string]{Children: p.children[node]}
+	}
+}
+
+func newTestTree(t *testing.T) (*Widget[string], *pathProvider) {
+	t.Helper()
+
+	orvyn.Init()
+
+	p := &pathProvider{children: map[string][]string{
+		"src":     {"src/app", "src/main.go"},
+		"src/app": {"src/app/screen.go", "src/app/theme.go"},
+		"docs":    {"docs/readme.md"},
+		"broken":  nil,
+	}}
+
+	w := New[string](p)
+	w.SetFilterable(false)
+	w.Resize(orvyn.NewSize(40, 12))
+	w.SetRoots([]string{"src", "docs", "broken"})
+
+	return w, p
+}
+
+// send runs the cmds of the tree until none is left, as the program would.
+func send(w *Widget[string], msg tea.Msg) {
+	cmd := w.Update(msg)
+
+	for cmd != nil {
+		cmd = w.Update(cmd())
+	}
+}
+
+func view(w *Widget[string]) string {
+	lines := strings.Split(ansi.Strip(w.Render()), "\n")
+
+	// Drop the border and the trailing padding.
+	for i, l := range lines {
+		r := []rune(l)
+		lines[i] = strings.TrimRight(string(r[1:len(r)-1]), " ")
+	}
+
+	return strings.TrimRight(strings.Join(lines[1:len(lines)-1], "\n"), "\n")
+}
+
+func TestTreeLazyExpand(t *testing.T) {
+	w, p := newTestTree(t)
+
+	send(w, tea.KeyMsg{Type: tea.KeyRight})
+
+	if p.loads != 1 {
+		t.Fatalf("children loaded %d times, want 1", p.loads)
+	}
+
+	send(w, tea.KeyMsg{Type: tea.KeyRight}) // moves to src/app
+	send(w, tea.KeyMsg{Type: tea.KeyRight}) // expands src/app
+
+	want := strings.Join([]string{
+		"▾ src",
+		"├─ ▾ app",
+		"│  ├─   screen.go",
+		"│  └─   theme.go",
+		"└─   main.go",
+		"▸ docs",
+		"▸ broken",
+	}, "\n")
+
+	if got := view(w); got != want {
+		t.Fatalf("tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Collapsing and expanding again does not reload.
+	send(w, tea.KeyMsg{Type: tea.KeyLeft})
+	send(w, tea.KeyMsg{Type: tea.KeyRight})
+
+	if p.loads != 2 {
+		t.Errorf("children loaded %d times, want 2", p.loads)
+	}
+}
+
+func TestTreeCollapseMovesToParent(t *testing.T) {
+	w, _ := newTestTree(t)
+
+	var moved []string
+	w.CursorMovedCallback = func(node string) { moved = append(moved, node) }
+
+	send(w, tea.KeyMsg{Type: tea.KeyRight})
+	send(w, tea.KeyMsg{Type: tea.KeyDown})
+	send(w, tea.KeyMsg{Type: tea.KeyDown})
+	send(w, tea.KeyMsg{Type: tea.KeyLeft})
+
+	if node := w.GetCursorNode(); node.Data != "src" {
+		t.Errorf("cursor on %q, want src", node.Data)
+	}
+
+	want := []string{"src/app", "src/main.go", "src"}
+
+	if strings.Join(moved, ",") != strings.Join(want, ",") {
+		t.Errorf("cursor moved to %v, want %v", moved, want)
+	}
+}
+
+func TestTreeLoadError(t *testing.T) {
+	w, _ := newTestTree(t)
+
+	w.SetCursor(2)
+	send(w, tea.KeyMsg{Type: tea.KeyRight})
+
+	node := w.GetCursorNode()
+
+	if node.GetError() == nil || node.IsExpanded() {
+		t.Fatalf("failed load: error %v, expanded %v", node.GetError(), node.IsExpanded())
+	}
+
+	if !strings.Contains(view(w), "broken permission denied") {
+		t.Errorf("the error is not shown:\n%s", view(w))
+	}
+}
+
+func TestTreeFilterKeepsAncestors(t *testing.T) {
+	w, _ := newTestTree(t)
+
+	send(w, tea.KeyMsg{Type: tea.KeyRight})
+	send(w, tea.KeyMsg{Type: tea.KeyRight})
+	send(w, tea.KeyMsg{Type: tea.KeyRight})
+	send(w, tea.KeyMsg{Type: tea.KeyLeft})
+
+	// src/app is collapsed, its children still match.
+	w.SetFilter("theme")
+
+	want := strings.Join([]string{
+		"▾ src",
+		"└─ ▾ app",
+		"   └─   theme.go",
+	}, "\n")
+
+	if got := view(w); got != want {
+		t.Fatalf("filtered tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	w.ClearFilter()
+
+	if got := view(w); !strings.Contains(got, "▸ app") || strings.Contains(got, "theme.go") {
+		t.Errorf("clearing the filter did not restore the collapsed node:\n%s", got)
+	}
+}
+
+// treeScreen shows a tree next to a second one to move the focus to.
+type treeScreen struct {
+	tree         *Widget[string]
+	other        *Widget[string]
+	focusManager *orvyn.FocusManager
+}
+
+func newTreeScreen(t *testing.T) *treeScreen {
+	t.Helper()
+
+	s := new(treeScreen)
+	s.tree, _ = newTestTree(t)
+	s.tree.SetFilterable(true)
+	s.other, _ = newTestTree(t)
+
+	s.focusManager = orvyn.NewFocusManager()
+	s.focusManager.Add(s.tree)
+	s.focusManager.Add(s.other)
+
+	return s
+}
+
+func (s *treeScreen) OnEnter(any) tea.Cmd {
+	s.focusManager.FocusFirst()
+
+	return nil
+}
+
+func (s *treeScreen) OnExit() any {
+	return nil
+}
+
+func (s *treeScreen) Update(msg tea.Msg) tea.Cmd {
+	return s.focusManager.Update(msg)
+}
+
+func (s *treeScreen) Render() orvyn.Layout {
+	return layout.NewPileLayout(s.tree, s.other)
+}
+
+func TestTreeFilterTakesKeys(t *testing.T) {
+	h := orvyntest.New(t, orvyn.NewSize(80, 20))
+
+	s := newTreeScreen(t)
+
+	h.Register("tree", s)
+	h.App.RegisterKeyMap("tree", s.focusManager)
+	h.Start("tree")
+
+	h.Press("/", "tab", "?")
+
+	h.AssertFocused(s.tree)
+
+	if h.App.IsHelpVisible() || s.tree.FilterState() != widgetlist.Filtering {
+		t.Fatalf("the keys typed in the filter left it")
+	}
+
+	h.Press("esc")
+
+	if s.tree.FilterState() != widgetlist.Unfiltered {
+		t.Errorf("filter state %v after esc, want unfiltered", s.tree.FilterState())
+	}
+
+	h.AssertFocused(s.tree)
+
+	// Leaving the tree drops the filter being typed.
+	h.Press("/")
+	s.focusManager.NextFocus()
+
+	if s.tree.FilterState() != widgetlist.Unfiltered || s.tree.IsInputting() {
+		t.Errorf("filter state %v after the focus left, want unfiltered", s.tree.FilterState())
+	}
+}
+
+func TestTreeChildrenLoadedUnfocused(t *testing.T) {
+	s := newTreeScreen(t)
+	s.focusManager.FocusFirst()
+
+	cmd := s.focusManager.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	if cmd == nil {
+		t.Fatal("expanding src did not load its children")
+	}
+
+	// The focus leaves the tree before the children arrive.
+	s.focusManager.NextFocus()
+	s.focusManager.Update(cmd())
+
+	if got := view(s.tree); !strings.Contains(got, "▾ src") || !strings.Contains(got, "main.go") {
+		t.Errorf("the children loaded unfocused are not shown:\n%s", got)
+	}
+}
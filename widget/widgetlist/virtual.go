@@ -0,0 +1,205 @@
+package widgetlist
+
+import (
+	"github.com/halsten-dev/orvyn"
+)
+
+// NewVirtual creates a new *Widget widgetlist in virtual mode, made for large
+// amounts of data. Only the ListItems of the items shown, and of BufferItems
+// items around them, are constructed: they are recycled with UpdateData as the
+// view moves, and the filter runs over the data through filterValue and
+// ValueFilter. A nil filterValue makes the list not filterable. The list
+// starts in the Scroll display mode.
+// T type represents the type of the item data.
+func NewVirtual[T any](itemConstructor ItemConstructor[T], filterValue func(T) string) *Widget[T] {
+	w := New(itemConstructor)
+
+	w.virtual = true
+	w.filterValue = filterValue
+	w.bound = make(map[int]ListItem[T])
+
+	w.SetFilterable(filterValue != nil)
+	w.SetDisplayMode(Scroll)
+
+	return w
+}
+
+// Hidden functions
+
+// itemAt returns the constructed item of the global index, none in virtual
+// mode when the item is not bound.
+func (w *Widget[T]) itemAt(index int) (ListItem[T], bool) {
+	if w.virtual {
+		item, ok := w.bound[index]
+
+		return item, ok
+	}
+
+	if index < 0 || index >= len(w.listItems) {
+		return nil, false
+	}
+
+	return w.listItems[index], true
+}
+
+// dataAt returns the data of the item at the global index, with the changes
+// made by its constructed item.
+func (w *Widget[T]) dataAt(index int) T {
+	if item, ok := w.itemAt(index); ok {
+		return item.GetData()
+	}
+
+	return w.data[index]
+}
+
+// constructedItems returns the items constructed, bound or not.
+func (w *Widget[T]) constructedItems() []ListItem[T] {
+	if !w.virtual {
+		return w.listItems
+	}
+
+	items := make([]ListItem[T], 0, len(w.bound)+len(w.free))
+
+	for _, item := range w.bound {
+		items = append(items, item)
+	}
+
+	return append(items, w.free...)
+}
+
+// filterItems returns the items matching the filter text.
+func (w *Widget[T]) filterItems(s string) FilteredItems {
+	if !w.virtual {
+		return w.Filter(&w.listItems, s)
+	}
+
+	w.writeBack()
+
+	values := make([]string, len(w.data))
+
+	for i, data := range w.data {
+		values[i] = w.filterValue(data)
+	}
+
+	return w.ValueFilter(values, s)
+}
+
+// bindView constructs or recycles the items shown, and BufferItems items
+// around them, and releases the others. Returns true when it measured items
+// the layout did not know the height of.
+func (w *Widget[T]) bindView() bool {
+	order := w.visibleIndexes()
+	start, end := w.viewBounds()
+
+	start = max(start-w.BufferItems, 0)
+	end = min(end+w.BufferItems, len(order))
+
+	for index, item := range w.bound {
+		if position := w.positionOf(index); position < start || position >= end {
+			w.release(index, item)
+		}
+	}
+
+	for _, index := range order[start:end] {
+		if _, ok := w.bound[index]; !ok {
+			w.bind(index)
+		}
+	}
+
+	w.focusCursorItem()
+
+	return w.layoutStale
+}
+
+// bind gives the data at the global index to a free item, constructing one
+// when none is free, and measures it.
+func (w *Widget[T]) bind(index int) ListItem[T] {
+	var item ListItem[T]
+
+	if n := len(w.free); n > 0 {
+		item = w.free[n-1]
+		w.free = w.free[:n-1]
+
+		item.UpdateData(w.data[index])
+	} else {
+		item = w.itemConstructor(w.data[index])
+	}
+
+	w.bound[index] = item
+	w.measureItem(index)
+
+	return item
+}
+
+// release writes the data of the item back and frees it.
+func (w *Widget[T]) release(index int, item ListItem[T]) {
+	if item == w.focused {
+		w.blurBoundItem()
+	}
+
+	w.data[index] = item.GetData()
+
+	delete(w.bound, index)
+	w.free = append(w.free, item)
+}
+
+func (w *Widget[T]) releaseAll() {
+	for index, item := range w.bound {
+		w.release(index, item)
+	}
+}
+
+// writeBack copies the data of the bound items to the list data.
+func (w *Widget[T]) writeBack() {
+	for index, item := range w.bound {
+		w.data[index] = item.GetData()
+	}
+}
+
+// shiftBound moves the bound items from the global index by delta, after
+// data was inserted or removed.
+func (w *Widget[T]) shiftBound(from, delta int) {
+	bound := make(map[int]ListItem[T], len(w.bound))
+
+	for index, item := range w.bound {
+		if index >= from {
+			index += delta
+		}
+
+		bound[index] = item
+	}
+
+	w.bound = bound
+}
+
+// focusBoundItem gives the focus to the cursor item, binding it when it is
+// not, and takes it from the previous one. No item has the focus while
+// filtering.
+func (w *Widget[T]) focusBoundItem() {
+	if w.globalIndex < 0 || w.globalIndex >= len(w.data) || w.filterState == Filtering {
+		w.blurBoundItem()
+
+		return
+	}
+
+	item, ok := w.bound[w.globalIndex]
+
+	if !ok {
+		item = w.bind(w.globalIndex)
+	}
+
+	if item != w.focused {
+		w.blurBoundItem()
+
+		w.focused = item
+		w.focusManager.SetWidgets([]orvyn.Focusable{item})
+	}
+
+	w.focusManager.Focus(0)
+}
+
+func (w *Widget[T]) blurBoundItem() {
+	w.focusManager.BlurCurrent()
+	w.focusManager.SetWidgets(nil)
+	w.focused = nil
+}
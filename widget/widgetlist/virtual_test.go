@@ -0,0 +1,203 @@
+package widgetlist
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/halsten-dev/orvyn"
+)
+
+func newTestVirtualList(t *testing.T, count int, size orvyn.Size) (*Widget[string], *int) {
+	t.Helper()
+
+	orvyn.Init()
+
+	constructed := new(int)
+
+	w := NewVirtual(func(value string) ListItem[string] {
+		*constructed++
+
+		return SimpleListItemConstructor(value)
+	}, func(value string) string {
+		return value
+	})
+
+	w.SetFilterable(false)
+
+	items := make([]string, 0, count)
+
+	for i := range count {
+		items = append(items, fmt.Sprintf("item %d", i))
+	}
+
+	w.SetItems(items)
+	w.Resize(size)
+
+	return w, constructed
+}
+
+func TestVirtualConstructsOnlyTheView(t *testing.T) {
+	w, constructed := newTestVirtualList(t, 50000, orvyn.NewSize(20, 20))
+
+	// Border, then items of 3 lines: 6 items fit, plus the buffer below.
+	if *constructed != 8 {
+		t.Fatalf("%d items constructed, want 8", *constructed)
+	}
+
+	for range 100 {
+		w.NextItem()
+	}
+
+	if *constructed > 12 {
+		t.Errorf("%d items constructed after scrolling, the items are not recycled", *constructed)
+	}
+
+	if len(w.bound) > 6+2*w.BufferItems {
+		t.Errorf("%d items bound, want at most the view and the buffer", len(w.bound))
+	}
+
+	view := w.Render()
+
+	if !strings.Contains(view, "item 100") || !strings.Contains(view, "item 102") ||
+		strings.Contains(view, "item 103") {
+		t.Errorf("view does not end 2 items after the cursor:\n%s", view)
+	}
+
+	if !w.bound[100].IsFocused() || w.GetSelectedItem() != "item 100" {
+		t.Errorf("cursor item not focused")
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	if w.GetGlobalIndex() != 49999 || !strings.Contains(w.Render(), "item 49999") {
+		t.Errorf("end: global index %d, want 49999", w.GetGlobalIndex())
+	}
+}
+
+func TestVirtualFiltersTheData(t *testing.T) {
+	w, constructed := newTestVirtualList(t, 50000, orvyn.NewSize(20, 20))
+	w.ValueFilter = BasicValueFilter
+
+	w.ApplyFilter("item 4999")
+
+	// item 4999 and item 49990 to 49999.
+	if w.itemsCount() != 11 {
+		t.Fatalf("%d matching items, want 11", w.itemsCount())
+	}
+
+	if w.GetSelectedItem() != "item 4999" {
+		t.Errorf("cursor on %q, want item 4999", w.GetSelectedItem())
+	}
+
+	w.NextItem()
+
+	if w.GetGlobalIndex() != 49990 {
+		t.Errorf("global index %d after moving down, want 49990", w.GetGlobalIndex())
+	}
+
+	if *constructed > 12 {
+		t.Errorf("%d items constructed by the filter, want the view only", *constructed)
+	}
+
+	w.ClearFilter()
+
+	if w.itemsCount() != 50000 || w.GetGlobalIndex() != 0 {
+		t.Errorf("clearing the filter: %d items, cursor on %d", w.itemsCount(), w.GetGlobalIndex())
+	}
+}
+
+func TestVirtualRemoveItem(t *testing.T) {
+	w, _ := newTestVirtualList(t, 10, orvyn.NewSize(20, 20))
+
+	w.SetCursor(9)
+	w.RemoveItem(9)
+
+	if w.Length() != 9 || w.GetGlobalIndex() >= 9 || !w.bound[w.GetGlobalIndex()].IsFocused() {
+		t.Errorf("after removing the last item: %d items, cursor on %d", w.Length(), w.GetGlobalIndex())
+	}
+
+	w.SetCursor(5)
+	w.RemoveItem(2)
+
+	if w.GetSelectedItem() != "item 5" || !w.bound[w.GetGlobalIndex()].IsFocused() {
+		t.Errorf("cursor moved to %q removing an item above it", w.GetSelectedItem())
+	}
+}
+
+func TestVirtualPaginatedGroupsAndSelection(t *testing.T) {
+	w, constructed := newTestVirtualList(t, 1000, orvyn.NewSize(20, 10))
+
+	w.MultiSelect = true
+	w.SetDisplayMode(Paginated)
+	w.SetGroupBy(func(value string) string {
+		if (value[len(value)-1]-'0')%2 == 0 {
+			return "even"
+		}
+
+		return "odd"
+	})
+
+	// Border, then 7 lines above the paginator: a header and two items.
+	if len(w.pages) != 500 || w.pages[1] != 2 {
+		t.Fatalf("%d pages, the second from %d, want 500 from 2", len(w.pages), w.pages[1])
+	}
+
+	w.LastItem()
+	w.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	view := w.Render()
+
+	if w.GetGlobalIndex() != 999 || !w.IsSelected(999) || !w.bound[999].IsFocused() {
+		t.Errorf("end: cursor on %d, want 999 selected and focused", w.GetGlobalIndex())
+	}
+
+	if !strings.Contains(view, "▾ odd (500)") || !strings.Contains(view, "item 997") {
+		t.Errorf("last page without its sticky header and items:\n%s", view)
+	}
+
+	if *constructed > 12 || len(w.bound) > 2+2*w.BufferItems {
+		t.Errorf("%d items constructed, %d bound, want the page and the buffer",
+			*constructed, len(w.bound))
+	}
+}
+
+func TestVirtualVariableHeights(t *testing.T) {
+	orvyn.Init()
+
+	w := NewVirtual(newLinesItem, nil)
+	w.Resize(orvyn.NewSize(20, 14))
+
+	// Even items are 3 lines tall, odd ones 5.
+	items := make([]string, 0, 100)
+
+	for i := range 100 {
+		value := fmt.Sprint(i)
+
+		if i%2 == 1 {
+			value = strings.Repeat(value+"\n", 2) + value
+		}
+
+		items = append(items, value)
+	}
+
+	w.SetItems(items)
+
+	// 12 lines: 0, 1 and 2.
+	if w.offset != 0 || w.viewItems != 3 {
+		t.Errorf("first view from %d with %d items, want 3 from 0", w.offset, w.viewItems)
+	}
+
+	w.LastItem()
+	view := w.Render()
+
+	// 98 and 99, 97 does not fit.
+	if w.offset != 98 || w.viewItems != 2 || strings.Contains(view, "97") {
+		t.Errorf("last view from %d with %d items, want 2 from 98:\n%s", w.offset, w.viewItems, view)
+	}
+
+	if w.heights[97] != 5 || w.heights[50] != 0 {
+		t.Errorf("heights %d and %d, want the buffer measured only", w.heights[97], w.heights[50])
+	}
+}
@@ -1,7 +1,9 @@
 package widgetlist
 
 import (
-	"math"
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/halsten-dev/orvyn/widget/textinput"
@@ -11,10 +13,86 @@ import (
 	"github.com/charmbracelet/bubbles/paginator"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/internal/scrollbar"
+	"github.com/halsten-dev/orvyn/theme"
 	"github.com/halsten-dev/orvyn/widget"
 )
 
+// Actions of the widgetlist widget, see orvyn.Bindings.
+const (
+	CursorUpAction    orvyn.ActionID = "list.cursor_up"
+	CursorDownAction  orvyn.ActionID = "list.cursor_down"
+	EnterFilterAction orvyn.ActionID = "list.filter"
+	ApplyFilterAction orvyn.ActionID = "list.apply_filter"
+	ClearFilterAction orvyn.ActionID = "list.clear_filter"
+	PageUpAction      orvyn.ActionID = "list.page_up"
+	PageDownAction    orvyn.ActionID = "list.page_down"
+	FirstItemAction   orvyn.ActionID = "list.first"
+	LastItemAction    orvyn.ActionID = "list.last"
+
+	CollapseSectionAction orvyn.ActionID = "list.collapse_section"
+	ExpandSectionsAction  orvyn.ActionID = "list.expand_sections"
+
+	ToggleSelectAction    orvyn.ActionID = "list.toggle_select"
+	SelectRangeUpAction   orvyn.ActionID = "list.select_range_up"
+	SelectRangeDownAction orvyn.ActionID = "list.select_range_down"
+	SelectAllAction       orvyn.ActionID = "list.select_all"
+	SelectNoneAction      orvyn.ActionID = "list.select_none"
+)
+
+func init() {
+	orvyn.DefineAction(CursorUpAction,
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"))
+	orvyn.DefineAction(CursorDownAction,
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"))
+	orvyn.DefineAction(EnterFilterAction,
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"))
+	orvyn.DefineAction(ApplyFilterAction,
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "apply filter"))
+	orvyn.DefineAction(ClearFilterAction,
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "clear filter"))
+	orvyn.DefineAction(PageUpAction,
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "page up"))
+	orvyn.DefineAction(PageDownAction,
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdown", "page down"))
+	orvyn.DefineAction(FirstItemAction,
+		key.WithKeys("home"),
+		key.WithHelp("home", "first"))
+	orvyn.DefineAction(LastItemAction,
+		key.WithKeys("end"),
+		key.WithHelp("end", "last"))
+	orvyn.DefineAction(CollapseSectionAction,
+		key.WithKeys("z"),
+		key.WithHelp("z", "fold section"))
+	orvyn.DefineAction(ExpandSectionsAction,
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "unfold all"))
+	orvyn.DefineAction(ToggleSelectAction,
+		key.WithKeys(" "),
+		key.WithHelp("space", "select"))
+	orvyn.DefineAction(SelectRangeUpAction,
+		key.WithKeys("shift+up"),
+		key.WithHelp("shift+↑", "select up"))
+	orvyn.DefineAction(SelectRangeDownAction,
+		key.WithKeys("shift+down"),
+		key.WithHelp("shift+↓", "select down"))
+	orvyn.DefineAction(SelectAllAction,
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("ctrl+a", "select all"))
+	orvyn.DefineAction(SelectNoneAction,
+		key.WithKeys("alt+a"),
+		key.WithHelp("alt+a", "select none"))
+}
+
 type ListItem[T any] interface {
 	orvyn.Focusable
 	orvyn.Renderable
@@ -35,6 +113,10 @@ type FilteredItems []FilteredItem
 
 type ListFilter[T any] func(items *[]ListItem[T], s string) FilteredItems
 
+// ValueFilter filters the items on their filter values, returning the
+// indexes of the matching ones.
+type ValueFilter func(values []string, s string) FilteredItems
+
 // FilterState Taken from github.com/charmbracelet/bubbles/list/list.go
 // FilterState describes the current filtering state on the model.
 type FilterState int
@@ -55,12 +137,38 @@ func (f FilterState) String() string {
 	}[f]
 }
 
+// DisplayMode defines how the list shows the items not fitting its height.
+type DisplayMode int
+
+const (
+	// Paginated shows a page of items at a time, with dots below the items
+	// giving the page.
+	Paginated DisplayMode = iota
+
+	// Scroll moves the view one item at a time to follow the cursor, with a
+	// scrollbar on the right of the items.
+	Scroll
+)
+
 type keybinds struct {
 	cursorUp    key.Binding
 	cursorDown  key.Binding
 	enterFilter key.Binding
 	clearFilter key.Binding
 	applyFilter key.Binding
+	pageUp      key.Binding
+	pageDown    key.Binding
+	firstItem   key.Binding
+	lastItem    key.Binding
+
+	collapseSection key.Binding
+	expandSections  key.Binding
+
+	toggleSelect    key.Binding
+	selectRangeUp   key.Binding
+	selectRangeDown key.Binding
+	selectAll       key.Binding
+	selectNone      key.Binding
 }
 
 // Widget defines a widgetlist widget.
@@ -75,6 +183,8 @@ type Widget[T any] struct {
 	blockCursorMovingCallback bool
 	filterState               FilterState
 
+	blockSelectionChangedCallback bool
+
 	cursor      int
 	globalIndex int
 
@@ -85,18 +195,126 @@ type Widget[T any] struct {
 
 	paginator paginator.Model
 
+	// ScrollOff is the number of items kept visible above and below the cursor
+	// in scroll mode, when the view is tall enough. 2 by default.
+	ScrollOff int
+
+	// pages holds the position of the first listed item of each page, filled
+	// with as many items as their heights allow, and tops the lines before
+	// each listed item, the last entry being all of them. The paginator only
+	// draws the pages. Both are computed again once layoutStale or when the
+	// height of the items changes, see layout.
+	pages        []int
+	tops         []int
+	layoutHeight int
+	layoutStale  bool
+
+	// heights holds the height of every item by global index, 0 until
+	// measured, and measuredSize the size they were measured with. An item
+	// is only measured again when its data changes, after a message, or when
+	// the size or the theme changes, see measureItems.
+	heights      []int
+	measuredSize orvyn.Size
+
+	// mode is the display mode. In scroll mode, pages holds a single page with
+	// all the items, offset is the position of the first item shown,
+	// viewItems the number of items fitting the view from it and scrollbar
+	// true when the items do not all fit.
+	mode      DisplayMode
+	offset    int
+	viewItems int
+	scrollbar bool
+
 	focusManager *orvyn.FocusManager
 
 	itemConstructor ItemConstructor[T]
 
-	maxItemHeight int
-
 	keybinds keybinds
 
+	// renderedItems, renderedIndexes and positions hold the items of the last
+	// Render, their global index and their position in the widget, for the
+	// mouse.
+	renderedItems   []orvyn.Renderable
+	renderedIndexes []int
+	positions       map[orvyn.Renderable]orvyn.Position
+
 	CursorMovingCallback func(int)
 	CursorMovedCallback  func(int)
 
+	// MultiSelect allows selecting several items: space toggles the item under
+	// the cursor, shift+up and shift+down select a range. False by default.
+	MultiSelect bool
+
+	// SelectionMarker is shown before the selected items in multi-select
+	// mode, styled with theme.SelectionMarkerStyleID. "✓ " by default.
+	SelectionMarker string
+
+	// SelectionChangedCallback is called when the multi-selection changes.
+	SelectionChangedCallback func()
+
+	// selected holds the global indexes of the selected items, so the
+	// selection survives filtering.
+	selected map[int]struct{}
+
+	// anchor is the global index a range selection started from, -1 if none,
+	// and rangeBase the selection before the range.
+	anchor    int
+	rangeBase map[int]struct{}
+
 	Filter ListFilter[T]
+
+	// ValueFilter matches the filter text against the filter values of the
+	// data in virtual mode, in place of Filter. FuzzyValueFilter by default.
+	ValueFilter ValueFilter
+
+	// BufferItems is the number of items constructed above and below the page
+	// or the view in virtual mode, ready to show when the cursor moves. 2 by
+	// default.
+	BufferItems int
+
+	// virtual is true for the lists made with NewVirtual, which construct
+	// only the items around the view: data then holds the data of the list,
+	// bound the constructed items by global index, free the constructed items
+	// waiting to be reused and focused the cursor item, the only one in
+	// focusManager. filterValue gives the text the filter matches for a data
+	// and estimatedHeight the height given to the items not measured yet.
+	virtual         bool
+	data            []T
+	bound           map[int]ListItem[T]
+	free            []ListItem[T]
+	focused         ListItem[T]
+	filterValue     func(T) string
+	estimatedHeight int
+
+	// groupBy returns the section of an item data, nil when the list is not
+	// grouped. collapsed holds the keys of the collapsed sections.
+	groupBy   func(T) string
+	collapsed map[string]struct{}
+
+	// sections holds the sections of the listed items in order, headers the
+	// headers drawn before each listed item, the last entry being the ones
+	// after the last item, and sectionOf the section of each listed item.
+	sections  []section
+	headers   [][]int
+	sectionOf []int
+
+	// listed holds the global indexes of the listed items in their order and
+	// listedPositions their position by global index, filtered or grouped
+	// only. Both, and the sections, are computed again once listingStale, see
+	// invalidateListing.
+	listed          []int
+	listedPositions map[int]int
+	listingStale    bool
+
+	// headerRows holds the section of the headers of the last Render, by line
+	// in the widget, for the mouse.
+	headerRows map[int]string
+}
+
+// section is a group of listed items sharing a section key.
+type section struct {
+	key     string
+	indexes []int
 }
 
 // New creates a new *Widget widgetlist and takes an itemConstructor as parameter.
@@ -108,11 +326,24 @@ func New[T any](itemConstructor ItemConstructor[T]) *Widget[T] {
 	w.BaseFocusable = orvyn.NewBaseFocusable(w)
 
 	w.keybinds = keybinds{
-		cursorUp:    key.NewBinding(key.WithKeys("up", "k")),
-		cursorDown:  key.NewBinding(key.WithKeys("down", "j")),
-		enterFilter: key.NewBinding(key.WithKeys("/")),
-		applyFilter: key.NewBinding(key.WithKeys("enter")),
-		clearFilter: key.NewBinding(key.WithKeys("esc")),
+		cursorUp:    orvyn.Binding(CursorUpAction),
+		cursorDown:  orvyn.Binding(CursorDownAction),
+		enterFilter: orvyn.Binding(EnterFilterAction),
+		applyFilter: orvyn.Binding(ApplyFilterAction),
+		clearFilter: orvyn.Binding(ClearFilterAction),
+		pageUp:      orvyn.Binding(PageUpAction),
+		pageDown:    orvyn.Binding(PageDownAction),
+		firstItem:   orvyn.Binding(FirstItemAction),
+		lastItem:    orvyn.Binding(LastItemAction),
+
+		collapseSection: orvyn.Binding(CollapseSectionAction),
+		expandSections:  orvyn.Binding(ExpandSectionsAction),
+
+		toggleSelect:    orvyn.Binding(ToggleSelectAction),
+		selectRangeUp:   orvyn.Binding(SelectRangeUpAction),
+		selectRangeDown: orvyn.Binding(SelectRangeDownAction),
+		selectAll:       orvyn.Binding(SelectAllAction),
+		selectNone:      orvyn.Binding(SelectNoneAction),
 	}
 
 	w.itemConstructor = itemConstructor
@@ -123,6 +354,17 @@ func New[T any](itemConstructor ItemConstructor[T]) *Widget[T] {
 	w.blockCursorMovingCallback = false
 	w.filterState = Unfiltered
 	w.Filter = FuzzyFilter
+	w.ValueFilter = FuzzyValueFilter
+	w.BufferItems = 2
+	w.estimatedHeight = 1
+
+	w.MultiSelect = false
+	w.SelectionMarker = "✓ "
+	w.selected = make(map[int]struct{})
+	w.anchor = -1
+
+	w.collapsed = make(map[string]struct{})
+	w.listingStale = true
 
 	w.cursor = 0
 
@@ -132,6 +374,11 @@ func New[T any](itemConstructor ItemConstructor[T]) *Widget[T] {
 	w.paginator = paginator.New()
 	w.paginator.Type = paginator.Dots
 
+	w.ScrollOff = 2
+	w.mode = Paginated
+	w.viewItems = 1
+	w.pages = []int{0}
+
 	w.focusManager = orvyn.NewFocusManager()
 	w.focusManager.ManageFocusNextPrevKeybind = false
 	w.focusManager.PreviousFocusKeybind = w.keybinds.cursorUp
@@ -141,8 +388,6 @@ func New[T any](itemConstructor ItemConstructor[T]) *Widget[T] {
 	w.BaseRenderable.SetMinSize(orvyn.NewSize(10, 5))
 	w.BaseRenderable.SetPreferredSize(orvyn.NewSize(20, 10))
 
-	w.maxItemHeight = 1
-
 	w.OnBlur()
 
 	return w
@@ -157,6 +402,33 @@ func (w *Widget[T]) Init() tea.Cmd {
 }
 
 func (w *Widget[T]) Update(msg tea.Msg) tea.Cmd {
+	if _, ok := msg.(orvyn.ThemeChangedMsg); ok {
+		w.BaseFocusable.UpdateTheme()
+		w.invalidateHeights()
+
+		cmds := []tea.Cmd{w.tiFilter.Update(msg)}
+
+		for _, item := range w.constructedItems() {
+			cmds = append(cmds, item.Update(msg))
+		}
+
+		return tea.Batch(cmds...)
+	}
+
+	previous := w.globalIndex
+
+	cmd := w.update(msg)
+
+	// The cursor item takes the messages and the focus, the one it left loses
+	// the focus: both can change height.
+	w.measureItem(previous)
+	w.measureItem(w.globalIndex)
+
+	return cmd
+}
+
+// update handles the messages other than orvyn.ThemeChangedMsg.
+func (w *Widget[T]) update(msg tea.Msg) tea.Cmd {
 	if w.filterState == Filtering {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
@@ -182,9 +454,42 @@ func (w *Widget[T]) Update(msg tea.Msg) tea.Cmd {
 
 	isInputting := w.checkInputting()
 
+	if m, ok := msg.(orvyn.MouseMsg); ok && !isInputting && m.IsOver(w) {
+		w.anchor = -1
+
+		switch {
+		case m.IsWheelUp():
+			w.PreviousItem()
+			w.focusCursorItem()
+
+			return nil
+
+		case m.IsWheelDown():
+			w.NextItem()
+			w.focusCursorItem()
+
+			return nil
+
+		case m.IsLeftClick():
+			if section, ok := w.headerAt(m); ok {
+				w.SetSectionCollapsed(section, !w.IsSectionCollapsed(section))
+
+				return nil
+			}
+
+			w.selectItemAt(m)
+		}
+	}
+
 	if !isInputting {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
+			if w.MultiSelect && w.updateSelection(msg) {
+				return nil
+			}
+
+			w.anchor = -1
+
 			switch {
 			case key.Matches(msg, w.focusManager.PreviousFocusKeybind):
 				w.PreviousItem()
@@ -192,6 +497,32 @@ func (w *Widget[T]) Update(msg tea.Msg) tea.Cmd {
 			case key.Matches(msg, w.focusManager.NextFocusKeybind):
 				w.NextItem()
 
+			case key.Matches(msg, w.keybinds.pageUp):
+				w.PageUp()
+
+			case key.Matches(msg, w.keybinds.pageDown):
+				w.PageDown()
+
+			case key.Matches(msg, w.keybinds.firstItem):
+				w.FirstItem()
+
+			case key.Matches(msg, w.keybinds.lastItem):
+				w.LastItem()
+
+			case key.Matches(msg, w.keybinds.collapseSection):
+				if w.groupBy != nil {
+					w.CollapseSection()
+
+					return nil
+				}
+
+			case key.Matches(msg, w.keybinds.expandSections):
+				if w.groupBy != nil {
+					w.ExpandAllSections()
+
+					return nil
+				}
+
 			case key.Matches(msg, w.keybinds.enterFilter):
 				if w.filterable {
 					w.enterFilter()
@@ -211,110 +542,96 @@ func (w *Widget[T]) Update(msg tea.Msg) tea.Cmd {
 		}
 	}
 
+	// In virtual mode, the focus manager only holds the cursor item: it takes
+	// the message once the cursor moved.
+	if w.virtual {
+		w.focusCursorItem()
+	}
+
 	cmd := w.focusManager.Update(msg)
 
-	w.focusManager.Focus(w.globalIndex)
+	w.focusCursorItem()
 
 	return cmd
 }
 
 func (w *Widget[T]) Resize(size orvyn.Size) {
-	w.maxItemHeight = 1
-
 	w.BaseWidget.Resize(size)
 	w.tiFilter.Resize(w.GetContentSize())
 
 	w.paginatorUpdate()
 }
 
+// paginatorUpdate measures the items not measured yet and splits the listed
+// ones into pages, or a single page in scroll mode, then brings the cursor
+// back on its item. Render calls it again to follow the items changing
+// height.
 func (w *Widget[T]) paginatorUpdate() {
-	var perPage int
-
-	contentSize := w.GetContentSize()
-	total := 0
-	calcHeight := 0
-
-	for _, li := range w.listItems {
-		li.Resize(contentSize)
-
-		height := li.GetSize().Height
-
-		w.maxItemHeight = max(w.maxItemHeight, height)
-
-		total += height
+	w.groupSections()
+	w.measureItems()
+	w.layout()
+
+	if w.mode == Scroll {
+		order := w.visibleIndexes()
+
+		// The scrollbar takes a column from the items, measure them again when
+		// it shows or hides.
+		if overflow := w.spanHeight(order, 0, len(order)) > w.itemsHeight(); overflow != w.scrollbar {
+			w.scrollbar = overflow
+			w.measureItems()
+			w.layout()
+		}
 	}
 
-	calcHeight = contentSize.Height
+	w.paginator.TotalPages = len(w.pages)
 
-	if total > contentSize.Height {
-		calcHeight -= 1 // paginator
-	}
+	w.clampCursorState()
 
-	if w.filterable {
-		calcHeight -= w.tiFilter.GetSize().Height
+	if w.mode == Scroll {
+		w.scrollToCursor()
 	}
 
-	perPage = calcHeight / w.maxItemHeight
-	perPage = max(perPage, 1)
-
-	w.paginator.PerPage = perPage
-
-	w.paginator.TotalPages = 0
-
-	if w.filterState == FilterApplied {
-		w.paginator.SetTotalPages(len(w.filteredListItems))
-	} else {
-		w.paginator.SetTotalPages(len(w.listItems))
+	// The items bound in virtual mode are measured: lay them out again until
+	// the view holds no item unknown to the layout.
+	if w.virtual && w.bindView() {
+		w.paginatorUpdate()
 	}
-
-	w.clampCursorState()
 }
 
 // clampCursorState brings the paginator page, the cursor and the global index
 // back inside the bounds of the current list. Both the item count and the
-// per-page capacity change during the widget's life (items replaced or
-// removed, widget resized), and neither paginator.SetTotalPages nor
-// paginator.GetSliceBounds clamp the page index: a page left over from a
-// longer list or a smaller page size makes GetSliceBounds return a start
-// greater than the end, which panics when Render slices the items.
+// page sizes change during the widget's life (items replaced or removed,
+// widget resized, items changing height): the page and the cursor are derived
+// from the global index so the item under the cursor stays under it. When
+// that item is filtered out, the cursor stays at the same place in the list.
 func (w *Widget[T]) clampCursorState() {
-	perPage := max(w.paginator.PerPage, 1)
+	count := w.itemsCount()
 
-	w.paginator.Page = min(w.paginator.Page, w.paginator.TotalPages-1)
+	w.paginator.Page = min(w.paginator.Page, len(w.pages)-1)
 	w.paginator.Page = max(w.paginator.Page, 0)
 
-	if w.filterState == FilterApplied {
-		itemsOnPage := w.paginator.ItemsOnPage(len(w.filteredListItems))
+	if count == 0 {
+		w.paginator.Page = 0
+		w.cursor = 0
+		w.globalIndex = 0
 
-		if itemsOnPage <= 0 {
+		if w.filterState == FilterApplied || w.groupBy != nil {
 			w.cursor = -1
 			w.globalIndex = -1
-
-			return
 		}
 
-		w.cursor = min(w.cursor, itemsOnPage-1)
-		w.cursor = max(w.cursor, 0)
-		w.globalIndex = w.getFilteredGlobalIndex()
-
 		return
 	}
 
-	if len(w.listItems) == 0 {
-		w.cursor = 0
-		w.globalIndex = 0
+	position := w.positionOf(w.globalIndex)
 
-		return
+	if position < 0 {
+		position = w.pages[w.paginator.Page] + w.cursor
 	}
 
-	// Unfiltered, the global index is what the cursor tracks: derive the page
-	// and the on-page cursor from it so the selected item stays visible when
-	// the page size changes.
-	w.globalIndex = min(w.globalIndex, len(w.listItems)-1)
-	w.globalIndex = max(w.globalIndex, 0)
+	w.setPosition(max(min(position, count-1), 0))
 
-	w.paginator.Page = w.globalIndex / perPage
-	w.cursor = w.globalIndex % perPage
+	w.globalIndex = w.getFilteredGlobalIndex()
 }
 
 func (w *Widget[T]) Render() string {
@@ -325,38 +642,99 @@ func (w *Widget[T]) Render() string {
 
 	elements = make([]string, 0)
 
-	if w.filterState == FilterApplied {
-		start, end = w.paginator.GetSliceBounds(len(w.filteredListItems))
-		start = min(start, end)
+	w.renderedItems = w.renderedItems[:0]
+	w.renderedIndexes = w.renderedIndexes[:0]
+	w.positions = make(map[orvyn.Renderable]orvyn.Position)
+
+	style := w.GetStyle()
+	x := style.GetBorderLeftSize() + style.GetPaddingLeft() + style.GetMarginLeft()
+	y := style.GetBorderTopSize() + style.GetPaddingTop() + style.GetMarginTop()
+
+	contentSize := w.GetContentSize()
+	itemsHeight := contentSize.Height
+
+	if w.filterable {
+		filterView := w.tiFilter.Render()
+		elements = append(elements, filterView)
+		y += lipgloss.Height(filterView)
+		itemsHeight -= lipgloss.Height(filterView)
+	}
+
+	w.paginatorUpdate()
+
+	start, end = w.viewBounds()
+
+	order := w.visibleIndexes()
 
-		for i, li := range w.filteredListItems[start:end] {
-			if i > 0 {
+	w.headerRows = make(map[int]string)
+
+	itemX := x
+
+	if w.MultiSelect {
+		itemX += lipgloss.Width(w.SelectionMarker)
+	}
+
+	writeHeaders := func(sections []int) {
+		for _, section := range sections {
+			if b.Len() > 0 {
 				b.WriteString("\n")
 			}
 
-			item := w.listItems[li.Index]
-			b.WriteString(item.Render())
+			b.WriteString(w.renderHeader(section))
+
+			w.headerRows[y] = w.sections[section].key
+			y++
 		}
-	} else {
-		start, end = w.paginator.GetSliceBounds(len(w.listItems))
-		start = min(start, end)
+	}
 
-		for i, li := range w.listItems[start:end] {
-			if i > 0 {
-				b.WriteString("\n")
+	for position := start; position < end; position++ {
+		index := order[position]
+		item, ok := w.itemAt(index)
+
+		if !ok {
+			continue
+		}
+
+		if w.groupBy != nil {
+			headers := w.headers[position]
+
+			// A window starting inside a section starts with its header.
+			if position == start && len(headers) == 0 {
+				headers = []int{w.sectionOf[position]}
 			}
 
-			b.WriteString(li.Render())
+			writeHeaders(headers)
+		}
+
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+
+		view := item.Render()
+
+		if w.MultiSelect {
+			view = lipgloss.JoinHorizontal(lipgloss.Center, w.renderMarker(index), view)
 		}
+
+		b.WriteString(view)
+
+		w.renderedItems = append(w.renderedItems, item)
+		w.renderedIndexes = append(w.renderedIndexes, index)
+		w.positions[item] = orvyn.NewPosition(itemX, y)
+		y += lipgloss.Height(view)
 	}
 
-	contentSize := w.GetContentSize()
+	if w.groupBy != nil && end == len(order) {
+		writeHeaders(w.headers[len(order)])
+	}
 
-	if w.filterable {
-		elements = append(elements, w.tiFilter.Render())
+	itemsView := b.String()
+
+	if w.mode == Scroll && w.scrollbar {
+		itemsView = w.renderScrollbar(itemsView, itemsHeight)
 	}
 
-	elements = append(elements, b.String())
+	elements = append(elements, itemsView)
 
 	paginatorView := ""
 
@@ -364,8 +742,8 @@ func (w *Widget[T]) Render() string {
 		paginatorView = w.paginator.View()
 	}
 
-	// Items use a fixed height, so the rendered content rarely fills the
-	// content area exactly. Absorb the leftover rows as blank space placed
+	// Pages are filled with whole items, so the rendered content rarely fills
+	// the content area exactly. Absorb the leftover rows as blank space placed
 	// before the paginator: items stay at the top and the paginator is pinned
 	// to the bottom instead of leaving an empty gap below it.
 	usedHeight := 0
@@ -397,6 +775,19 @@ func (w *Widget[T]) Render() string {
 		Render(view)
 }
 
+// GetElements returns the items of the last Render, so the list can be
+// hit-tested like a layout, see orvyn.HitTest.
+func (w *Widget[T]) GetElements() []orvyn.Renderable {
+	return w.renderedItems
+}
+
+// GetElementPosition returns the position of a rendered item in the list.
+func (w *Widget[T]) GetElementPosition(e orvyn.Renderable) (orvyn.Position, bool) {
+	position, ok := w.positions[e]
+
+	return position, ok
+}
+
 func (w *Widget[T]) OnFocus() {
 	w.BaseFocusable.OnFocus()
 	widget.UpdatePaginatorTheme(&w.paginator)
@@ -407,7 +798,13 @@ func (w *Widget[T]) OnBlur() {
 	widget.UpdatePaginatorTheme(&w.paginator)
 }
 
+// IsInputting returns true while the filter is typed or an item is in input
+// mode, the keys then being text.
 func (w *Widget[T]) IsInputting() bool {
+	if w.filterState == Filtering {
+		return true
+	}
+
 	inputting := w.checkInputting()
 
 	if inputting {
@@ -418,7 +815,7 @@ func (w *Widget[T]) IsInputting() bool {
 }
 
 func (w *Widget[T]) checkInputting() bool {
-	for _, item := range w.listItems {
+	for _, item := range w.constructedItems() {
 		if item.IsInputting() {
 			return true
 		}
@@ -427,17 +824,83 @@ func (w *Widget[T]) checkInputting() bool {
 	return false
 }
 
+// ShortHelp implements orvyn.KeyMap. Returns the filter keys while filtering,
+// otherwise the bindings of the focused item, the cursor keys and the filter
+// key.
+func (w *Widget[T]) ShortHelp() []key.Binding {
+	if w.filterState == Filtering {
+		return []key.Binding{w.keybinds.applyFilter, w.keybinds.clearFilter}
+	}
+
+	bindings := w.focusManager.ShortHelp()
+
+	if w.checkInputting() {
+		return bindings
+	}
+
+	if w.MultiSelect {
+		bindings = append(bindings, w.keybinds.toggleSelect)
+	}
+
+	if w.filterable {
+		bindings = append(bindings, w.keybinds.enterFilter)
+	}
+
+	if w.filterState == FilterApplied {
+		bindings = append(bindings, w.keybinds.clearFilter)
+	}
+
+	if w.groupBy != nil {
+		bindings = append(bindings, w.keybinds.collapseSection)
+	}
+
+	return bindings
+}
+
+// FullHelp implements orvyn.KeyMap.
+func (w *Widget[T]) FullHelp() [][]key.Binding {
+	bindings := []key.Binding{w.keybinds.cursorUp, w.keybinds.cursorDown,
+		w.keybinds.pageUp, w.keybinds.pageDown, w.keybinds.firstItem, w.keybinds.lastItem}
+
+	if w.filterable {
+		bindings = append(bindings,
+			w.keybinds.enterFilter, w.keybinds.applyFilter, w.keybinds.clearFilter)
+	}
+
+	columns := [][]key.Binding{bindings}
+
+	if w.MultiSelect {
+		columns = append(columns, []key.Binding{
+			w.keybinds.toggleSelect, w.keybinds.selectRangeUp, w.keybinds.selectRangeDown,
+			w.keybinds.selectAll, w.keybinds.selectNone,
+		})
+	}
+
+	if w.groupBy != nil {
+		columns = append(columns, []key.Binding{
+			w.keybinds.collapseSection, w.keybinds.expandSections,
+		})
+	}
+
+	return columns
+}
+
+// KeyMapTitle implements orvyn.TitledKeyMap.
+func (w *Widget[T]) KeyMapTitle() string {
+	return "List"
+}
+
 // Public API
 
 // PreviousItem manages the focus of the previous item.
 func (w *Widget[T]) PreviousItem() {
-	if len(w.listItems) == 0 {
+	if w.Length() == 0 {
 		return
 	}
 
 	w.callCursorMovingCallback(w.globalIndex)
 
-	if w.filterState == FilterApplied {
+	if w.filterState == FilterApplied || w.groupBy != nil {
 		w.previousFilteredItem()
 		return
 	}
@@ -446,7 +909,7 @@ func (w *Widget[T]) PreviousItem() {
 
 	if w.globalIndex < 0 {
 		if w.InfiniteScroll {
-			w.globalIndex = len(w.listItems) - 1
+			w.globalIndex = w.Length() - 1
 			w.moveCursor(w.globalIndex)
 			return
 		}
@@ -460,7 +923,7 @@ func (w *Widget[T]) PreviousItem() {
 }
 
 func (w *Widget[T]) previousFilteredItem() {
-	if len(w.filteredListItems) == 0 {
+	if w.itemsCount() == 0 {
 		return
 	}
 
@@ -469,7 +932,7 @@ func (w *Widget[T]) previousFilteredItem() {
 	if w.cursor < 0 && w.paginator.Page == 0 {
 		if w.InfiniteScroll {
 			w.paginator.Page = w.paginator.TotalPages - 1
-			w.cursor = w.paginator.ItemsOnPage(len(w.filteredListItems)) - 1
+			w.cursor = w.itemsOnPage() - 1
 			w.globalIndex = w.getFilteredGlobalIndex()
 			w.callCursorMovedCallback(w.globalIndex)
 			return
@@ -489,34 +952,34 @@ func (w *Widget[T]) previousFilteredItem() {
 	}
 
 	w.paginator.PrevPage()
-	w.cursor = w.paginator.ItemsOnPage(len(w.filteredListItems)) - 1
+	w.cursor = w.itemsOnPage() - 1
 	w.globalIndex = w.getFilteredGlobalIndex()
 	w.callCursorMovedCallback(w.globalIndex)
 }
 
 // NextItem manages the focus of the next item.
 func (w *Widget[T]) NextItem() {
-	if len(w.listItems) == 0 {
+	if w.Length() == 0 {
 		return
 	}
 
 	w.callCursorMovingCallback(w.globalIndex)
 
-	if w.filterState == FilterApplied {
+	if w.filterState == FilterApplied || w.groupBy != nil {
 		w.nextFilteredItem()
 		return
 	}
 
 	w.globalIndex++
 
-	if w.globalIndex > len(w.listItems)-1 {
+	if w.globalIndex > w.Length()-1 {
 		if w.InfiniteScroll {
 			w.globalIndex = 0
 			w.moveCursor(0)
 			return
 		}
 
-		w.globalIndex = len(w.listItems) - 1
+		w.globalIndex = w.Length() - 1
 		w.moveCursor(w.globalIndex)
 		return
 	}
@@ -525,11 +988,11 @@ func (w *Widget[T]) NextItem() {
 }
 
 func (w *Widget[T]) nextFilteredItem() {
-	if len(w.filteredListItems) == 0 {
+	if w.itemsCount() == 0 {
 		return
 	}
 
-	itemsOnPage := w.paginator.ItemsOnPage(len(w.filteredListItems))
+	itemsOnPage := w.itemsOnPage()
 
 	w.cursor++
 
@@ -562,161 +1025,358 @@ func (w *Widget[T]) nextFilteredItem() {
 }
 
 func (w *Widget[T]) moveCursor(globalIndex int) {
-	var cursor int
-
 	// based on the global index set the cursor and the current page.
 	if globalIndex < 0 {
 		return
 	}
 
-	itemsOnPage := w.paginator.PerPage
-	index := globalIndex
-
-	if w.FilterState() == FilterApplied {
-		for i, fi := range w.filteredListItems {
-			if fi.Index == globalIndex {
-				cursor = i
-				index = i
-				break
-			}
-		}
-	} else {
-		cursor = globalIndex % itemsOnPage
+	if position := w.positionOf(globalIndex); position >= 0 {
+		w.setPosition(position)
 	}
 
-	page := int(math.Floor(float64(index) / float64(itemsOnPage)))
-
-	w.paginator.Page = page
-	w.cursor = cursor
-
 	w.callCursorMovedCallback(w.globalIndex)
 }
 
-func (w *Widget[T]) SetFilterable(filterable bool) {
-	w.filterable = filterable
-	w.tiFilter.SetActive(filterable)
+// PageUp moves the cursor up by a page: the items per page, or the items
+// fitting the view in scroll mode.
+func (w *Widget[T]) PageUp() {
+	w.moveBy(-w.pageItems())
 }
 
-func (w *Widget[T]) SetFilterPlaceholder(s string) {
-	w.tiFilter.Placeholder = s
+// PageDown moves the cursor down by a page, see PageUp.
+func (w *Widget[T]) PageDown() {
+	w.moveBy(w.pageItems())
 }
 
-func (w *Widget[T]) GetGlobalIndex() int {
-	return w.globalIndex
+// FirstItem moves the cursor to the first item, the first matching one while a
+// filter is applied.
+func (w *Widget[T]) FirstItem() {
+	w.moveBy(-w.Length())
 }
 
-// SetItems takes a []T (slice of data) and instantiate all items
-// based on it.
-func (w *Widget[T]) SetItems(items []T) {
-	w.listItems = make([]ListItem[T], 0)
-	focusableList := make([]orvyn.Focusable, 0)
+// LastItem moves the cursor to the last item, the last matching one while a
+// filter is applied.
+func (w *Widget[T]) LastItem() {
+	w.moveBy(w.Length())
+}
 
-	for i := range items {
-		item := w.itemConstructor(items[i])
-		w.listItems = append(w.listItems,
-			item)
-		focusableList = append(focusableList,
-			item)
-	}
+// SetDisplayMode changes how the list shows the items not fitting its height,
+// Paginated by default. The cursor stays on its item.
+func (w *Widget[T]) SetDisplayMode(mode DisplayMode) {
+	globalIndex := w.globalIndex
 
-	w.focusManager.SetWidgets(focusableList)
+	w.mode = mode
+	w.offset = 0
+	w.scrollbar = false
+	w.layoutStale = true
 
-	// paginatorUpdate clamps the global index against the new list, so focus
-	// after it: focusing first would use the index of the previous, possibly
-	// longer list, and Focus ignores an out-of-range index.
 	w.paginatorUpdate()
 
-	w.focusManager.Focus(w.globalIndex)
+	// Unfiltered, paginatorUpdate keeps the cursor on its item. Filtered, it
+	// only clamps the cursor on the page.
+	if w.filterState == FilterApplied && globalIndex >= 0 {
+		w.globalIndex = globalIndex
+		w.moveCursor(globalIndex)
+	}
 }
 
-func (w *Widget[T]) SetCursorMovementKeybinds(cursorUp, cursorDown key.Binding) {
-	w.keybinds.cursorUp = cursorUp
-	w.keybinds.cursorDown = cursorDown
-	w.focusManager.PreviousFocusKeybind = cursorUp
-	w.focusManager.NextFocusKeybind = cursorDown
+// GetDisplayMode returns how the list shows the items not fitting its height.
+func (w *Widget[T]) GetDisplayMode() DisplayMode {
+	return w.mode
 }
 
-func (w *Widget[T]) GetItems() []T {
-	var data []T
+// SetGroupBy groups the items in sections, groupBy returning the section key
+// of an item data. The sections follow the first appearance of their key in
+// the list and each one is shown under a header the cursor skips. nil to stop
+// grouping. The sections are all expanded, the cursor stays on its item.
+func (w *Widget[T]) SetGroupBy(groupBy func(T) string) {
+	w.groupBy = groupBy
+	w.collapsed = make(map[string]struct{})
+	w.invalidateListing()
 
-	for _, li := range w.listItems {
-		data = append(data, li.GetData())
+	w.paginatorUpdate()
+	w.focusCursorItem()
+}
+
+// CollapseSection collapses the section of the cursor item, the cursor moving
+// to the next listed item, or the previous one at the end of the list.
+func (w *Widget[T]) CollapseSection() {
+	if w.groupBy == nil || w.globalIndex < 0 || w.globalIndex >= w.Length() {
+		return
 	}
 
-	return data
+	w.SetSectionCollapsed(w.sectionKey(w.globalIndex), true)
 }
 
-func (w *Widget[T]) GetSelectedItem() T {
-	var none T
-
-	if w.globalIndex < 0 || w.globalIndex >= len(w.listItems) {
-		return none
+// SetSectionCollapsed collapses or expands the section with the given key. The
+// items of a collapsed section are not listed, its header stays.
+func (w *Widget[T]) SetSectionCollapsed(key string, collapsed bool) {
+	if collapsed == w.IsSectionCollapsed(key) {
+		return
 	}
 
-	return w.listItems[w.globalIndex].GetData()
-}
+	globalIndex := w.globalIndex
 
-func (w *Widget[T]) GetItem(index int) T {
-	var none T
+	if collapsed {
+		if w.groupBy != nil {
+			globalIndex = w.cursorOutside(key)
+		}
 
-	if index < 0 || index >= len(w.listItems) {
-		return none
+		w.collapsed[key] = struct{}{}
+	} else {
+		delete(w.collapsed, key)
 	}
 
-	return w.listItems[index].GetData()
+	w.invalidateListing()
+
+	w.moveToIndex(globalIndex)
 }
 
-func (w *Widget[T]) SetItem(index int, data T) {
-	if index < 0 || index >= len(w.listItems) {
+// IsSectionCollapsed returns true if the section with the given key is
+// collapsed.
+func (w *Widget[T]) IsSectionCollapsed(key string) bool {
+	_, ok := w.collapsed[key]
+
+	return ok
+}
+
+// ExpandAllSections expands the collapsed sections.
+func (w *Widget[T]) ExpandAllSections() {
+	if len(w.collapsed) == 0 {
 		return
 	}
 
-	w.listItems[index].UpdateData(data)
+	clear(w.collapsed)
+	w.invalidateListing()
 
-	if w.filterState == FilterApplied {
-		w.filter(w.tiFilter.Value())
-	}
+	w.moveToIndex(w.globalIndex)
 }
 
-func (w *Widget[T]) AppendItem(data T) {
-	w.clearFilter()
-
-	index := len(w.listItems)
+// SetFilterable allows filtering the items, always refused in virtual mode
+// without a filter value function.
+func (w *Widget[T]) SetFilterable(filterable bool) {
+	w.filterable = filterable && (!w.virtual || w.filterValue != nil)
+	w.tiFilter.SetActive(w.filterable)
+}
 
-	widget := w.itemConstructor(data)
+func (w *Widget[T]) SetFilterPlaceholder(s string) {
+	w.tiFilter.Placeholder = s
+}
 
-	w.listItems = append(w.listItems, widget)
-	w.focusManager.Add(widget)
+func (w *Widget[T]) GetGlobalIndex() int {
+	return w.globalIndex
+}
 
-	if w.filterState == FilterApplied {
-		w.filter(w.tiFilter.Value())
+// SetCursor moves the cursor to the item at the global index. Nothing happens
+// when the item is out of range, filtered out or in a collapsed section.
+func (w *Widget[T]) SetCursor(index int) {
+	if index < 0 || index >= w.Length() {
+		return
 	}
 
-	w.paginatorUpdate()
+	if w.positionOf(index) < 0 {
+		return
+	}
 
-	if w.AutoFocusNewItem {
+	if index != w.globalIndex {
 		w.callCursorMovingCallback(w.globalIndex)
 		w.globalIndex = index
-		w.moveCursor(w.globalIndex)
-		w.focusManager.Focus(w.globalIndex)
 	}
+
+	w.moveCursor(index)
+	w.focusCursorItem()
 }
 
-func (w *Widget[T]) InsertItem(index int, data T) {
-	w.clearFilter()
+// ApplyFilter filters the items with the given text, as if the user typed it
+// in the filter input, and moves the cursor to the first match. An empty text
+// clears the filter.
+func (w *Widget[T]) ApplyFilter(s string) {
+	if s == "" {
+		w.ClearFilter()
+		return
+	}
+
+	w.tiFilter.SetValue(s)
+	w.filter(s)
+}
+
+// ClearFilter removes the filter and moves the cursor to the first item.
+func (w *Widget[T]) ClearFilter() {
+	w.clearFilter()
+	w.FocusFirst()
+}
+
+// SetItems takes a []T (slice of data) and instantiate all items
+// based on it.
+func (w *Widget[T]) SetItems(items []T) {
+	if w.virtual {
+		w.releaseAll()
+		w.data = slices.Clone(items)
+	} else {
+		w.listItems = make([]ListItem[T], 0)
+		focusableList := make([]orvyn.Focusable, 0)
+
+		for i := range items {
+			item := w.itemConstructor(items[i])
+			w.listItems = append(w.listItems,
+				item)
+			focusableList = append(focusableList,
+				item)
+		}
+
+		w.focusManager.SetWidgets(focusableList)
+	}
+
+	w.invalidateListing()
+
+	w.heights = make([]int, w.Length())
+
+	w.anchor = -1
+
+	if len(w.selected) > 0 {
+		clear(w.selected)
+		w.selectionChanged()
+	}
+
+	if w.filterState == FilterApplied {
+		w.filteredListItems = w.filterItems(w.tiFilter.Value())
+	}
+
+	// paginatorUpdate clamps the global index against the new list, so focus
+	// after it: focusing first would use the index of the previous, possibly
+	// longer list, and Focus ignores an out-of-range index.
+	w.paginatorUpdate()
+
+	w.focusCursorItem()
+}
+
+func (w *Widget[T]) SetCursorMovementKeybinds(cursorUp, cursorDown key.Binding) {
+	w.keybinds.cursorUp = cursorUp
+	w.keybinds.cursorDown = cursorDown
+	w.focusManager.PreviousFocusKeybind = cursorUp
+	w.focusManager.NextFocusKeybind = cursorDown
+}
+
+func (w *Widget[T]) GetItems() []T {
+	var data []T
+
+	if w.virtual {
+		w.writeBack()
+
+		return slices.Clone(w.data)
+	}
+
+	for _, li := range w.listItems {
+		data = append(data, li.GetData())
+	}
+
+	return data
+}
+
+func (w *Widget[T]) GetSelectedItem() T {
+	var none T
+
+	if w.globalIndex < 0 || w.globalIndex >= w.Length() {
+		return none
+	}
+
+	return w.dataAt(w.globalIndex)
+}
+
+func (w *Widget[T]) GetItem(index int) T {
+	var none T
+
+	if index < 0 || index >= w.Length() {
+		return none
+	}
+
+	return w.dataAt(index)
+}
+
+func (w *Widget[T]) SetItem(index int, data T) {
+	if index < 0 || index >= w.Length() {
+		return
+	}
+
+	if w.virtual {
+		w.data[index] = data
+
+		// Measured again once bound.
+		w.heights[index] = 0
+		w.layoutStale = true
+	}
+
+	if item, ok := w.itemAt(index); ok {
+		item.UpdateData(data)
+		w.measureItem(index)
+	}
+
+	if w.filterState == FilterApplied {
+		w.filter(w.tiFilter.Value())
+	}
+
+	if w.groupBy != nil {
+		w.invalidateListing()
+		w.paginatorUpdate()
+	}
+}
+
+func (w *Widget[T]) AppendItem(data T) {
+	w.clearFilter()
+
+	index := w.Length()
+
+	if w.virtual {
+		w.data = append(w.data, data)
+	} else {
+		widget := w.itemConstructor(data)
+
+		w.listItems = append(w.listItems, widget)
+		w.focusManager.Add(widget)
+	}
+
+	w.invalidateListing()
 
-	length := len(w.listItems)
+	w.heights = append(w.heights, 0)
+
+	w.paginatorUpdate()
+
+	if w.AutoFocusNewItem {
+		w.callCursorMovingCallback(w.globalIndex)
+		w.globalIndex = index
+		w.moveCursor(w.globalIndex)
+		w.focusCursorItem()
+	}
+}
+
+func (w *Widget[T]) InsertItem(index int, data T) {
+	w.clearFilter()
+
+	length := w.Length()
 
 	if length == 0 || index >= length {
 		w.AppendItem(data)
 		return
 	}
 
-	widget := w.itemConstructor(data)
+	if w.virtual {
+		w.shiftBound(index, 1)
+		w.data = slices.Insert(w.data, index, data)
+	} else {
+		widget := w.itemConstructor(data)
+
+		w.listItems = append(w.listItems[:index+1], w.listItems[index:]...)
+		w.listItems[index] = widget
+		w.focusManager.Insert(index, widget)
+	}
 
-	w.listItems = append(w.listItems[:index+1], w.listItems[index:]...)
-	w.listItems[index] = widget
-	w.focusManager.Insert(index, widget)
+	w.invalidateListing()
+
+	w.heights = slices.Insert(w.heights, index, 0)
+
+	if w.shiftSelection(index, 1) {
+		w.selectionChanged()
+	}
 
 	if w.filterState == FilterApplied {
 		w.filter(w.tiFilter.Value())
@@ -728,7 +1388,7 @@ func (w *Widget[T]) InsertItem(index int, data T) {
 		w.callCursorMovingCallback(w.globalIndex)
 		w.globalIndex = index
 		w.moveCursor(w.globalIndex)
-		w.focusManager.Focus(w.globalIndex)
+		w.focusCursorItem()
 	} else {
 		if index <= w.globalIndex {
 			w.NextItem()
@@ -739,34 +1399,47 @@ func (w *Widget[T]) InsertItem(index int, data T) {
 func (w *Widget[T]) MoveItem(startIndex, destIndex int) {
 	w.clearFilter()
 
-	if startIndex < 0 || startIndex >= len(w.listItems) {
+	if startIndex < 0 || startIndex >= w.Length() {
 		return
 	}
 
-	if destIndex < 0 || destIndex > len(w.listItems) {
+	if destIndex < 0 || destIndex > w.Length() {
 		return
 	}
 
-	item := w.listItems[startIndex]
+	data := w.dataAt(startIndex)
+	selected := w.IsSelected(startIndex)
 	w.removeItem(startIndex)
 
 	autoFocus := w.AutoFocusNewItem
 
 	w.AutoFocusNewItem = true
 	w.blockCursorMovingCallback = true
+	w.blockSelectionChangedCallback = true
 
-	w.InsertItem(destIndex, item.GetData())
+	w.InsertItem(destIndex, data)
 
 	w.AutoFocusNewItem = autoFocus
 	w.blockCursorMovingCallback = false
+	w.blockSelectionChangedCallback = false
+
+	if selected {
+		w.selected[w.globalIndex] = struct{}{}
+	}
+
+	if len(w.selected) > 0 {
+		w.selectionChanged()
+	}
 }
 
 func (w *Widget[T]) RemoveItem(index int) {
-	if index < 0 || index >= len(w.listItems) {
+	if index < 0 || index >= w.Length() {
 		return
 	}
 
-	w.removeItem(index)
+	if w.removeItem(index) {
+		w.selectionChanged()
+	}
 
 	if w.filterState == FilterApplied {
 		w.filter(w.tiFilter.Value())
@@ -780,22 +1453,43 @@ func (w *Widget[T]) RemoveItem(index int) {
 
 	w.blockCursorMovingCallback = false
 
-	w.focusManager.Focus(w.globalIndex)
+	w.focusCursorItem()
 }
 
-func (w *Widget[T]) removeItem(index int) {
-	if index < 0 || index >= len(w.listItems) {
-		return
+// removeItem removes the item and returns true if the selection changed.
+func (w *Widget[T]) removeItem(index int) bool {
+	if index < 0 || index >= w.Length() {
+		return false
+	}
+
+	if w.virtual {
+		if item, ok := w.bound[index]; ok {
+			w.release(index, item)
+		}
+
+		w.data = slices.Delete(w.data, index, index+1)
+		w.shiftBound(index+1, -1)
+	} else {
+		w.listItems = append(w.listItems[:index], w.listItems[index+1:]...)
+		w.focusManager.Remove(index)
 	}
 
-	w.listItems = append(w.listItems[:index], w.listItems[index+1:]...)
-	w.focusManager.Remove(index)
+	w.invalidateListing()
+
+	w.heights = slices.Delete(w.heights, index, index+1)
+
+	w.anchor = -1
+
+	selected := w.IsSelected(index)
+	delete(w.selected, index)
+
+	return w.shiftSelection(index+1, -1) || selected
 }
 
 func (w *Widget[T]) FocusFirst() {
-	if w.filterState == FilterApplied {
-		if len(w.filteredListItems) > 0 {
-			w.globalIndex = w.filteredListItems[0].Index
+	if w.filterState == FilterApplied || w.groupBy != nil {
+		if order := w.visibleIndexes(); len(order) > 0 {
+			w.globalIndex = order[0]
 			w.cursor = 0
 		} else {
 			w.cursor = -1
@@ -811,11 +1505,7 @@ func (w *Widget[T]) FocusFirst() {
 	// the filtered-out items, so focusManager.FocusFirst would land on the
 	// first item of the full list instead of the first filtered match, leaving
 	// the focused style desynced from the cursor.
-	if w.globalIndex >= 0 {
-		w.focusManager.Focus(w.globalIndex)
-	} else {
-		w.focusManager.BlurCurrent()
-	}
+	w.focusCursorItem()
 
 	w.moveCursor(w.globalIndex)
 }
@@ -839,20 +1529,34 @@ func (w *Widget[T]) filter(s string) {
 
 	w.tiFilter.OnBlur()
 
-	w.filteredListItems = w.Filter(&w.listItems, s)
+	w.filteredListItems = w.filterItems(s)
 
 	w.filterState = FilterApplied
+	w.invalidateListing()
 
 	w.paginatorUpdate()
 
 	w.FocusFirst()
 }
 
+// BasicFilter keeps the items whose FilterValue contains the text, ignoring
+// the case.
 func BasicFilter[T any](items *[]ListItem[T], s string) FilteredItems {
+	return BasicValueFilter(filterValues(*items), s)
+}
+
+// FuzzyFilter keeps the items whose FilterValue fuzzy matches the text, best
+// matches first.
+func FuzzyFilter[T any](items *[]ListItem[T], s string) FilteredItems {
+	return FuzzyValueFilter(filterValues(*items), s)
+}
+
+// BasicValueFilter is the ValueFilter of BasicFilter.
+func BasicValueFilter(values []string, s string) FilteredItems {
 	var filteredItems FilteredItems
 
-	for i, v := range *items {
-		if strings.Contains(strings.ToLower(v.FilterValue()), strings.ToLower(s)) {
+	for i, v := range values {
+		if strings.Contains(strings.ToLower(v), strings.ToLower(s)) {
 			filteredItems = append(filteredItems, FilteredItem{
 				Index: i,
 			})
@@ -862,15 +1566,11 @@ func BasicFilter[T any](items *[]ListItem[T], s string) FilteredItems {
 	return filteredItems
 }
 
-func FuzzyFilter[T any](items *[]ListItem[T], s string) FilteredItems {
-	var data []string
+// FuzzyValueFilter is the ValueFilter of FuzzyFilter.
+func FuzzyValueFilter(values []string, s string) FilteredItems {
 	var filteredItems FilteredItems
 
-	for _, v := range *items {
-		data = append(data, v.FilterValue())
-	}
-
-	matches := fuzzy.Find(s, data)
+	matches := fuzzy.Find(s, values)
 
 	for _, m := range matches {
 		filteredItems = append(filteredItems, FilteredItem{
@@ -881,22 +1581,104 @@ func FuzzyFilter[T any](items *[]ListItem[T], s string) FilteredItems {
 	return filteredItems
 }
 
+func filterValues[T any](items []ListItem[T]) []string {
+	var data []string
+
+	for _, v := range items {
+		data = append(data, v.FilterValue())
+	}
+
+	return data
+}
+
 // Length returns the count of items in the list.
 func (w *Widget[T]) Length() int {
+	if w.virtual {
+		return len(w.data)
+	}
+
 	return len(w.listItems)
 }
 
+// IsSelected returns true if the item at the global index is selected.
+func (w *Widget[T]) IsSelected(index int) bool {
+	_, ok := w.selected[index]
+
+	return ok
+}
+
+// SetSelected selects or deselects the item at the global index, filtered
+// out or not.
+func (w *Widget[T]) SetSelected(index int, selected bool) {
+	if index < 0 || index >= w.Length() || w.IsSelected(index) == selected {
+		return
+	}
+
+	if selected {
+		w.selected[index] = struct{}{}
+	} else {
+		delete(w.selected, index)
+	}
+
+	w.selectionChanged()
+}
+
+// SelectAll selects every item, the matching ones only while a filter is
+// applied.
+func (w *Widget[T]) SelectAll() {
+	for _, index := range w.visibleIndexes() {
+		w.selected[index] = struct{}{}
+	}
+
+	w.selectionChanged()
+}
+
+// ClearSelection deselects every item, filtered out or not.
+func (w *Widget[T]) ClearSelection() {
+	clear(w.selected)
+
+	w.selectionChanged()
+}
+
+// GetSelectedIndexes returns the global indexes of the selected items,
+// ascending. Without MultiSelect, it returns the index of the cursor item.
+func (w *Widget[T]) GetSelectedIndexes() []int {
+	if !w.MultiSelect {
+		if w.globalIndex < 0 || w.globalIndex >= w.Length() {
+			return nil
+		}
+
+		return []int{w.globalIndex}
+	}
+
+	return slices.Sorted(maps.Keys(w.selected))
+}
+
+// GetSelectedItems returns the data of the selected items, see
+// GetSelectedIndexes.
+func (w *Widget[T]) GetSelectedItems() []T {
+	indexes := w.GetSelectedIndexes()
+	items := make([]T, 0, len(indexes))
+
+	for _, index := range indexes {
+		items = append(items, w.dataAt(index))
+	}
+
+	return items
+}
+
 func (w *Widget[T]) clearFilter() {
 	w.tiFilter.SetValue("")
 	w.tiFilter.OnBlur()
 
 	w.filteredListItems = make(FilteredItems, 0)
 
-	for _, v := range w.listItems {
+	for _, v := range w.constructedItems() {
 		v.SetActive(true)
 	}
 
 	w.filterState = Unfiltered
+	w.invalidateListing()
 
 	w.paginatorUpdate()
 }
@@ -905,18 +1687,39 @@ func (w *Widget[T]) enterFilter() {
 	w.focusManager.BlurCurrent()
 	w.tiFilter.OnFocus()
 	w.filterState = Filtering
+	w.invalidateListing()
 
 	w.paginatorUpdate()
 }
 
 func (w *Widget[T]) getFilteredGlobalIndex() int {
-	index := (w.paginator.Page * w.paginator.PerPage) + w.cursor
+	position := w.pages[w.paginator.Page] + w.cursor
+	order := w.visibleIndexes()
 
-	if index < 0 || index >= len(w.filteredListItems) {
+	if position < 0 || position >= len(order) {
 		return -1
 	}
 
-	return w.filteredListItems[index].Index
+	return order[position]
+}
+
+// selectItemAt moves the cursor to the item under the pointer.
+func (w *Widget[T]) selectItemAt(msg orvyn.MouseMsg) {
+	for n, item := range w.renderedItems {
+		if !msg.IsOver(item) {
+			continue
+		}
+
+		i := w.renderedIndexes[n]
+
+		if i != w.globalIndex {
+			w.callCursorMovingCallback(w.globalIndex)
+			w.globalIndex = i
+			w.moveCursor(i)
+		}
+
+		return
+	}
 }
 
 func (w *Widget[T]) callCursorMovingCallback(index int) {
@@ -924,7 +1727,7 @@ func (w *Widget[T]) callCursorMovingCallback(index int) {
 		return
 	}
 
-	if index < 0 || index >= len(w.listItems) {
+	if index < 0 || index >= w.Length() {
 		return
 	}
 
@@ -934,7 +1737,7 @@ func (w *Widget[T]) callCursorMovingCallback(index int) {
 }
 
 func (w *Widget[T]) callCursorMovedCallback(index int) {
-	if index < 0 || index >= len(w.listItems) {
+	if index < 0 || index >= w.Length() {
 		return
 	}
 
@@ -942,3 +1745,702 @@ func (w *Widget[T]) callCursorMovedCallback(index int) {
 		w.CursorMovedCallback(index)
 	}
 }
+
+// itemSize returns the size given to the items, the content one without the
+// selection marker.
+func (w *Widget[T]) itemSize() orvyn.Size {
+	size := w.GetContentSize()
+
+	if w.MultiSelect {
+		size.Width -= lipgloss.Width(w.SelectionMarker)
+	}
+
+	if w.mode == Scroll && w.scrollbar {
+		size.Width--
+	}
+
+	size.Width = max(size.Width, 0)
+
+	return size
+}
+
+// measureItems measures the items not measured yet, all of them when the
+// size given to the items changed.
+func (w *Widget[T]) measureItems() {
+	if size := w.itemSize(); size != w.measuredSize {
+		w.measuredSize = size
+		w.invalidateHeights()
+	}
+
+	if !w.layoutStale {
+		return
+	}
+
+	for index, height := range w.heights {
+		if height == 0 {
+			w.measureItem(index)
+		}
+	}
+}
+
+// measureItem gives the item at the global index the width of the list and
+// records its height: the one it keeps from the height of the list, or when
+// it takes it all, its preferred one, at least its minimum one.
+func (w *Widget[T]) measureItem(index int) {
+	li, ok := w.itemAt(index)
+
+	if !ok {
+		return
+	}
+
+	size := w.measuredSize
+
+	li.Resize(size)
+
+	height := li.GetSize().Height
+
+	if height >= size.Height {
+		preferred := max(li.GetPreferredSize().Height, li.GetMinSize().Height)
+
+		if preferred < height {
+			li.Resize(orvyn.NewSize(size.Width, preferred))
+			height = li.GetSize().Height
+		}
+	}
+
+	w.estimatedHeight = max(height, 1)
+
+	if w.estimatedHeight != w.heights[index] {
+		w.heights[index] = w.estimatedHeight
+		w.layoutStale = true
+	}
+}
+
+// invalidateHeights makes every item measured again.
+func (w *Widget[T]) invalidateHeights() {
+	clear(w.heights)
+
+	w.layoutStale = true
+}
+
+// layout records the lines before each listed item and splits them into
+// pages, after the listing, the heights or the height of the list changed.
+func (w *Widget[T]) layout() {
+	height := w.itemsHeight()
+
+	if !w.layoutStale && height == w.layoutHeight {
+		return
+	}
+
+	w.layoutStale = false
+	w.layoutHeight = height
+
+	order := w.visibleIndexes()
+
+	w.tops = append(w.tops[:0], 0)
+
+	for position := range order {
+		w.tops = append(w.tops, w.tops[position]+w.rowHeight(order, position))
+	}
+
+	if w.mode == Scroll {
+		w.pages = []int{0}
+
+		return
+	}
+
+	w.pages = w.splitPages(order, height)
+
+	if len(w.pages) > 1 {
+		w.pages = w.splitPages(order, height-1) // paginator
+	}
+}
+
+// itemsHeight returns the height left to the items under the filter input.
+func (w *Widget[T]) itemsHeight() int {
+	height := w.GetContentSize().Height
+
+	if w.filterable {
+		height -= w.tiFilter.GetSize().Height
+	}
+
+	return height
+}
+
+// rowHeight returns the height of the listed item at the position with the
+// section headers drawn before it, and after it for the last one.
+func (w *Widget[T]) rowHeight(order []int, position int) int {
+	height := w.heights[order[position]]
+
+	if height == 0 {
+		height = w.estimatedHeight
+	}
+
+	if w.groupBy == nil {
+		return height
+	}
+
+	height += len(w.headers[position])
+
+	if position == len(order)-1 {
+		height += len(w.headers[len(order)])
+	}
+
+	return height
+}
+
+// stickyRows returns the lines of the sticky header drawn when a page or the
+// view starts at the position, inside a section.
+func (w *Widget[T]) stickyRows(position int) int {
+	if w.groupBy == nil || len(w.headers[position]) > 0 {
+		return 0
+	}
+
+	return 1
+}
+
+// spanHeight returns the height of the listed items from the position from to
+// the one before to, drawn as a page or the view.
+func (w *Widget[T]) spanHeight(order []int, from, to int) int {
+	if from >= to {
+		return 0
+	}
+
+	return w.stickyRows(from) + w.tops[to] - w.tops[from]
+}
+
+// splitPages returns the position of the first item of each page, the
+// listed items filling the pages in order. A page holds at least one item.
+func (w *Widget[T]) splitPages(order []int, height int) []int {
+	pages := []int{0}
+	used := 0
+
+	for position := range order {
+		if used > 0 && used+w.rowHeight(order, position) > height {
+			pages = append(pages, position)
+			used = 0
+		}
+
+		if used == 0 {
+			used = w.stickyRows(position)
+		}
+
+		used += w.rowHeight(order, position)
+	}
+
+	return pages
+}
+
+// pageBounds returns the positions of the first listed item of the current
+// page and of the one after its last.
+func (w *Widget[T]) pageBounds() (int, int) {
+	page := w.paginator.Page
+
+	if page+1 < len(w.pages) {
+		return w.pages[page], w.pages[page+1]
+	}
+
+	return min(w.pages[page], w.itemsCount()), w.itemsCount()
+}
+
+// viewBounds returns the positions of the first listed item shown and of the
+// one after the last: the ones of the page, or of the view in scroll mode.
+func (w *Widget[T]) viewBounds() (int, int) {
+	start, end := w.pageBounds()
+
+	if w.mode == Scroll {
+		start = min(start+w.offset, end)
+		end = min(start+w.viewItems, end)
+	}
+
+	return start, end
+}
+
+// itemsOnPage returns the number of items of the current page.
+func (w *Widget[T]) itemsOnPage() int {
+	start, end := w.pageBounds()
+
+	return end - start
+}
+
+// setPosition moves the page and the cursor to the listed item at the
+// position.
+func (w *Widget[T]) setPosition(position int) {
+	page, found := slices.BinarySearch(w.pages, position)
+
+	if !found {
+		page--
+	}
+
+	w.paginator.Page = max(page, 0)
+	w.cursor = position - w.pages[w.paginator.Page]
+}
+
+// positionOf returns the position of the item at the global index in the
+// listed items, -1 if it is filtered out or in a collapsed section.
+func (w *Widget[T]) positionOf(index int) int {
+	w.groupSections()
+
+	if w.listedPositions == nil {
+		if index < 0 || index >= len(w.listed) {
+			return -1
+		}
+
+		return index
+	}
+
+	position, ok := w.listedPositions[index]
+
+	if !ok {
+		return -1
+	}
+
+	return position
+}
+
+// itemsCount returns the number of listed items, the matching ones only while
+// a filter is applied, without the ones of the collapsed sections.
+func (w *Widget[T]) itemsCount() int {
+	return len(w.visibleIndexes())
+}
+
+// pageItems returns the number of items of a page, at least one.
+func (w *Widget[T]) pageItems() int {
+	if w.mode == Scroll {
+		return max(w.viewItems, 1)
+	}
+
+	return max(w.itemsOnPage(), 1)
+}
+
+// moveBy moves the cursor by delta listed items, stopping on the first and the
+// last ones.
+func (w *Widget[T]) moveBy(delta int) {
+	order := w.visibleIndexes()
+	position := w.positionOf(w.globalIndex)
+
+	if position < 0 {
+		return
+	}
+
+	position = max(min(position+delta, len(order)-1), 0)
+
+	w.SetCursor(order[position])
+}
+
+// scrollToCursor moves the view of the scroll mode the least needed to show
+// the cursor item with ScrollOff items around it, the margin shrinking in a
+// view too small for it, and updates the number of items shown. The cursor
+// is the position of the item in the list, pages holding a single page.
+func (w *Widget[T]) scrollToCursor() {
+	order := w.visibleIndexes()
+	height := w.itemsHeight()
+
+	if len(order) == 0 {
+		w.offset = 0
+		w.viewItems = 0
+
+		return
+	}
+
+	cursor := max(w.cursor, 0)
+	margin := max(min(w.ScrollOff, (w.fittingItems(order, cursor, height)-1)/2), 0)
+
+	w.offset = min(w.offset, max(cursor-margin, 0))
+
+	last := min(cursor+margin, len(order)-1)
+
+	for w.offset < cursor && w.spanHeight(order, w.offset, last+1) > height {
+		w.offset++
+	}
+
+	// Do not leave space under the last item.
+	for w.offset > 0 && w.spanHeight(order, w.offset-1, len(order)) <= height {
+		w.offset--
+	}
+
+	w.offset = min(w.offset, w.lastOffset(order, height))
+	w.viewItems = w.fittingItems(order, w.offset, height)
+}
+
+// fittingItems returns the number of listed items fitting the height from
+// the position, at least one.
+func (w *Widget[T]) fittingItems(order []int, position, height int) int {
+	used := w.stickyRows(position)
+
+	for i := range order[position:] {
+		used += w.rowHeight(order, position+i)
+
+		if used > height {
+			return max(i, 1)
+		}
+	}
+
+	return len(order) - position
+}
+
+// lastOffset returns the smallest position from which the last listed items
+// fill the height.
+func (w *Widget[T]) lastOffset(order []int, height int) int {
+	used := 0
+
+	for position := len(order) - 1; position >= 0; position-- {
+		used += w.rowHeight(order, position)
+
+		if used+w.stickyRows(position) > height {
+			return min(position+1, len(order)-1)
+		}
+	}
+
+	return 0
+}
+
+// renderScrollbar pads the items view to the given height and joins the
+// scrollbar on its right.
+func (w *Widget[T]) renderScrollbar(itemsView string, height int) string {
+	height = max(height, 1)
+
+	// In lines, from the heights of the listed items.
+	order := w.visibleIndexes()
+	contentHeight := max(w.spanHeight(order, 0, len(order)), height)
+	offset := w.spanHeight(order, 0, w.offset)
+
+	itemsView = lipgloss.NewStyle().
+		Width(w.GetContentSize().Width - 1).
+		Height(height).
+		MaxHeight(height).
+		Render(itemsView)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		itemsView, scrollbar.Render(height, contentHeight, offset))
+}
+
+// updateSelection handles the multi-selection keys, returns true if the key
+// was one of them.
+func (w *Widget[T]) updateSelection(msg tea.KeyMsg) bool {
+	switch {
+	case key.Matches(msg, w.keybinds.selectRangeUp):
+		w.selectRange(w.PreviousItem)
+
+	case key.Matches(msg, w.keybinds.selectRangeDown):
+		w.selectRange(w.NextItem)
+
+	case key.Matches(msg, w.keybinds.toggleSelect):
+		w.anchor = -1
+		w.SetSelected(w.globalIndex, !w.IsSelected(w.globalIndex))
+
+	case key.Matches(msg, w.keybinds.selectAll):
+		w.anchor = -1
+		w.SelectAll()
+
+	case key.Matches(msg, w.keybinds.selectNone):
+		w.anchor = -1
+		w.ClearSelection()
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// selectRange moves the cursor and selects the items from the anchor, the
+// cursor item when the range starts, to the new cursor item.
+func (w *Widget[T]) selectRange(move func()) {
+	if w.globalIndex < 0 || w.globalIndex >= w.Length() {
+		return
+	}
+
+	if w.anchor < 0 {
+		w.anchor = w.globalIndex
+		w.rangeBase = maps.Clone(w.selected)
+	}
+
+	move()
+	w.focusCursorItem()
+
+	order := w.visibleIndexes()
+	from := w.positionOf(w.anchor)
+	to := w.positionOf(w.globalIndex)
+
+	if from < 0 || to < 0 {
+		return
+	}
+
+	w.selected = maps.Clone(w.rangeBase)
+
+	for _, index := range order[min(from, to) : max(from, to)+1] {
+		w.selected[index] = struct{}{}
+	}
+
+	w.selectionChanged()
+}
+
+// visibleIndexes returns the global indexes of the listed items, the
+// matching ones only while a filter is applied, in their order. Grouped, the
+// items follow their section, without the ones of the collapsed sections.
+func (w *Widget[T]) visibleIndexes() []int {
+	w.groupSections()
+
+	return w.listed
+}
+
+// listedIndexes returns the global indexes of the listed items, the matching
+// ones only while a filter is applied, in their order.
+func (w *Widget[T]) listedIndexes() []int {
+	var indexes []int
+
+	if w.filterState == FilterApplied {
+		for _, fi := range w.filteredListItems {
+			indexes = append(indexes, fi.Index)
+		}
+
+		return indexes
+	}
+
+	for i := range w.Length() {
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
+
+// shiftSelection moves the selected indexes from the given one by delta,
+// returns true if any moved.
+func (w *Widget[T]) shiftSelection(from, delta int) bool {
+	shifted := make(map[int]struct{}, len(w.selected))
+	moved := false
+
+	for index := range w.selected {
+		if index >= from {
+			index += delta
+			moved = true
+		}
+
+		shifted[index] = struct{}{}
+	}
+
+	w.selected = shifted
+
+	return moved
+}
+
+// renderMarker renders the selection marker of the item, or as many spaces.
+func (w *Widget[T]) renderMarker(index int) string {
+	if !w.IsSelected(index) {
+		return strings.Repeat(" ", lipgloss.Width(w.SelectionMarker))
+	}
+
+	return orvyn.GetTheme().Style(theme.SelectionMarkerStyleID).Render(w.SelectionMarker)
+}
+
+func (w *Widget[T]) selectionChanged() {
+	if w.blockSelectionChangedCallback {
+		return
+	}
+
+	if w.SelectionChangedCallback != nil {
+		w.SelectionChangedCallback()
+	}
+}
+
+// group returns the sections of the listed items, in the order of the first
+// appearance of their key.
+func (w *Widget[T]) group() []section {
+	var sections []section
+
+	positions := make(map[string]int)
+
+	for _, index := range w.listedIndexes() {
+		key := w.groupBy(w.dataAt(index))
+		position, ok := positions[key]
+
+		if !ok {
+			position = len(sections)
+			positions[key] = position
+			sections = append(sections, section{key: key})
+		}
+
+		sections[position].indexes = append(sections[position].indexes, index)
+	}
+
+	return sections
+}
+
+// groupSections records the listed items and, grouped, the sections and where
+// their headers are drawn: a header comes before the first item of its
+// section, the ones of the collapsed sections before the next listed item or
+// after the last one. Nothing is done until the listing is stale again.
+func (w *Widget[T]) groupSections() {
+	if !w.listingStale {
+		return
+	}
+
+	w.listingStale = false
+
+	w.sections = nil
+	w.headers = nil
+	w.sectionOf = nil
+	w.listedPositions = nil
+
+	if w.groupBy == nil {
+		w.listed = w.listedIndexes()
+
+		if w.filterState == FilterApplied {
+			w.indexPositions()
+		}
+
+		return
+	}
+
+	w.sections = w.group()
+	w.listed = nil
+
+	var pending []int
+
+	for i, section := range w.sections {
+		pending = append(pending, i)
+
+		if w.IsSectionCollapsed(section.key) {
+			continue
+		}
+
+		w.headers = append(w.headers, pending)
+		pending = nil
+
+		w.listed = append(w.listed, section.indexes...)
+
+		for range section.indexes {
+			w.sectionOf = append(w.sectionOf, i)
+		}
+
+		// Only the first item of the section has headers before it.
+		for range len(section.indexes) - 1 {
+			w.headers = append(w.headers, nil)
+		}
+	}
+
+	w.headers = append(w.headers, pending)
+
+	w.indexPositions()
+}
+
+// indexPositions records the position of the listed items by global index.
+func (w *Widget[T]) indexPositions() {
+	w.listedPositions = make(map[int]int, len(w.listed))
+
+	for position, index := range w.listed {
+		w.listedPositions[index] = position
+	}
+}
+
+// invalidateListing makes the listed items and the sections computed again,
+// after the items, the filter, the grouping or the collapsed sections
+// changed.
+func (w *Widget[T]) invalidateListing() {
+	w.listingStale = true
+	w.layoutStale = true
+}
+
+// renderHeader returns the header line of the section at the index.
+func (w *Widget[T]) renderHeader(index int) string {
+	section := w.sections[index]
+	arrow := "▾"
+
+	if w.IsSectionCollapsed(section.key) {
+		arrow = "▸"
+	}
+
+	width := w.itemSize().Width
+
+	if w.MultiSelect {
+		width += lipgloss.Width(w.SelectionMarker)
+	}
+
+	header := fmt.Sprintf("%s %s (%d)", arrow, section.key, len(section.indexes))
+
+	return orvyn.GetTheme().Style(theme.SectionHeaderStyleID).
+		Width(width).
+		MaxWidth(width).
+		Render(ansi.Truncate(header, width, "…"))
+}
+
+// headerAt returns the key of the section whose header is under the pointer.
+func (w *Widget[T]) headerAt(msg orvyn.MouseMsg) (string, bool) {
+	local, ok := msg.Local(w)
+
+	if !ok {
+		return "", false
+	}
+
+	key, ok := w.headerRows[local.Y]
+
+	return key, ok
+}
+
+// cursorOutside returns the global index of the listed item the cursor moves
+// to when the section with the given key collapses: the next item outside of
+// the section, or the previous one, -1 if there is none. The cursor stays when
+// it is not in the section.
+func (w *Widget[T]) cursorOutside(key string) int {
+	order := w.visibleIndexes()
+	position := w.positionOf(w.globalIndex)
+
+	if position < 0 || w.sections[w.sectionOf[position]].key != key {
+		return w.globalIndex
+	}
+
+	// The items of a section are listed together.
+	for i := position + 1; i < len(order); i++ {
+		if w.sectionOf[i] != w.sectionOf[position] {
+			return order[i]
+		}
+	}
+
+	for i := position - 1; i >= 0; i-- {
+		if w.sectionOf[i] != w.sectionOf[position] {
+			return order[i]
+		}
+	}
+
+	return -1
+}
+
+// sectionKey returns the section key of the item at the global index.
+func (w *Widget[T]) sectionKey(index int) string {
+	return w.groupBy(w.dataAt(index))
+}
+
+// moveToIndex lays the sections out again and moves the cursor to the item at
+// the global index, calling the cursor callbacks when it changes.
+func (w *Widget[T]) moveToIndex(globalIndex int) {
+	previous := w.globalIndex
+
+	if globalIndex != previous {
+		w.callCursorMovingCallback(previous)
+		w.globalIndex = globalIndex
+	}
+
+	w.paginatorUpdate()
+	w.focusCursorItem()
+
+	if w.globalIndex != previous {
+		w.callCursorMovedCallback(w.globalIndex)
+	}
+}
+
+// focusCursorItem gives the focus to the cursor item, none when the cursor is
+// on no item.
+func (w *Widget[T]) focusCursorItem() {
+	if w.virtual {
+		w.focusBoundItem()
+
+		return
+	}
+
+	if w.globalIndex >= 0 && w.globalIndex < w.Length() {
+		w.focusManager.Focus(w.globalIndex)
+	} else {
+		w.focusManager.BlurCurrent()
+	}
+}
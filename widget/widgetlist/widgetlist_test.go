@@ -2,10 +2,14 @@ package widgetlist
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/halsten-dev/orvyn"
+	"github.com/halsten-dev/orvyn/layout"
+	"github.com/halsten-dev/orvyn/orvyntest"
 )
 
 func newTestList(t *testing.T, count int, size orvyn.Size) *Widget[string] {
@@ -37,11 +41,11 @@ func checkBounds(t *testing.T, w *Widget[string]) {
 		length = len(w.filteredListItems)
 	}
 
-	start, end := w.paginator.GetSliceBounds(length)
+	start, end := w.pageBounds()
 
-	if start > end {
-		t.Fatalf("slice bounds out of range [%d:%d] (len %d, page %d, perPage %d, totalPages %d)",
-			start, end, length, w.paginator.Page, w.paginator.PerPage, w.paginator.TotalPages)
+	if start > end || end > length {
+		t.Fatalf("slice bounds out of range [%d:%d] (len %d, page %d, pages %v)",
+			start, end, length, w.paginator.Page, w.pages)
 	}
 }
 
@@ -197,3 +201,536 @@ func TestFilteredListShrinks(t *testing.T) {
 
 	w.Render()
 }
+
+// click returns a left click at the given cell, hit-tested against the last
+// Render the way the App does.
+func click(w *Widget[string], x, y int) orvyn.MouseMsg {
+	return orvyn.MouseMsg{
+		MouseMsg: tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft},
+		Hits:     orvyn.HitTest(w, orvyn.NewPosition(0, 0), orvyn.NewPosition(x, y)),
+	}
+}
+
+func TestClickSelectsItem(t *testing.T) {
+	w := newTestList(t, 20, orvyn.NewSize(20, 12))
+
+	var moved []int
+	w.CursorMovedCallback = func(index int) { moved = append(moved, index) }
+
+	w.Render()
+
+	// Border, then items of 3 lines: item 2 starts on line 7.
+	w.Update(click(w, 3, 8))
+
+	if w.GetGlobalIndex() != 2 {
+		t.Fatalf("global index = %d after the click, want 2", w.GetGlobalIndex())
+	}
+
+	if !w.listItems[2].IsFocused() || w.listItems[0].IsFocused() {
+		t.Errorf("the focus did not follow the click")
+	}
+
+	if len(moved) != 1 || moved[0] != 2 {
+		t.Errorf("cursor moved callbacks = %v, want [2]", moved)
+	}
+
+	wheel := click(w, 3, 8)
+	wheel.Button = tea.MouseButtonWheelDown
+
+	w.Update(wheel)
+
+	if w.GetGlobalIndex() != 3 {
+		t.Errorf("global index = %d after a wheel step, want 3", w.GetGlobalIndex())
+	}
+}
+
+func TestMultiSelect(t *testing.T) {
+	w := newTestList(t, 10, orvyn.NewSize(20, 40))
+	w.MultiSelect = true
+
+	changes := 0
+	w.SelectionChangedCallback = func() { changes++ }
+
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	w.Update(space)
+	w.Update(tea.KeyMsg{Type: tea.KeyDown})
+	w.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	// Range from item 2 down to item 4, then back up to item 3.
+	w.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	w.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	w.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+
+	if got := fmt.Sprint(w.GetSelectedIndexes()); got != "[0 2 3]" {
+		t.Errorf("selected %s, want [0 2 3]", got)
+	}
+
+	if changes != 4 {
+		t.Errorf("%d selection changes, want 4", changes)
+	}
+
+	// Space toggles, ending the range.
+	w.Update(space)
+
+	if w.IsSelected(3) {
+		t.Error("space did not deselect the cursor item")
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+
+	if len(w.GetSelectedItems()) != 10 {
+		t.Errorf("%d items selected after select all", len(w.GetSelectedItems()))
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}, Alt: true})
+
+	if len(w.GetSelectedItems()) != 0 {
+		t.Errorf("%d items selected after select none", len(w.GetSelectedItems()))
+	}
+}
+
+func TestSelectionSurvivesFiltering(t *testing.T) {
+	w := newTestList(t, 12, orvyn.NewSize(20, 40))
+	w.MultiSelect = true
+
+	w.SetSelected(1, true)
+	w.SetSelected(5, true)
+
+	w.ApplyFilter("item 1")
+
+	// item 1, item 10, item 11: select all selects the matches only.
+	w.SelectAll()
+	w.ClearFilter()
+
+	want := []string{"item 1", "item 5", "item 10", "item 11"}
+
+	if got := w.GetSelectedItems(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("selected %v, want %v", got, want)
+	}
+
+	// The selection follows the items when others are removed or inserted.
+	w.RemoveItem(0)
+	w.InsertItem(2, "new")
+
+	want = []string{"item 1", "item 5", "item 10", "item 11"}
+
+	if got := w.GetSelectedItems(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("selected %v after remove and insert, want %v", got, want)
+	}
+
+	w.MoveItem(0, 4)
+
+	if got := w.GetSelectedItems(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("selected %v after move, want %v", got, want)
+	}
+}
+
+func TestScrollMode(t *testing.T) {
+	w := newTestList(t, 30, orvyn.NewSize(20, 20))
+	w.SetDisplayMode(Scroll)
+
+	// Border, then items of 3 lines: 6 items fit, the cursor keeps 2 items
+	// below it.
+	view := w.Render()
+
+	if !strings.Contains(view, "item 5") || strings.Contains(view, "item 6") {
+		t.Fatalf("first view does not list items 0 to 5:\n%s", view)
+	}
+
+	if !strings.Contains(view, "┃") || strings.Contains(view, "•") {
+		t.Errorf("scrollbar not drawn instead of the dots:\n%s", view)
+	}
+
+	for range 3 {
+		w.NextItem()
+	}
+
+	if w.Render(); w.offset != 0 {
+		t.Errorf("offset = %d with the cursor on item 3, want 0", w.offset)
+	}
+
+	for i := range 3 {
+		w.NextItem()
+
+		if w.Render(); w.offset != i+1 {
+			t.Errorf("offset = %d with the cursor on item %d, want %d", w.offset, w.GetGlobalIndex(), i+1)
+		}
+	}
+
+	keys := []struct {
+		key    tea.KeyType
+		index  int
+		offset int
+	}{
+		{tea.KeyEnd, 29, 24},
+		{tea.KeyPgUp, 23, 21},
+		{tea.KeyHome, 0, 0},
+		{tea.KeyPgDown, 6, 3},
+	}
+
+	for _, k := range keys {
+		w.Update(tea.KeyMsg{Type: k.key})
+		w.Render()
+
+		if w.GetGlobalIndex() != k.index || w.offset != k.offset {
+			t.Errorf("%s: index %d, offset %d, want %d, %d",
+				k.key, w.GetGlobalIndex(), w.offset, k.index, k.offset)
+		}
+
+		if !w.listItems[k.index].IsFocused() {
+			t.Errorf("%s: item %d not focused", k.key, k.index)
+		}
+	}
+
+	w.SetDisplayMode(Paginated)
+
+	if w.GetGlobalIndex() != 6 || w.paginator.Page != 1 {
+		t.Errorf("back to paginated: index %d, page %d, want 6, 1", w.GetGlobalIndex(), w.paginator.Page)
+	}
+}
+
+func TestPaginatedPageKeys(t *testing.T) {
+	w := newTestList(t, 20, orvyn.NewSize(20, 12))
+
+	perPage := w.itemsOnPage()
+
+	w.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+
+	if w.GetGlobalIndex() != perPage || w.paginator.Page != 1 {
+		t.Errorf("pgdown: index %d, page %d, want %d, 1", w.GetGlobalIndex(), w.paginator.Page, perPage)
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	if w.GetGlobalIndex() != 19 || !w.paginator.OnLastPage() {
+		t.Errorf("end: index %d, page %d, want 19 on the last page", w.GetGlobalIndex(), w.paginator.Page)
+	}
+}
+
+// linesItem is a list item as tall as its lines, given through its preferred
+// size.
+type linesItem struct {
+	SimpleListItem
+}
+
+func newLinesItem(value string) ListItem[string] {
+	item := new(linesItem)
+	item.SimpleListItem = *SimpleListItemConstructor(value).(*SimpleListItem)
+	item.BaseFocusable = orvyn.NewBaseFocusable(item)
+
+	return item
+}
+
+func (l *linesItem) Resize(size orvyn.Size) {
+	l.BaseWidget.Resize(size)
+}
+
+func (l *linesItem) GetPreferredSize() orvyn.Size {
+	return orvyn.NewSize(10, strings.Count(l.value, "\n")+3)
+}
+
+func TestVariableHeightItems(t *testing.T) {
+	orvyn.Init()
+
+	w := New(newLinesItem)
+	w.SetFilterable(false)
+	w.Resize(orvyn.NewSize(20, 14))
+
+	// Heights 3, 5, 3, 3, 7 and 3, in 11 lines: 12 less the paginator.
+	w.SetItems([]string{"a", "b\nb\nb", "c", "d", "e\ne\ne\ne\ne", "f"})
+
+	if !slices.Equal(w.pages, []int{0, 3, 5}) {
+		t.Fatalf("pages start at %v, want [0 3 5]", w.pages)
+	}
+
+	for range 4 {
+		w.NextItem()
+	}
+
+	view := w.Render()
+
+	if w.paginator.Page != 1 || w.cursor != 1 ||
+		!strings.Contains(view, "d") || strings.Contains(view, "f") {
+		t.Errorf("page %d, cursor %d, want the second page with d and e:\n%s",
+			w.paginator.Page, w.cursor, view)
+	}
+
+	w.NextItem()
+
+	// e shrinks to one line: d, e and f share the second page.
+	w.SetItem(4, "e")
+	w.Render()
+
+	if !slices.Equal(w.pages, []int{0, 3}) || w.paginator.Page != 1 || w.cursor != 2 {
+		t.Errorf("pages %v, page %d, cursor %d, want f third on the second page",
+			w.pages, w.paginator.Page, w.cursor)
+	}
+
+	if w.GetSelectedItem() != "f" {
+		t.Errorf("cursor on %q after e shrank, want f", w.GetSelectedItem())
+	}
+
+	w.SetDisplayMode(Scroll)
+	w.Render()
+
+	// 12 lines: c, d, e and f.
+	if w.offset != 2 || w.viewItems != 4 {
+		t.Errorf("scroll view from %d with %d items, want 4 from 2", w.offset, w.viewItems)
+	}
+}
+
+// resizedItem is a linesItem recording its resizes.
+type resizedItem struct {
+	*linesItem
+
+	resized map[string]int
+}
+
+func (r *resizedItem) Resize(size orvyn.Size) {
+	r.resized[r.value]++
+	r.linesItem.Resize(size)
+}
+
+func TestHeightsKeptBetweenRenders(t *testing.T) {
+	orvyn.Init()
+
+	resized := make(map[string]int)
+
+	w := New(func(value string) ListItem[string] {
+		return &resizedItem{linesItem: newLinesItem(value).(*linesItem), resized: resized}
+	})
+	w.SetFilterable(false)
+	w.Resize(orvyn.NewSize(20, 14))
+	w.SetItems([]string{"a", "b\nb\nb", "c", "d", "e\ne\ne\ne\ne", "f"})
+	w.Render()
+
+	clear(resized)
+	w.Render()
+	w.Render()
+
+	if len(resized) > 0 {
+		t.Errorf("items %v measured again rendering", resized)
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyDown})
+	w.Render()
+
+	if len(resized) != 2 || resized["a"] == 0 || resized["b\nb\nb"] == 0 {
+		t.Errorf("items %v measured moving down, want a and b only", resized)
+	}
+
+	clear(resized)
+	w.SetItem(4, "e")
+	w.Render()
+
+	if len(resized) != 1 || resized["e"] == 0 || !slices.Equal(w.pages, []int{0, 3}) {
+		t.Errorf("items %v measured changing e, pages %v, want e only and [0 3]",
+			resized, w.pages)
+	}
+
+	clear(resized)
+	w.Resize(orvyn.NewSize(30, 14))
+
+	if len(resized) != 6 {
+		t.Errorf("items %v measured resizing the list, want all of them", resized)
+	}
+}
+
+func TestGroupedSections(t *testing.T) {
+	w := newTestList(t, 12, orvyn.NewSize(20, 20))
+	w.SetDisplayMode(Scroll)
+
+	// Even items first: item 0 comes first.
+	w.SetGroupBy(func(value string) string {
+		if (value[len(value)-1]-'0')%2 == 0 {
+			return "even"
+		}
+
+		return "odd"
+	})
+
+	view := w.Render()
+
+	if !strings.Contains(view, "▾ even (6)") || !w.listItems[0].IsFocused() {
+		t.Fatalf("first view does not start with the even section:\n%s", view)
+	}
+
+	w.NextItem()
+
+	if w.GetGlobalIndex() != 2 {
+		t.Errorf("global index %d after moving down, want 2", w.GetGlobalIndex())
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyEnd})
+
+	// Border, then 18 lines: items 3 to 11 and the header of their section
+	// kept at the top.
+	view = w.Render()
+	checkBounds(t, w)
+
+	if w.GetGlobalIndex() != 11 || w.offset != 7 {
+		t.Errorf("end: index %d, offset %d, want 11, 7", w.GetGlobalIndex(), w.offset)
+	}
+
+	if !strings.Contains(view, "▾ odd (6)") || strings.Contains(view, "even") {
+		t.Errorf("odd section header not sticky:\n%s", view)
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+
+	if !w.IsSectionCollapsed("odd") || w.GetGlobalIndex() != 10 || !w.listItems[10].IsFocused() {
+		t.Fatalf("collapsing the cursor section: cursor on %d, want 10", w.GetGlobalIndex())
+	}
+
+	w.NextItem()
+
+	if w.GetGlobalIndex() != 10 {
+		t.Errorf("cursor moved to %d in a collapsed section", w.GetGlobalIndex())
+	}
+
+	view = w.Render()
+	line := slices.IndexFunc(strings.Split(view, "\n"), func(l string) bool {
+		return strings.Contains(l, "▸ odd (6)")
+	})
+
+	if line < 0 {
+		t.Fatalf("collapsed header not drawn:\n%s", view)
+	}
+
+	w.Update(click(w, 3, line))
+
+	if w.IsSectionCollapsed("odd") || w.GetGlobalIndex() != 10 {
+		t.Errorf("clicking the header did not expand the section")
+	}
+
+	w.SetSectionCollapsed("even", true)
+
+	if w.GetGlobalIndex() != 1 {
+		t.Errorf("collapsing the even section: cursor on %d, want 1", w.GetGlobalIndex())
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+
+	if w.IsSectionCollapsed("even") || w.GetGlobalIndex() != 1 || w.itemsCount() != 12 {
+		t.Errorf("unfolding: cursor on %d, %d items", w.GetGlobalIndex(), w.itemsCount())
+	}
+}
+
+func TestGroupedSectionsPaginated(t *testing.T) {
+	w := newTestList(t, 12, orvyn.NewSize(20, 10))
+
+	calls := 0
+
+	w.SetGroupBy(func(value string) string {
+		calls++
+
+		if (value[len(value)-1]-'0')%2 == 0 {
+			return "even"
+		}
+
+		return "odd"
+	})
+
+	// Border, then 7 lines above the paginator: a header and two items.
+	if !slices.Equal(w.pages, []int{0, 2, 4, 6, 8, 10}) {
+		t.Fatalf("pages start at %v, want [0 2 4 6 8 10]", w.pages)
+	}
+
+	w.NextItem()
+	w.NextItem()
+
+	calls = 0
+	view := w.Render()
+	w.Render()
+
+	if calls != 0 {
+		t.Errorf("groupBy called %d times rendering, want the sections kept", calls)
+	}
+
+	if w.GetGlobalIndex() != 4 || w.paginator.Page != 1 || w.cursor != 0 {
+		t.Errorf("index %d, page %d, cursor %d, want 4 first on the second page",
+			w.GetGlobalIndex(), w.paginator.Page, w.cursor)
+	}
+
+	if !strings.Contains(view, "▾ even (6)") || !strings.Contains(view, "item 6") {
+		t.Errorf("even section header not sticky on the second page:\n%s", view)
+	}
+
+	w.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+
+	// Both headers come before item 1, the odd items fill the other pages.
+	if !slices.Equal(w.pages, []int{0, 1, 3, 5}) || w.GetGlobalIndex() != 1 {
+		t.Fatalf("collapsing: pages %v, cursor on %d, want [0 1 3 5] on 1",
+			w.pages, w.GetGlobalIndex())
+	}
+
+	view = w.Render()
+
+	if w.paginator.Page != 0 || !strings.Contains(view, "▸ even (6)") ||
+		!strings.Contains(view, "▾ odd (6)") || !w.listItems[1].IsFocused() {
+		t.Errorf("page %d after collapsing, want both headers and item 1:\n%s",
+			w.paginator.Page, view)
+	}
+
+	w.LastItem()
+
+	if w.GetGlobalIndex() != 11 || w.paginator.Page != 3 || w.itemsCount() != 6 {
+		t.Errorf("end: index %d, page %d, %d items, want 11 on the last page of 6 items",
+			w.GetGlobalIndex(), w.paginator.Page, w.itemsCount())
+	}
+}
+
+// listScreen shows a filterable list.
+type listScreen struct {
+	list *Widget[string]
+
+	focusManager *orvyn.FocusManager
+}
+
+func (s *listScreen) OnEnter(any) tea.Cmd {
+	s.focusManager.FocusFirst()
+
+	return nil
+}
+
+func (s *listScreen) OnExit() any {
+	return nil
+}
+
+func (s *listScreen) Update(msg tea.Msg) tea.Cmd {
+	return s.focusManager.Update(msg)
+}
+
+func (s *listScreen) Render() orvyn.Layout {
+	return layout.NewVBoxLayout(0, s.list)
+}
+
+func TestHelpKeyTypedInFilter(t *testing.T) {
+	h := orvyntest.New(t, orvyn.NewSize(40, 20))
+
+	s := new(listScreen)
+	s.list = New(SimpleListItemConstructor)
+	s.list.SetItems([]string{"first", "second"})
+
+	s.focusManager = orvyn.NewFocusManager()
+	s.focusManager.Add(s.list)
+
+	h.Register("list", s)
+	h.App.RegisterKeyMap("list", s.focusManager)
+	h.Start("list")
+
+	h.Press("/", "?")
+
+	if h.App.IsHelpVisible() || s.list.FilterState() != Filtering {
+		t.Fatalf("? opened the help while typing the filter")
+	}
+
+	// The filter "?" matches no item.
+	h.Press("enter")
+
+	if s.list.FilterState() != FilterApplied || s.list.GetGlobalIndex() != -1 {
+		t.Errorf("filter state %v, cursor on %d, want ? applied matching nothing",
+			s.list.FilterState(), s.list.GetGlobalIndex())
+	}
+}